@@ -0,0 +1,132 @@
+package algo
+
+import (
+	"cmp"
+	"github.com/ooyeku/algo/algo/structs"
+	"runtime"
+	"time"
+)
+
+// HeapResult represents the result of running the heap workload on one heap implementation.
+// It contains the name of the heap, the time the workload took and the memory it allocated.
+type HeapResult struct {
+	Algorithm string
+	Time      time.Duration
+	Memory    uint64
+}
+
+// HeapBenchmark represents the results of benchmarking the heap implementations.
+// It stores the results, the size of the input list, the name of the fastest heap and the name of
+// the heap that allocated the least memory.
+type HeapBenchmark struct {
+	Results             []HeapResult
+	ListSize            int
+	Fastest             string
+	MostMemoryEfficient string
+}
+
+// CompareHeapAlgorithms benchmarks the heap implementations of package structs on a given list and
+// returns a HeapBenchmark containing the results. It compares a Binary Heap and a 4-ary Heap,
+// both DaryHeaps, a Pairing Heap, a Binomial Heap and a Fibonacci Heap.
+//
+// Every heap runs the same workload, which exercises all operations of the structs.Heap interface
+// the way graph algorithms such as Dijkstra's do: the first half of the list is pushed into one
+// heap and the second half into another, the second heap is melded into the first, the key of
+// every other value is decreased by the length of the list, and finally all values are popped.
+// The time of the whole workload is measured, and the memory is the number of bytes allocated
+// while it ran. After benchmarking is completed, the heap with the shortest time is set as the
+// Fastest field and the heap that allocated the least as the MostMemoryEfficient field.
+func CompareHeapAlgorithms(list []int) HeapBenchmark {
+	benchmark := HeapBenchmark{
+		ListSize: len(list),
+	}
+
+	// Benchmark Binary Heap
+	benchmark.Results = append(benchmark.Results, benchmarkHeap("Binary Heap", list, func() structs.Heap[int] {
+		return structs.NewDaryHeap(2, cmp.Compare[int])
+	}))
+
+	// Benchmark 4-ary Heap
+	benchmark.Results = append(benchmark.Results, benchmarkHeap("4-ary Heap", list, func() structs.Heap[int] {
+		return structs.NewDaryHeap(4, cmp.Compare[int])
+	}))
+
+	// Benchmark Pairing Heap
+	benchmark.Results = append(benchmark.Results, benchmarkHeap("Pairing Heap", list, func() structs.Heap[int] {
+		return structs.NewPairingHeap(cmp.Compare[int])
+	}))
+
+	// Benchmark Binomial Heap
+	benchmark.Results = append(benchmark.Results, benchmarkHeap("Binomial Heap", list, func() structs.Heap[int] {
+		return structs.NewBinomialHeap(cmp.Compare[int])
+	}))
+
+	// Benchmark Fibonacci Heap
+	benchmark.Results = append(benchmark.Results, benchmarkHeap("Fibonacci Heap", list, func() structs.Heap[int] {
+		return structs.NewFibonacciHeap(cmp.Compare[int])
+	}))
+
+	// get the fastest and most memory-efficient heaps
+	fastest := benchmark.Results[0]
+	mostMemoryEfficient := benchmark.Results[0]
+	for _, result := range benchmark.Results {
+		if result.Time < fastest.Time {
+			fastest = result
+		}
+		if result.Memory < mostMemoryEfficient.Memory {
+			mostMemoryEfficient = result
+		}
+	}
+	benchmark.Fastest = fastest.Algorithm
+	benchmark.MostMemoryEfficient = mostMemoryEfficient.Algorithm
+
+	return benchmark
+}
+
+// benchmarkHeap runs the workload described at CompareHeapAlgorithms on heaps created by newHeap
+// and measures its time and the memory it allocates. The memory is taken from the cumulative
+// TotalAlloc counter rather than the live heap size, so that garbage collections during the
+// workload, which the many node allocations of the linked heaps make likely, do not distort it.
+func benchmarkHeap(name string, list []int, newHeap func() structs.Heap[int]) HeapResult {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	memBefore := m.TotalAlloc
+
+	start := time.Now()
+	heapWorkload(list, newHeap)
+	duration := time.Since(start)
+
+	runtime.ReadMemStats(&m)
+	memAfter := m.TotalAlloc
+
+	return HeapResult{
+		Algorithm: name,
+		Time:      duration,
+		Memory:    memAfter - memBefore,
+	}
+}
+
+// heapWorkload pushes the two halves of the list into two heaps, melds them, decreases the key of
+// every other value by the length of the list and pops all values, returning them in the order
+// they were popped.
+func heapWorkload(list []int, newHeap func() structs.Heap[int]) []int {
+	h, other := newHeap(), newHeap()
+	nodes := make([]structs.HeapNode[int], len(list))
+	half := len(list) / 2
+	for i, v := range list {
+		if i < half {
+			nodes[i] = h.Push(v)
+		} else {
+			nodes[i] = other.Push(v)
+		}
+	}
+	h.Meld(other)
+	for i := 0; i < len(nodes); i += 2 {
+		h.DecreaseKey(nodes[i], list[i]-len(list))
+	}
+	popped := make([]int, 0, len(list))
+	for v, ok := h.Pop(); ok; v, ok = h.Pop() {
+		popped = append(popped, v)
+	}
+	return popped
+}
@@ -2,98 +2,138 @@ package algo
 
 import (
 	"github.com/ooyeku/algo/algo/searching"
-	"math/rand"
 	"runtime"
+	"sort"
 	"time"
 )
 
 // SearchResult represents the result of a search operation.
+// When a whole workload is searched, Index holds the result of the first query,
+// Queries the number of queries issued and Hits the number of queries that were found.
 type SearchResult struct {
 	Algorithm string
 	Target    interface{}
 	Index     int
+	Queries   int
+	Hits      int
 	Time      time.Duration
 	Memory    uint64
 }
 
 // SearchBenchmark represents the result of a search algorithm benchmark.
 // It contains an array of SearchResult, the size of the list being searched,
-// and the name of the fastest search algorithm.
+// the workload that was searched for and the name of the fastest search algorithm.
 type SearchBenchmark struct {
 	Results  []SearchResult
 	ListSize int
+	Workload SearchWorkload
 	Fastest  string
 }
 
-// CompareSearchAlgorithms compares the performance of three different search algorithms:
-// Binary Search, Linear Search, and Jump Search. It takes a list of integers as input
-// and returns a SearchBenchmark struct that contains the results of each algorithm,
-// the size of the list, and the name of the fastest algorithm.
-//
-// The function first selects a random target from the list. Then, it benchmarks the
-// Binary Search algorithm, Linear Search algorithm, and Jump Search algorithm by
-// calling the benchmarkSearch function for each algorithm. The results of each algorithm
-// are appended to the Results field of the SearchBenchmark struct.
-//
-// After benchmarking is completed, the function determines the fastest algorithm
-// by comparing the execution time of each algorithm in the benchmark results. The
-// algorithm with the shortest execution time is set as the Fastest field of the
-// SearchBenchmark struct.
-//
-// The function returns the SearchBenchmark struct with all the benchmark results.
-//
-// SearchBenchmark struct:
-//
-//	type SearchBenchmark struct {
-//	    Results  []SearchResult
-//	    ListSize int
-//	    Fastest  string
-//	}
-//
-// benchmarkSearch function:
-//
-//	func benchmarkSearch(name string, searchFunc func() int) SearchResult {
-//	    ...
-//	}
-//
-// BinarySearch function:
-//
-//	func BinarySearch(arr []int, target int) int {
-//	    ...
-//	}
-//
-// LinearSearch function:
+// QueryDistribution describes how the hits of a SearchWorkload are spread over the list.
+type QueryDistribution int
+
+// UniformQueries picks every hit uniformly at random from the list.
+// SkewedQueries picks hits from a Zipf distribution, so a few elements at the front of
+// the list receive most of the lookups.
+// SequentialQueries picks hits uniformly at random but issues all queries in ascending order.
+const (
+	UniformQueries QueryDistribution = iota
+	SkewedQueries
+	SequentialQueries
+)
+
+// SearchWorkload describes the queries issued by a search benchmark.
+// Queries is the number of lookups, HitRatio the fraction of them (between 0 and 1)
+// that target a value present in the list, and Distribution how the hits are spread.
+type SearchWorkload struct {
+	Queries      int
+	HitRatio     float64
+	Distribution QueryDistribution
+}
+
+// CompareSearchAlgorithms compares the performance of the search algorithms on a single
+// random target taken from the list. It is shorthand for CompareSearchAlgorithmsWorkload
+// with a workload of one query that always hits.
 //
-//	func LinearSearch(slice []int, target int) int {
-//	    ...
-//	}
+// The list must be sorted in ascending order. The function returns a SearchBenchmark
+// struct that contains the results of each algorithm, the size of the list, and the
+// name of the fastest algorithm.
+func CompareSearchAlgorithms(list []int) SearchBenchmark {
+	return CompareSearchAlgorithmsWorkload(list, SearchWorkload{
+		Queries:      1,
+		HitRatio:     1,
+		Distribution: UniformQueries,
+	})
+}
+
+// CompareSearchAlgorithmsWorkload compares the performance of the search algorithms on a
+// query workload. The queries are generated once with GenerateSearchWorkload and every
+// algorithm answers the same queries, so the results are directly comparable.
 //
-// JumpSearch function:
+// Binary Search, Linear Search and Jump Search look up each query independently.
+// Batch Merge Search and Batch Gallop Search first sort a copy of the queries and then
+// answer all of them in a single pass over the list; the time spent sorting the queries
+// is included in their measurements. Eytzinger Search and Static B+ Tree Search look up
+// each query in a cache-friendly copy of the list; the copies are built before timing
+// starts, as they are meant to be built once and queried many times.
 //
-//	func JumpSearch(arr []int, x int) int {
-//	    ...
-//	}
-func CompareSearchAlgorithms(list []int) SearchBenchmark {
+// The list must be sorted in ascending order. After benchmarking is completed, the
+// algorithm with the shortest execution time is set as the Fastest field of the
+// returned SearchBenchmark.
+func CompareSearchAlgorithmsWorkload(list []int, workload SearchWorkload) SearchBenchmark {
 	benchmark := SearchBenchmark{
 		ListSize: len(list),
+		Workload: workload,
 	}
 
-	// Choose a random target from the list
-	target := list[rand.Intn(len(list))]
+	queries := GenerateSearchWorkload(list, workload)
 
 	// Benchmark Binary Search
-	benchmark.Results = append(benchmark.Results, benchmarkSearch("Binary Search", func() int {
-		return searching.BinarySearch(list, target)
+	benchmark.Results = append(benchmark.Results, benchmarkSearch("Binary Search", func() []int {
+		return searchEach(queries, func(target int) int {
+			return searching.BinarySearch(list, target)
+		})
 	}))
 
 	// Benchmark Linear Search
-	benchmark.Results = append(benchmark.Results, benchmarkSearch("Linear Search", func() int {
-		return searching.LinearSearch(list, target)
+	benchmark.Results = append(benchmark.Results, benchmarkSearch("Linear Search", func() []int {
+		return searchEach(queries, func(target int) int {
+			return searching.LinearSearch(list, target)
+		})
 	}))
 
 	// Benchmark Jump Search
-	benchmark.Results = append(benchmark.Results, benchmarkSearch("Jump Search", func() int {
-		return searching.JumpSearch(list, target)
+	benchmark.Results = append(benchmark.Results, benchmarkSearch("Jump Search", func() []int {
+		return searchEach(queries, func(target int) int {
+			return searching.JumpSearch(list, target)
+		})
+	}))
+
+	// Benchmark Batch Merge Search
+	benchmark.Results = append(benchmark.Results, benchmarkSearch("Batch Merge Search", func() []int {
+		sorted := append([]int(nil), queries...)
+		sort.Ints(sorted)
+		return searching.BatchMergeSearch(list, sorted)
+	}))
+
+	// Benchmark Batch Gallop Search
+	benchmark.Results = append(benchmark.Results, benchmarkSearch("Batch Gallop Search", func() []int {
+		sorted := append([]int(nil), queries...)
+		sort.Ints(sorted)
+		return searching.BatchGallopSearch(list, sorted)
+	}))
+
+	// Benchmark Eytzinger Search
+	eytzinger := searching.NewEytzinger(list)
+	benchmark.Results = append(benchmark.Results, benchmarkSearch("Eytzinger Search", func() []int {
+		return searchEach(queries, eytzinger.Search)
+	}))
+
+	// Benchmark Static B+ Tree Search
+	bplusTree := searching.NewStaticBPlusTree(list)
+	benchmark.Results = append(benchmark.Results, benchmarkSearch("Static B+ Tree Search", func() []int {
+		return searchEach(queries, bplusTree.Search)
 	}))
 
 	// get the fastest search algorithm
@@ -108,41 +148,55 @@ func CompareSearchAlgorithms(list []int) SearchBenchmark {
 	return benchmark
 }
 
+// searchEach answers every query with the given single-target search function and
+// returns the results in query order.
+func searchEach(queries []int, search func(target int) int) []int {
+	result := make([]int, len(queries))
+	for i, target := range queries {
+		result[i] = search(target)
+	}
+	return result
+}
+
 // benchmarkSearch measures the performance of a search algorithm by timing its execution
 // and measuring its memory usage.
 //
 // It takes a name string and a searchFunc function as input, where the searchFunc function
-// performs the search algorithm. The searchFunc function should return the index of the target
-// if found, or -1 if not found.
+// answers all queries of a workload. The searchFunc function should return one index per
+// query, or -1 for queries that were not found.
 //
 // benchmarkSearch records the memory usage before and after executing the search algorithm,
 // as well as the time taken to execute the algorithm. It returns a SearchResult struct
-// that contains the algorithm name, index, time, and memory usage.
-//
-// The SearchResult struct has the following fields:
-// - Algorithm: The name of the search algorithm.
-// - Target: The target value being searched.
-// - Index: The index of the target if found, or -1 if not found.
-// - Time: The duration of time taken to execute the search algorithm.
-// - Memory: The difference in memory usage before and after executing the search algorithm.
-func benchmarkSearch(name string, searchFunc func() int) SearchResult {
+// that contains the algorithm name, the index found for the first query, the number of
+// queries and hits, the time, and the memory usage.
+func benchmarkSearch(name string, searchFunc func() []int) SearchResult {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 	memBefore := m.Alloc
 
 	start := time.Now()
-	index := searchFunc()
+	indices := searchFunc()
 	duration := time.Since(start)
 
 	runtime.ReadMemStats(&m)
 	memAfter := m.Alloc
 
-	return SearchResult{
+	result := SearchResult{
 		Algorithm: name,
-		Index:     index,
+		Index:     -1,
+		Queries:   len(indices),
 		Time:      duration,
 		Memory:    memAfter - memBefore,
 	}
+	if len(indices) > 0 {
+		result.Index = indices[0]
+	}
+	for _, index := range indices {
+		if index != -1 {
+			result.Hits++
+		}
+	}
+	return result
 }
 
 // CompareSearchAlgorithmsGeneric compares the performance of three generic search algorithms:
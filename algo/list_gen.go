@@ -1,6 +1,10 @@
 package algo
 
-import "math/rand"
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
 
 // GenerateList generates a list of random integers with the specified length, minimum value, and maximum value.
 func GenerateList(length int, min int, max int) []int {
@@ -38,3 +42,88 @@ func GenerateListGeneric(length int, min int, max int) []interface{} {
 	}
 	return list
 }
+
+// GenerateSearchWorkload generates the queries described by workload for the given list.
+// Each query is a hit, i.e. a value taken from the list, with probability HitRatio and a
+// miss, i.e. a value that does not occur in the list, otherwise. Hits are picked according
+// to the workload's Distribution; for SequentialQueries the returned queries are sorted in
+// ascending order. If the list is empty, every query is a miss. A negative number of queries
+// is treated as zero.
+func GenerateSearchWorkload(list []int, workload SearchWorkload) []int {
+	queries := make([]int, max(workload.Queries, 0))
+	if len(list) == 0 {
+		for i := range queries {
+			queries[i] = rand.Int()
+		}
+		return queries
+	}
+
+	present := make(map[int]struct{}, len(list))
+	lo, hi := list[0], list[0]
+	for _, v := range list {
+		present[v] = struct{}{}
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+
+	var zipf *rand.Zipf
+	if workload.Distribution == SkewedQueries && len(list) > 1 {
+		zipf = rand.NewZipf(rand.New(rand.NewSource(rand.Int63())), 1.1, 1, uint64(len(list)-1))
+	}
+
+	for i := range queries {
+		if rand.Float64() < workload.HitRatio {
+			if zipf != nil {
+				queries[i] = list[zipf.Uint64()]
+			} else {
+				queries[i] = list[rand.Intn(len(list))]
+			}
+			continue
+		}
+		queries[i] = generateMiss(present, lo, hi)
+	}
+
+	if workload.Distribution == SequentialQueries {
+		sort.Ints(queries)
+	}
+	return queries
+}
+
+// generateMiss returns a value that is not in present. It first tries a few random values
+// between lo and hi, so that misses fall inside the searched range, and otherwise returns
+// a value just above hi, or just below lo if the values above hi would overflow.
+//
+// The width of the range is computed in uint64, where hi-lo+1 cannot overflow; it wraps to
+// zero only when the range covers every int, and then any int is a candidate.
+func generateMiss(present map[int]struct{}, lo, hi int) int {
+	span := uint64(hi-lo) + 1
+	for try := 0; try < 8; try++ {
+		v := int(rand.Uint64())
+		if span != 0 {
+			v = lo + int(rand.Uint64()%span)
+		}
+		if _, ok := present[v]; !ok {
+			return v
+		}
+	}
+	gap := len(present) + 1
+	switch {
+	case hi <= math.MaxInt-gap:
+		return hi + 1 + rand.Intn(gap)
+	case lo >= math.MinInt+gap:
+		return lo - 1 - rand.Intn(gap)
+	}
+	// The list holds values near both ends of the int range. Some value below hi is missing,
+	// since present holds fewer values than there are ints.
+	v := hi
+	for {
+		if _, ok := present[v]; !ok {
+			return v
+		}
+		v--
+	}
+}
@@ -0,0 +1,75 @@
+package algo
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestGenerateSearchWorkload(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     []int
+		workload SearchWorkload
+	}{
+		{"AllHits", []int{1, 3, 5, 7, 9}, SearchWorkload{Queries: 100, HitRatio: 1, Distribution: UniformQueries}},
+		{"AllMisses", []int{1, 3, 5, 7, 9}, SearchWorkload{Queries: 100, HitRatio: 0, Distribution: UniformQueries}},
+		{"Skewed", []int{1, 2, 3, 4, 5, 6, 7, 8}, SearchWorkload{Queries: 100, HitRatio: 0.5, Distribution: SkewedQueries}},
+		{"Sequential", []int{10, 20, 30}, SearchWorkload{Queries: 100, HitRatio: 0.5, Distribution: SequentialQueries}},
+		{"EmptyList", nil, SearchWorkload{Queries: 10, HitRatio: 1, Distribution: UniformQueries}},
+		{"NoQueries", []int{1, 2}, SearchWorkload{Queries: 0, HitRatio: 1}},
+		{"NegativeQueries", []int{1, 2}, SearchWorkload{Queries: -5, HitRatio: 1}},
+		{"MinMaxInt", []int{math.MinInt, 0, math.MaxInt}, SearchWorkload{Queries: 100, HitRatio: 0, Distribution: UniformQueries}},
+		{"NearMaxInt", []int{math.MaxInt - 1, math.MaxInt}, SearchWorkload{Queries: 100, HitRatio: 0, Distribution: UniformQueries}},
+		{"NearMinInt", []int{math.MinInt, math.MinInt + 1}, SearchWorkload{Queries: 100, HitRatio: 0, Distribution: UniformQueries}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateSearchWorkload(tt.list, tt.workload)
+			if want := max(tt.workload.Queries, 0); len(got) != want {
+				t.Fatalf("GenerateSearchWorkload() returned %d queries, want %d", len(got), want)
+			}
+			for _, q := range got {
+				hit := slices.Contains(tt.list, q)
+				if tt.workload.HitRatio == 1 && len(tt.list) > 0 && !hit {
+					t.Errorf("query %d is a miss, want only hits", q)
+				}
+				if tt.workload.HitRatio == 0 && hit {
+					t.Errorf("query %d is a hit, want only misses", q)
+				}
+			}
+			if tt.workload.Distribution == SequentialQueries && !slices.IsSorted(got) {
+				t.Errorf("sequential queries are not sorted: %v", got)
+			}
+		})
+	}
+}
+
+func TestGenerateMiss(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int
+	}{
+		{"Dense", []int{1, 2, 3, 4, 5}},
+		{"FullRange", []int{math.MinInt, math.MaxInt}},
+		{"AtMaxInt", []int{math.MaxInt - 2, math.MaxInt - 1, math.MaxInt}},
+		{"AtMinInt", []int{math.MinInt, math.MinInt + 1, math.MinInt + 2}},
+		{"BothEnds", []int{math.MinInt, math.MinInt + 1, math.MaxInt - 1, math.MaxInt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			present := make(map[int]struct{}, len(tt.values))
+			for _, v := range tt.values {
+				present[v] = struct{}{}
+			}
+			lo, hi := slices.Min(tt.values), slices.Max(tt.values)
+			for i := 0; i < 100; i++ {
+				if v := generateMiss(present, lo, hi); slices.Contains(tt.values, v) {
+					t.Fatalf("generateMiss() = %d, which is present", v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package searching
+
+// BatchMergeSearch looks up every value of queries in the sorted slice arr in a single
+// merge-style pass, like intersecting two sorted lists. It returns a slice with one entry
+// per query holding the index of the first occurrence of that query in arr, or -1 if the
+// query is not present.
+//
+// The pass costs O(n + m) when queries is sorted in ascending order. Unsorted queries are
+// still answered correctly: whenever a query is smaller than its predecessor the scan
+// restarts from the beginning of arr, which degrades towards O(n * m).
+func BatchMergeSearch(arr []int, queries []int) []int {
+	result := make([]int, len(queries))
+	i := 0
+	for q, target := range queries {
+		if q > 0 && target < queries[q-1] {
+			i = 0
+		}
+		for i < len(arr) && arr[i] < target {
+			i++
+		}
+		if i < len(arr) && arr[i] == target {
+			result[q] = i
+		} else {
+			result[q] = -1
+		}
+	}
+	return result
+}
+
+// BatchMergeSearchGeneric is the generic form of BatchMergeSearch. The less function
+// defines the ordering of both arr and queries; two values are considered equal when
+// neither is less than the other.
+func BatchMergeSearchGeneric[T any](arr []T, queries []T, less func(T, T) bool) []int {
+	result := make([]int, len(queries))
+	i := 0
+	for q, target := range queries {
+		if q > 0 && less(target, queries[q-1]) {
+			i = 0
+		}
+		for i < len(arr) && less(arr[i], target) {
+			i++
+		}
+		if i < len(arr) && !less(target, arr[i]) {
+			result[q] = i
+		} else {
+			result[q] = -1
+		}
+	}
+	return result
+}
+
+// BatchGallopSearch looks up every value of queries in the sorted slice arr and returns
+// the index of the first occurrence of each query, or -1 if it is not present.
+//
+// Instead of stepping through arr one element at a time, the search gallops from the
+// position of the previous answer: it probes 1, 2, 4, ... elements ahead until it passes
+// the query and then binary searches inside the last gap. For m sorted queries this costs
+// O(m log(n/m)), which beats both m independent binary searches and a linear merge when
+// the queries are sparse. A query smaller than its predecessor restarts the gallop from
+// the beginning of arr, so unsorted queries are still answered correctly.
+func BatchGallopSearch(arr []int, queries []int) []int {
+	return BatchGallopSearchGeneric(arr, queries, func(a, b int) bool {
+		return a < b
+	})
+}
+
+// BatchGallopSearchGeneric is the generic form of BatchGallopSearch. The less function
+// defines the ordering of both arr and queries; two values are considered equal when
+// neither is less than the other.
+func BatchGallopSearchGeneric[T any](arr []T, queries []T, less func(T, T) bool) []int {
+	result := make([]int, len(queries))
+	lo := 0
+	for q, target := range queries {
+		if q > 0 && less(target, queries[q-1]) {
+			lo = 0
+		}
+		lo = gallop(arr, lo, target, less)
+		if lo < len(arr) && !less(target, arr[lo]) {
+			result[q] = lo
+		} else {
+			result[q] = -1
+		}
+	}
+	return result
+}
+
+// gallop returns the smallest index i >= lo such that arr[i] is not less than target,
+// or len(arr) if there is none. It doubles its step until it overshoots the target and
+// then binary searches the final interval.
+func gallop[T any](arr []T, lo int, target T, less func(T, T) bool) int {
+	n := len(arr)
+	step := 1
+	hi := lo
+	for hi < n && less(arr[hi], target) {
+		lo = hi + 1
+		hi += step
+		step *= 2
+	}
+	if hi > n {
+		hi = n
+	}
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if less(arr[mid], target) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
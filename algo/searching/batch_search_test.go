@@ -0,0 +1,68 @@
+package searching
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBatchSearch(t *testing.T) {
+	tt := []struct {
+		name     string
+		arr      []int
+		queries  []int
+		expected []int
+	}{
+		{"EmptyArray", []int{}, []int{1, 2}, []int{-1, -1}},
+		{"NoQueries", []int{1, 2, 3}, []int{}, []int{}},
+		{"AllFound", []int{1, 3, 5, 7, 9}, []int{1, 5, 9}, []int{0, 2, 4}},
+		{"SomeMissing", []int{1, 3, 5, 7, 9}, []int{0, 3, 4, 9, 10}, []int{-1, 1, -1, 4, -1}},
+		{"Duplicates", []int{1, 2, 2, 2, 3}, []int{2, 2, 3}, []int{1, 1, 4}},
+		{"Unsorted", []int{1, 3, 5, 7, 9}, []int{9, 1, 7, 3}, []int{4, 0, 3, 1}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			less := func(a, b int) bool { return a < b }
+			if got := BatchMergeSearch(tc.arr, tc.queries); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("BatchMergeSearch: expected %v but got %v", tc.expected, got)
+			}
+			if got := BatchMergeSearchGeneric(tc.arr, tc.queries, less); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("BatchMergeSearchGeneric: expected %v but got %v", tc.expected, got)
+			}
+			if got := BatchGallopSearch(tc.arr, tc.queries); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("BatchGallopSearch: expected %v but got %v", tc.expected, got)
+			}
+			if got := BatchGallopSearchGeneric(tc.arr, tc.queries, less); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("BatchGallopSearchGeneric: expected %v but got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestBatchSearchRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	arr := make([]int, 1000)
+	for i := range arr {
+		arr[i] = 2 * i
+	}
+	queries := make([]int, 200)
+	for i := range queries {
+		queries[i] = r.Intn(2 * len(arr))
+	}
+
+	merge := BatchMergeSearch(arr, queries)
+	gallop := BatchGallopSearch(arr, queries)
+	for i, q := range queries {
+		expected := -1
+		if q%2 == 0 {
+			expected = q / 2
+		}
+		if merge[i] != expected {
+			t.Fatalf("BatchMergeSearch(%d): expected %d but got %d", q, expected, merge[i])
+		}
+		if gallop[i] != expected {
+			t.Fatalf("BatchGallopSearch(%d): expected %d but got %d", q, expected, gallop[i])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package searching
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound, ErrUnsorted and ErrEmpty are the errors returned by the validated search
+// functions instead of the -1 sentinel used by the plain search functions.
+// ErrNotFound is returned when the target does not occur in the slice.
+// ErrUnsorted is returned when sortedness verification is requested and the slice is not
+// sorted in ascending order; the concrete error is an *UnsortedError.
+// ErrEmpty is returned when the slice has no elements.
+var (
+	ErrNotFound = errors.New("searching: target not found")
+	ErrUnsorted = errors.New("searching: slice is not sorted")
+	ErrEmpty    = errors.New("searching: slice is empty")
+)
+
+// UnsortedError reports the first position at which a slice violates ascending order,
+// i.e. the smallest Index for which slice[Index] is less than slice[Index-1].
+// It matches ErrUnsorted when inspected with errors.Is.
+type UnsortedError struct {
+	Index int
+}
+
+// Error returns a description of the error including the offending index.
+func (e *UnsortedError) Error() string {
+	return fmt.Sprintf("%s: element at index %d is less than its predecessor", ErrUnsorted, e.Index)
+}
+
+// Is reports whether target is ErrUnsorted, so that errors.Is(err, ErrUnsorted) holds
+// for every *UnsortedError.
+func (e *UnsortedError) Is(target error) bool {
+	return target == ErrUnsorted
+}
@@ -0,0 +1,70 @@
+package searching
+
+import "math/bits"
+
+// Eytzinger is a static search structure that stores a sorted slice of integers in
+// Eytzinger (breadth-first) order: the root of the implicit binary search tree sits at
+// position 1 and the children of position k sit at 2k and 2k+1.
+//
+// A binary search on a plain sorted slice touches positions that are far apart in memory,
+// so almost every probe of a large slice is a cache miss. In the Eytzinger layout the first
+// levels of the tree share a handful of cache lines and the two candidates of the next step
+// are always adjacent, which makes the search considerably faster once the slice no longer
+// fits into the cache. The structure is built once in O(n) and cannot be modified.
+type Eytzinger struct {
+	keys  []int
+	index []int
+}
+
+// NewEytzinger builds an Eytzinger layout of the given slice, which must be sorted in
+// ascending order. The slice is copied and may be modified afterwards.
+func NewEytzinger(sorted []int) *Eytzinger {
+	e := &Eytzinger{
+		keys:  make([]int, len(sorted)+1),
+		index: make([]int, len(sorted)+1),
+	}
+	e.build(sorted, 0, 1)
+	return e
+}
+
+// build fills the subtree rooted at position k with an in-order walk over sorted, starting
+// at sorted[i], and returns the index of the next unused element.
+func (e *Eytzinger) build(sorted []int, i, k int) int {
+	if k < len(e.keys) {
+		i = e.build(sorted, i, 2*k)
+		e.keys[k] = sorted[i]
+		e.index[k] = i
+		i++
+		i = e.build(sorted, i, 2*k+1)
+	}
+	return i
+}
+
+// Len returns the number of elements in the structure.
+func (e *Eytzinger) Len() int {
+	return len(e.keys) - 1
+}
+
+// Search has the same contract as BinarySearch on the original sorted slice: it returns the
+// index of the target in that slice, or -1 if not found. If the target occurs more than once,
+// the index of its first occurrence is returned.
+//
+// The search descends the implicit tree without ever leaving the loop early, going right
+// whenever the current key is less than the target. The path taken is encoded in the bits of
+// the final position; stripping the trailing right turns and the last left turn yields the
+// position of the smallest key not less than the target.
+func (e *Eytzinger) Search(target int) int {
+	k := 1
+	for k < len(e.keys) {
+		if e.keys[k] < target {
+			k = 2*k + 1
+		} else {
+			k = 2 * k
+		}
+	}
+	k >>= bits.TrailingZeros(^uint(k)) + 1
+	if k != 0 && e.keys[k] == target {
+		return e.index[k]
+	}
+	return -1
+}
@@ -0,0 +1,96 @@
+package searching
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestEytzinger(t *testing.T) {
+	tt := []struct {
+		name     string
+		arr      []int
+		target   int
+		expected int
+	}{
+		{"One", []int{1, 2, 3, 4, 5}, 1, 0},
+		{"Two", []int{1, 2, 3, 4, 5}, 2, 1},
+		{"Middle", []int{1, 2, 3, 4, 5}, 3, 2},
+		{"Four", []int{1, 2, 3, 4, 5}, 4, 3},
+		{"End", []int{1, 2, 3, 4, 5}, 5, 4},
+		{"Duplicates", []int{1, 2, 2, 2, 5}, 2, 1},
+		{"Empty", []int{}, 5, -1},
+		{"NotFound", []int{1, 2, 3, 4, 5}, 6, -1},
+		{"NotFoundBelow", []int{1, 2, 3, 4, 5}, 0, -1},
+		{"NotFoundBetween", []int{1, 3, 5, 7}, 4, -1},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := NewEytzinger(tc.arr)
+			if e.Len() != len(tc.arr) {
+				t.Errorf("Expected length %d but got %d", len(tc.arr), e.Len())
+			}
+			if got := e.Search(tc.target); got != tc.expected {
+				t.Errorf("Expected %d but got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEytzingerRandom(t *testing.T) {
+	checkStaticSearch(t, func(sorted []int) func(int) int {
+		return NewEytzinger(sorted).Search
+	})
+}
+
+// checkStaticSearch compares a static search structure against a linear scan on random
+// sorted slices of many sizes, including duplicates and targets outside the value range.
+func checkStaticSearch(t *testing.T, build func(sorted []int) func(int) int) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 15, 16, 17, 100, 272, 289, 1000, 5000} {
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = r.Intn(2*n + 1)
+		}
+		sort.Ints(arr)
+		search := build(arr)
+		for target := -1; target <= 2*n+2; target++ {
+			expected := -1
+			for i, v := range arr {
+				if v == target {
+					expected = i
+					break
+				}
+			}
+			if got := search(target); got != expected {
+				t.Fatalf("n=%d: Search(%d): expected %d but got %d", n, target, expected, got)
+			}
+		}
+	}
+}
+
+func benchmarkSortedSlice() []int {
+	slice := make([]int, 1<<22)
+	for i := range slice {
+		slice[i] = 2 * i
+	}
+	return slice
+}
+
+func BenchmarkBinarySearch(b *testing.B) {
+	slice := benchmarkSortedSlice()
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BinarySearch(slice, r.Intn(2*len(slice)))
+	}
+}
+
+func BenchmarkEytzingerSearch(b *testing.B) {
+	e := NewEytzinger(benchmarkSortedSlice())
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.Search(r.Intn(2 * e.Len()))
+	}
+}
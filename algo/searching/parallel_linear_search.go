@@ -0,0 +1,145 @@
+package searching
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// SearchMode selects which matches a parallel search reports.
+type SearchMode int
+
+// FirstIndex reports the lowest index holding the target, exactly like the sequential search.
+// AnyIndex reports the index of whichever match a worker finds first and stops all other
+// workers immediately; the result is not necessarily the lowest index.
+// AllIndices reports every index holding the target in ascending order.
+const (
+	FirstIndex SearchMode = iota
+	AnyIndex
+	AllIndices
+)
+
+// checkInterval is the number of elements a worker scans between checks for early termination.
+const checkInterval = 1024
+
+// ParallelLinearSearch performs a linear search on an integer slice using the given number
+// of goroutines. It returns the lowest index of the target, or -1 if the target is not found.
+// A non-positive workers value uses runtime.GOMAXPROCS(0) goroutines.
+func ParallelLinearSearch(slice []int, target int, workers int) int {
+	return ParallelLinearSearchGeneric(slice, target, workers)
+}
+
+// ParallelLinearSearchString performs a linear search on a string slice using the given number
+// of goroutines. It returns the lowest index of the target, or -1 if the target is not found.
+// A non-positive workers value uses runtime.GOMAXPROCS(0) goroutines.
+func ParallelLinearSearchString(slice []string, target string, workers int) int {
+	return ParallelLinearSearchGeneric(slice, target, workers)
+}
+
+// ParallelLinearSearchGeneric performs a linear search on a generic slice using the given number
+// of goroutines. It returns the lowest index of the target, or -1 if the target is not found.
+// A non-positive workers value uses runtime.GOMAXPROCS(0) goroutines.
+func ParallelLinearSearchGeneric[T comparable](slice []T, target T, workers int) int {
+	if found := ParallelLinearSearchMode(slice, target, workers, FirstIndex); len(found) > 0 {
+		return found[0]
+	}
+	return -1 // Return -1 if the target is not found
+}
+
+// ParallelLinearSearchMode splits the slice into one contiguous chunk per worker and scans the
+// chunks concurrently. It returns the indices reported by the given mode: at most one index
+// for FirstIndex and AnyIndex, every matching index for AllIndices, and an empty slice if the
+// target is not found.
+//
+// Workers stop early as soon as the result is known. In AnyIndex mode the first match cancels
+// a shared context that every worker polls. In FirstIndex mode a match is published as the best
+// index so far and only workers scanning beyond it stop, because a worker on an earlier chunk
+// may still find a lower index. AllIndices always scans the whole slice.
+//
+// A non-positive workers value uses runtime.GOMAXPROCS(0) goroutines; small slices are searched
+// with fewer goroutines than requested so that every worker gets at least one element.
+func ParallelLinearSearchMode[T comparable](slice []T, target T, workers int, mode SearchMode) []int {
+	n := len(slice)
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, n)
+	if workers <= 1 {
+		return append([]int{}, linearSearchRange(context.Background(), slice, target, 0, n, mode, nil)...)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var best atomic.Int64
+	best.Store(int64(n))
+
+	chunk := (n + workers - 1) / workers
+	results := make([][]int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo := w * chunk
+		hi := min(lo+chunk, n)
+		if lo >= hi {
+			break
+		}
+		wg.Add(1)
+		go func(w, lo, hi int) {
+			defer wg.Done()
+			results[w] = linearSearchRange(ctx, slice, target, lo, hi, mode, &best)
+			if mode == AnyIndex && len(results[w]) > 0 {
+				cancel()
+			}
+		}(w, lo, hi)
+	}
+	wg.Wait()
+
+	found := make([]int, 0)
+	for _, r := range results {
+		if len(r) == 0 {
+			continue
+		}
+		if mode != AllIndices {
+			return r
+		}
+		found = append(found, r...)
+	}
+	return found
+}
+
+// linearSearchRange scans slice[lo:hi] for target and returns the matches for the given mode.
+// Every checkInterval elements it stops if ctx has been cancelled or, in FirstIndex mode, if
+// best already holds an index lower than the current position. A match in FirstIndex mode
+// lowers best so that workers on later chunks can stop.
+func linearSearchRange[T comparable](ctx context.Context, slice []T, target T, lo, hi int, mode SearchMode, best *atomic.Int64) []int {
+	var found []int
+	for start := lo; start < hi; start += checkInterval {
+		if ctx.Err() != nil {
+			return found
+		}
+		if mode == FirstIndex && best != nil && best.Load() < int64(start) {
+			return found
+		}
+		end := min(start+checkInterval, hi)
+		for i := start; i < end; i++ {
+			if slice[i] != target {
+				continue
+			}
+			if mode == AllIndices {
+				found = append(found, i)
+				continue
+			}
+			if mode == FirstIndex && best != nil {
+				for {
+					cur := best.Load()
+					if int64(i) >= cur || best.CompareAndSwap(cur, int64(i)) {
+						break
+					}
+				}
+			}
+			return []int{i}
+		}
+	}
+	return found
+}
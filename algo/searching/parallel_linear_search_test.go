@@ -0,0 +1,135 @@
+package searching
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParallelLinearSearch(t *testing.T) {
+	large := make([]int, 10000)
+	for i := range large {
+		large[i] = i % 1000
+	}
+
+	tests := []struct {
+		name    string
+		slice   []int
+		target  int
+		workers int
+		want    int
+	}{
+		{"Empty slice", []int{}, 5, 4, -1},
+		{"Target at beginning", []int{5, 3, 2, 1}, 5, 2, 0},
+		{"Target at end", []int{1, 2, 3, 5}, 5, 2, 3},
+		{"Target in middle", []int{1, 5, 2}, 5, 8, 1},
+		{"Target not present", []int{1, 2, 3}, 5, 2, -1},
+		{"Default workers", []int{1, 2, 3, 5}, 5, 0, 3},
+		{"First of many", large, 999, 8, 999},
+		{"Large not present", large, 1000, 8, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParallelLinearSearch(tt.slice, tt.target, tt.workers); got != tt.want {
+				t.Errorf("ParallelLinearSearch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParallelLinearSearchString(t *testing.T) {
+	tests := []struct {
+		name   string
+		slice  []string
+		target string
+		want   int
+	}{
+		{"Empty slice", []string{}, "5", -1},
+		{"Target at beginning", []string{"5", "3", "2", "1"}, "5", 0},
+		{"Target at end", []string{"1", "2", "3", "5"}, "5", 3},
+		{"Duplicates", []string{"1", "5", "2", "5"}, "5", 1},
+		{"Target not present", []string{"1", "2", "3"}, "5", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParallelLinearSearchString(tt.slice, tt.target, 3); got != tt.want {
+				t.Errorf("ParallelLinearSearchString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParallelLinearSearchMode(t *testing.T) {
+	slice := make([]int, 5000)
+	slice[10] = 7
+	slice[2600] = 7
+	slice[4999] = 7
+
+	tests := []struct {
+		name string
+		mode SearchMode
+		want []int
+	}{
+		{"FirstIndex", FirstIndex, []int{10}},
+		{"AllIndices", AllIndices, []int{10, 2600, 4999}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParallelLinearSearchMode(slice, 7, 4, tt.mode); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParallelLinearSearchMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("AnyIndex", func(t *testing.T) {
+		got := ParallelLinearSearchMode(slice, 7, 4, AnyIndex)
+		if len(got) != 1 || slice[got[0]] != 7 {
+			t.Errorf("ParallelLinearSearchMode() = %v, want one index holding 7", got)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		for _, mode := range []SearchMode{FirstIndex, AnyIndex, AllIndices} {
+			if got := ParallelLinearSearchMode(slice, 8, 4, mode); len(got) != 0 {
+				t.Errorf("ParallelLinearSearchMode(%v) = %v, want empty", mode, got)
+			}
+		}
+	})
+}
+
+func benchmarkSlice() []int {
+	slice := make([]int, 1<<22)
+	for i := range slice {
+		slice[i] = i
+	}
+	return slice
+}
+
+func BenchmarkLinearSearch(b *testing.B) {
+	slice := benchmarkSlice()
+	target := len(slice) * 3 / 4
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		LinearSearch(slice, target)
+	}
+}
+
+func BenchmarkParallelLinearSearch(b *testing.B) {
+	slice := benchmarkSlice()
+	target := len(slice) * 3 / 4
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParallelLinearSearch(slice, target, 0)
+	}
+}
+
+func BenchmarkParallelLinearSearchAny(b *testing.B) {
+	slice := benchmarkSlice()
+	target := len(slice) * 3 / 4
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParallelLinearSearchMode(slice, target, 0, AnyIndex)
+	}
+}
@@ -0,0 +1,65 @@
+package searching
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestStaticBPlusTree(t *testing.T) {
+	tt := []struct {
+		name     string
+		arr      []int
+		target   int
+		expected int
+	}{
+		{"One", []int{1, 2, 3, 4, 5}, 1, 0},
+		{"Middle", []int{1, 2, 3, 4, 5}, 3, 2},
+		{"End", []int{1, 2, 3, 4, 5}, 5, 4},
+		{"Duplicates", []int{1, 2, 2, 2, 5}, 2, 1},
+		{"Empty", []int{}, 5, -1},
+		{"NotFound", []int{1, 2, 3, 4, 5}, 6, -1},
+		{"NotFoundBelow", []int{1, 2, 3, 4, 5}, 0, -1},
+		{"MaxInt", []int{1, math.MaxInt, math.MaxInt}, math.MaxInt, 1},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewStaticBPlusTree(tc.arr)
+			if tree.Len() != len(tc.arr) {
+				t.Errorf("Expected length %d but got %d", len(tc.arr), tree.Len())
+			}
+			if got := tree.Search(tc.target); got != tc.expected {
+				t.Errorf("Expected %d but got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestStaticBPlusTreeRandom(t *testing.T) {
+	checkStaticSearch(t, func(sorted []int) func(int) int {
+		return NewStaticBPlusTree(sorted).Search
+	})
+}
+
+func TestStaticBPlusTreeLargeRuns(t *testing.T) {
+	// Long runs of equal keys span several leaves and internal nodes.
+	arr := make([]int, 3000)
+	for i := range arr {
+		arr[i] = i / 500
+	}
+	tree := NewStaticBPlusTree(arr)
+	for v := 0; v < 6; v++ {
+		if got := tree.Search(v); got != v*500 {
+			t.Errorf("Search(%d): expected %d but got %d", v, v*500, got)
+		}
+	}
+}
+
+func BenchmarkStaticBPlusTreeSearch(b *testing.B) {
+	tree := NewStaticBPlusTree(benchmarkSortedSlice())
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Search(r.Intn(2 * tree.Len()))
+	}
+}
@@ -0,0 +1,192 @@
+package searching
+
+import "sort"
+
+// SuffixArray is a static index over a text that answers substring queries without
+// rescanning the text. It stores the suffix array (the starting offsets of all suffixes
+// of the text in lexicographic order) together with the LCP array, where lcp[i] is the
+// length of the longest common prefix of the suffixes at sa[i-1] and sa[i].
+//
+// The index is built once in O(n log n) using prefix doubling with radix sorting and
+// the LCP array is computed in O(n) with Kasai's algorithm. A pattern of length m is
+// then located in O(m log n). The text is treated as a sequence of bytes.
+type SuffixArray struct {
+	text string
+	sa   []int
+	lcp  []int
+}
+
+// NewSuffixArray builds a suffix array and its LCP array for the given text.
+func NewSuffixArray(text string) *SuffixArray {
+	sa := buildSuffixArray(text)
+	return &SuffixArray{
+		text: text,
+		sa:   sa,
+		lcp:  buildLCP(text, sa),
+	}
+}
+
+// Text returns the text the suffix array was built from.
+func (s *SuffixArray) Text() string {
+	return s.text
+}
+
+// Suffixes returns a copy of the suffix array, i.e. the starting offsets of all
+// suffixes of the text in lexicographic order.
+func (s *SuffixArray) Suffixes() []int {
+	return append([]int{}, s.sa...)
+}
+
+// LCP returns a copy of the LCP array. The first entry is always 0 and entry i holds
+// the length of the longest common prefix of the suffixes at positions i-1 and i of
+// the suffix array.
+func (s *SuffixArray) LCP() []int {
+	return append([]int{}, s.lcp...)
+}
+
+// Lookup returns the offsets of all occurrences of pattern in the text in ascending
+// order. An empty pattern matches at every offset. If the pattern does not occur,
+// an empty slice is returned.
+func (s *SuffixArray) Lookup(pattern string) []int {
+	lo, hi := s.lookupRange(pattern)
+	result := append([]int{}, s.sa[lo:hi]...)
+	sort.Ints(result)
+	return result
+}
+
+// Count returns the number of (possibly overlapping) occurrences of pattern in the text.
+func (s *SuffixArray) Count(pattern string) int {
+	lo, hi := s.lookupRange(pattern)
+	return hi - lo
+}
+
+// Contains reports whether pattern occurs in the text.
+func (s *SuffixArray) Contains(pattern string) bool {
+	return s.Count(pattern) > 0
+}
+
+// LongestRepeatedSubstring returns the longest substring that occurs at least twice
+// in the text, or the empty string if no byte is repeated. It is read directly off
+// the maximum entry of the LCP array.
+func (s *SuffixArray) LongestRepeatedSubstring() string {
+	best, at := 0, 0
+	for i, l := range s.lcp {
+		if l > best {
+			best, at = l, s.sa[i]
+		}
+	}
+	return s.text[at : at+best]
+}
+
+// lookupRange returns the half-open range [lo, hi) of the suffix array whose suffixes
+// start with pattern. Both bounds are found by binary search over the sorted suffixes.
+func (s *SuffixArray) lookupRange(pattern string) (int, int) {
+	n := len(s.sa)
+	lo := sort.Search(n, func(i int) bool {
+		return s.text[s.sa[i]:] >= pattern
+	})
+	hi := lo + sort.Search(n-lo, func(i int) bool {
+		suffix := s.text[s.sa[lo+i]:]
+		return len(suffix) < len(pattern) || suffix[:len(pattern)] != pattern
+	})
+	return lo, hi
+}
+
+// buildSuffixArray sorts the suffixes of text by prefix doubling. After the round with
+// step k every suffix is ranked by its first 2k bytes; each round sorts the (rank[i],
+// rank[i+k]) pairs with two stable counting sort passes, so a round costs O(n) and at
+// most log n rounds are needed.
+func buildSuffixArray(text string) []int {
+	n := len(text)
+	sa := make([]int, n)
+	if n == 0 {
+		return sa
+	}
+	rank := make([]int, n)
+	tmp := make([]int, n)
+	for i := 0; i < n; i++ {
+		sa[i] = i
+		rank[i] = int(text[i]) + 1
+	}
+	bound := n
+	if bound < 256 {
+		bound = 256
+	}
+	bound += 2
+
+	for k := 1; ; k <<= 1 {
+		second := func(i int) int {
+			if i+k < n {
+				return rank[i+k]
+			}
+			return 0
+		}
+		first := func(i int) int {
+			return rank[i]
+		}
+		sa = countingSortBy(sa, second, bound)
+		sa = countingSortBy(sa, first, bound)
+
+		tmp[sa[0]] = 1
+		for i := 1; i < n; i++ {
+			tmp[sa[i]] = tmp[sa[i-1]]
+			if rank[sa[i]] != rank[sa[i-1]] || second(sa[i]) != second(sa[i-1]) {
+				tmp[sa[i]]++
+			}
+		}
+		rank, tmp = tmp, rank
+		if rank[sa[n-1]] == n || k >= n {
+			break
+		}
+	}
+	return sa
+}
+
+// countingSortBy returns the offsets in sa stably sorted by key, whose values must
+// lie in [0, bound).
+func countingSortBy(sa []int, key func(int) int, bound int) []int {
+	count := make([]int, bound)
+	for _, i := range sa {
+		count[key(i)]++
+	}
+	sum := 0
+	for k, c := range count {
+		count[k] = sum
+		sum += c
+	}
+	out := make([]int, len(sa))
+	for _, i := range sa {
+		k := key(i)
+		out[count[k]] = i
+		count[k]++
+	}
+	return out
+}
+
+// buildLCP computes the LCP array of text from its suffix array using Kasai's algorithm.
+// It walks the suffixes in text order and relies on the fact that the common prefix with
+// the lexicographic predecessor shrinks by at most one between consecutive suffixes.
+func buildLCP(text string, sa []int) []int {
+	n := len(sa)
+	lcp := make([]int, n)
+	rank := make([]int, n)
+	for i, p := range sa {
+		rank[p] = i
+	}
+	h := 0
+	for i := 0; i < n; i++ {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && text[i+h] == text[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
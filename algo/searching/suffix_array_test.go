@@ -0,0 +1,119 @@
+package searching
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSuffixArray(t *testing.T) {
+	tt := []struct {
+		name     string
+		text     string
+		suffixes []int
+		lcp      []int
+	}{
+		{"Empty", "", []int{}, []int{}},
+		{"Single", "a", []int{0}, []int{0}},
+		{"Banana", "banana", []int{5, 3, 1, 0, 4, 2}, []int{0, 1, 3, 0, 0, 2}},
+		{"Repeated", "aaaa", []int{3, 2, 1, 0}, []int{0, 1, 2, 3}},
+		{"Mississippi", "mississippi", []int{10, 7, 4, 1, 0, 9, 8, 6, 3, 5, 2}, []int{0, 1, 1, 4, 0, 0, 1, 0, 2, 1, 3}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSuffixArray(tc.text)
+			if got := s.Suffixes(); !reflect.DeepEqual(got, tc.suffixes) {
+				t.Errorf("Expected suffixes %v but got %v", tc.suffixes, got)
+			}
+			if got := s.LCP(); !reflect.DeepEqual(got, tc.lcp) {
+				t.Errorf("Expected LCP %v but got %v", tc.lcp, got)
+			}
+		})
+	}
+}
+
+func TestSuffixArrayLookup(t *testing.T) {
+	s := NewSuffixArray("abracadabra")
+	tt := []struct {
+		name     string
+		pattern  string
+		expected []int
+	}{
+		{"Multiple", "abra", []int{0, 7}},
+		{"SingleByte", "a", []int{0, 3, 5, 7, 10}},
+		{"Once", "cad", []int{4}},
+		{"Suffix", "ra", []int{2, 9}},
+		{"WholeText", "abracadabra", []int{0}},
+		{"NotFound", "abc", []int{}},
+		{"TooLong", "abracadabras", []int{}},
+		{"Empty", "", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := s.Lookup(tc.pattern); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, got)
+			}
+			if got := s.Count(tc.pattern); got != len(tc.expected) {
+				t.Errorf("Expected count %d but got %d", len(tc.expected), got)
+			}
+			if got := s.Contains(tc.pattern); got != (len(tc.expected) > 0) {
+				t.Errorf("Expected contains %v but got %v", len(tc.expected) > 0, got)
+			}
+		})
+	}
+}
+
+func TestSuffixArrayLongestRepeatedSubstring(t *testing.T) {
+	tt := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"Empty", "", ""},
+		{"NoRepeat", "abc", ""},
+		{"Banana", "banana", "ana"},
+		{"Abracadabra", "abracadabra", "abra"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NewSuffixArray(tc.text).LongestRepeatedSubstring(); got != tc.expected {
+				t.Errorf("Expected %q but got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSuffixArrayRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		b := make([]byte, r.Intn(200)+1)
+		for i := range b {
+			b[i] = byte('a' + r.Intn(3))
+		}
+		text := string(b)
+		s := NewSuffixArray(text)
+
+		sa := s.Suffixes()
+		for i := 1; i < len(sa); i++ {
+			if text[sa[i-1]:] >= text[sa[i]:] {
+				t.Fatalf("Suffixes not sorted for %q at %d", text, i)
+			}
+		}
+
+		start := r.Intn(len(text))
+		pattern := text[start : start+r.Intn(len(text)-start)/4+1]
+		expected := []int{}
+		for i := 0; i+len(pattern) <= len(text); i++ {
+			if strings.HasPrefix(text[i:], pattern) {
+				expected = append(expected, i)
+			}
+		}
+		if got := s.Lookup(pattern); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Lookup(%q) in %q: expected %v but got %v", pattern, text, expected, got)
+		}
+	}
+}
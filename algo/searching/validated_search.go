@@ -0,0 +1,145 @@
+package searching
+
+import "math"
+
+// SearchOptions configures the validated search functions.
+// VerifySorted makes BinarySearchValidated and JumpSearchValidated check that the slice is
+// sorted before searching it. The check costs O(n), so it is off by default.
+type SearchOptions struct {
+	VerifySorted bool
+}
+
+// VerifySorted checks that arr is sorted in ascending order. It returns nil if it is, and
+// an *UnsortedError holding the first out-of-order index otherwise.
+func VerifySorted(arr []int) error {
+	return VerifySortedGeneric(arr, func(a, b int) bool {
+		return a < b
+	})
+}
+
+// VerifySortedGeneric checks that arr is sorted in ascending order according to less. It
+// returns nil if it is, and an *UnsortedError holding the first out-of-order index otherwise.
+func VerifySortedGeneric[T any](arr []T, less func(T, T) bool) error {
+	for i := 1; i < len(arr); i++ {
+		if less(arr[i], arr[i-1]) {
+			return &UnsortedError{Index: i}
+		}
+	}
+	return nil
+}
+
+// BinarySearchValidated performs a binary search on a sorted slice of integers.
+// It returns the index of the first occurrence of the target, or -1 together with
+// ErrEmpty if the slice is empty, ErrNotFound if the target is not present, or an
+// *UnsortedError if opts.VerifySorted is set and the slice is not sorted.
+func BinarySearchValidated(arr []int, target int, opts SearchOptions) (int, error) {
+	return BinarySearchGenericValidated(arr, target, func(a, b int) bool {
+		return a < b
+	}, opts)
+}
+
+// BinarySearchGenericValidated performs a binary search on a sorted slice of any type
+// ordered by less. Two values are equal when neither is less than the other. It returns
+// the index of the first occurrence of the target, or -1 together with ErrEmpty,
+// ErrNotFound or, if opts.VerifySorted is set, an *UnsortedError.
+func BinarySearchGenericValidated[T any](arr []T, target T, less func(T, T) bool, opts SearchOptions) (int, error) {
+	if err := validate(arr, less, opts); err != nil {
+		return -1, err
+	}
+
+	left, right := 0, len(arr)
+	for left < right {
+		mid := left + (right-left)/2
+		if less(arr[mid], target) {
+			left = mid + 1
+		} else {
+			right = mid
+		}
+	}
+
+	if left < len(arr) && !less(target, arr[left]) {
+		return left, nil
+	}
+	return -1, ErrNotFound
+}
+
+// JumpSearchValidated performs a jump search on a sorted integer slice.
+// It returns the index of the first occurrence of the target, or -1 together with
+// ErrEmpty if the slice is empty, ErrNotFound if the target is not present, or an
+// *UnsortedError if opts.VerifySorted is set and the slice is not sorted.
+func JumpSearchValidated(arr []int, x int, opts SearchOptions) (int, error) {
+	return JumpSearchGenericValidated(arr, x, func(a, b int) bool {
+		return a < b
+	}, opts)
+}
+
+// JumpSearchGenericValidated performs a jump search on a sorted slice of any type ordered
+// by less. Unlike JumpSearchGeneric, equality is derived from less, so T does not need to
+// be comparable. Every index is bounds-checked, so an unsorted slice yields a wrong answer
+// but never a panic. It returns the index of the first occurrence of the target, or -1
+// together with ErrEmpty, ErrNotFound or, if opts.VerifySorted is set, an *UnsortedError.
+func JumpSearchGenericValidated[T any](arr []T, x T, less func(T, T) bool, opts SearchOptions) (int, error) {
+	if err := validate(arr, less, opts); err != nil {
+		return -1, err
+	}
+
+	n := len(arr)
+	jump := int(math.Sqrt(float64(n)))
+	prev, step := 0, jump
+	for less(arr[min(step, n)-1], x) {
+		prev = step
+		if prev >= n {
+			return -1, ErrNotFound
+		}
+		step += jump
+	}
+
+	for i := prev; i < min(step, n); i++ {
+		if !less(arr[i], x) {
+			if !less(x, arr[i]) {
+				return i, nil
+			}
+			break
+		}
+	}
+	return -1, ErrNotFound
+}
+
+// LinearSearchValidated performs a linear search on an integer slice. It returns the
+// index of the first occurrence of the target, or -1 together with ErrEmpty if the slice
+// is empty or ErrNotFound if the target is not present.
+func LinearSearchValidated(slice []int, target int) (int, error) {
+	return LinearSearchGenericValidated(slice, target)
+}
+
+// LinearSearchStringValidated performs a linear search on a string slice. It returns the
+// index of the first occurrence of the target, or -1 together with ErrEmpty if the slice
+// is empty or ErrNotFound if the target is not present.
+func LinearSearchStringValidated(slice []string, target string) (int, error) {
+	return LinearSearchGenericValidated(slice, target)
+}
+
+// LinearSearchGenericValidated performs a linear search on a generic slice. It returns the
+// index of the first occurrence of the target, or -1 together with ErrEmpty if the slice
+// is empty or ErrNotFound if the target is not present. Linear search does not require a
+// sorted slice, so there is no sortedness option.
+func LinearSearchGenericValidated[T comparable](slice []T, target T) (int, error) {
+	if len(slice) == 0 {
+		return -1, ErrEmpty
+	}
+	if i := LinearSearchGeneric(slice, target); i != -1 {
+		return i, nil
+	}
+	return -1, ErrNotFound
+}
+
+// validate performs the checks shared by the validated searches on sorted slices.
+func validate[T any](arr []T, less func(T, T) bool, opts SearchOptions) error {
+	if len(arr) == 0 {
+		return ErrEmpty
+	}
+	if opts.VerifySorted {
+		return VerifySortedGeneric(arr, less)
+	}
+	return nil
+}
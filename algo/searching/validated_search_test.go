@@ -0,0 +1,114 @@
+package searching
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerifySorted(t *testing.T) {
+	tt := []struct {
+		name     string
+		arr      []int
+		expected int
+	}{
+		{"Empty", []int{}, -1},
+		{"Single", []int{1}, -1},
+		{"Sorted", []int{1, 2, 2, 3}, -1},
+		{"Unsorted", []int{1, 3, 2, 4}, 2},
+		{"Descending", []int{3, 2, 1}, 1},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifySorted(tc.arr)
+			if tc.expected == -1 {
+				if err != nil {
+					t.Errorf("Expected no error but got %v", err)
+				}
+				return
+			}
+			var unsorted *UnsortedError
+			if !errors.As(err, &unsorted) || unsorted.Index != tc.expected {
+				t.Errorf("Expected UnsortedError at %d but got %v", tc.expected, err)
+			}
+			if !errors.Is(err, ErrUnsorted) {
+				t.Errorf("Expected error to match ErrUnsorted")
+			}
+		})
+	}
+}
+
+func TestValidatedSearch(t *testing.T) {
+	tt := []struct {
+		name     string
+		arr      []int
+		target   int
+		opts     SearchOptions
+		expected int
+		err      error
+	}{
+		{"One", []int{1, 2, 3, 4, 5}, 1, SearchOptions{}, 0, nil},
+		{"Middle", []int{1, 2, 3, 4, 5}, 3, SearchOptions{}, 2, nil},
+		{"End", []int{1, 2, 3, 4, 5}, 5, SearchOptions{}, 4, nil},
+		{"FirstOfDuplicates", []int{1, 2, 2, 2, 2, 2, 3}, 2, SearchOptions{}, 1, nil},
+		{"Empty", []int{}, 5, SearchOptions{}, -1, ErrEmpty},
+		{"EmptyVerified", []int{}, 5, SearchOptions{VerifySorted: true}, -1, ErrEmpty},
+		{"NotFound", []int{1, 2, 3, 4, 5}, 6, SearchOptions{}, -1, ErrNotFound},
+		{"NotFoundBetween", []int{1, 3, 5, 7, 9}, 4, SearchOptions{}, -1, ErrNotFound},
+		{"NotFoundBelow", []int{1, 3, 5, 7, 9}, 0, SearchOptions{}, -1, ErrNotFound},
+		{"SortedVerified", []int{1, 2, 3, 4, 5}, 4, SearchOptions{VerifySorted: true}, 3, nil},
+		{"Unsorted", []int{5, 4, 3, 2, 1}, 3, SearchOptions{VerifySorted: true}, -1, ErrUnsorted},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			check := func(name string, got int, err error) {
+				if got != tc.expected || !errors.Is(err, tc.err) {
+					t.Errorf("%s: expected (%d, %v) but got (%d, %v)", name, tc.expected, tc.err, got, err)
+				}
+			}
+			got, err := BinarySearchValidated(tc.arr, tc.target, tc.opts)
+			check("BinarySearchValidated", got, err)
+			got, err = JumpSearchValidated(tc.arr, tc.target, tc.opts)
+			check("JumpSearchValidated", got, err)
+			if tc.err != ErrUnsorted {
+				got, err = LinearSearchValidated(tc.arr, tc.target)
+				check("LinearSearchValidated", got, err)
+			}
+		})
+	}
+}
+
+func TestValidatedSearchUnsortedNoPanic(t *testing.T) {
+	arr := []int{9, 1, 8, 2, 7, 3, 6, 4, 5}
+	for target := 0; target < 11; target++ {
+		if i, err := JumpSearchValidated(arr, target, SearchOptions{}); err == nil && arr[i] != target {
+			t.Errorf("JumpSearchValidated(%d) returned index %d holding %d", target, i, arr[i])
+		}
+		if i, err := BinarySearchValidated(arr, target, SearchOptions{}); err == nil && arr[i] != target {
+			t.Errorf("BinarySearchValidated(%d) returned index %d holding %d", target, i, arr[i])
+		}
+	}
+}
+
+func TestValidatedSearchGeneric(t *testing.T) {
+	type pair struct {
+		key  int
+		name string
+	}
+	arr := []pair{{1, "a"}, {3, "b"}, {5, "c"}, {7, "d"}}
+	less := func(a, b pair) bool { return a.key < b.key }
+
+	if got, err := BinarySearchGenericValidated(arr, pair{key: 5}, less, SearchOptions{}); got != 2 || err != nil {
+		t.Errorf("BinarySearchGenericValidated: expected (2, nil) but got (%d, %v)", got, err)
+	}
+	if got, err := JumpSearchGenericValidated(arr, pair{key: 7}, less, SearchOptions{}); got != 3 || err != nil {
+		t.Errorf("JumpSearchGenericValidated: expected (3, nil) but got (%d, %v)", got, err)
+	}
+	if got, err := JumpSearchGenericValidated(arr, pair{key: 4}, less, SearchOptions{}); got != -1 || err != ErrNotFound {
+		t.Errorf("JumpSearchGenericValidated: expected (-1, ErrNotFound) but got (%d, %v)", got, err)
+	}
+	if got, err := LinearSearchStringValidated([]string{"a", "b"}, "b"); got != 1 || err != nil {
+		t.Errorf("LinearSearchStringValidated: expected (1, nil) but got (%d, %v)", got, err)
+	}
+}
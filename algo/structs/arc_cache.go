@@ -0,0 +1,229 @@
+package structs
+
+// ARCCache is a thread-safe cache with a fixed capacity that implements the Adaptive Replacement
+// Cache policy of Megiddo and Modha. It balances between recency and frequency by itself,
+// adjusting to the workload as it changes.
+//
+// The cache keeps four lists, each ordered by recency, most recent first. T1 holds the entries
+// that have been used once since they entered the cache and T2 those that have been used at least
+// twice; together they hold at most capacity entries. B1 and B2 are ghost lists that remember only
+// the keys of the entries recently evicted from T1 and T2. A target size p for T1 decides which
+// of T1 and T2 gives up its least recently used entry when a new key does not fit. A Put of a key
+// found in B1 shows that T1 was evicted too early, so p grows; a key found in B2 makes p shrink.
+// The lists are DoublyLinkedLists, and a HashMap maps every key to its element in any of them, so
+// every operation takes O(1) time.
+//
+// Like LFUCache, ARCCache resists scans, since keys used only once never leave T1, but unlike
+// LFUCache it forgets old popularity as the workload changes. Remembering the ghost keys costs
+// memory for up to capacity more keys, but not for their values.
+type ARCCache[K comparable, V any] struct {
+	cacheCore[K, V]
+	index          *HashMap[K, *Element[cacheEntry[K, V]]]
+	t1, t2, b1, b2 *DoublyLinkedList[cacheEntry[K, V]]
+	p              int
+}
+
+// NewARCCache returns an empty ARCCache that holds up to capacity entries, which never expire. It
+// panics if capacity is less than 1.
+func NewARCCache[K comparable, V any](capacity int) *ARCCache[K, V] {
+	return NewARCCacheWithOptions(capacity, CacheOptions[K, V]{})
+}
+
+// NewARCCacheWithOptions returns an empty ARCCache that holds up to capacity entries and is
+// configured by opts. It panics if capacity is less than 1.
+func NewARCCacheWithOptions[K comparable, V any](capacity int, opts CacheOptions[K, V]) *ARCCache[K, V] {
+	return &ARCCache[K, V]{
+		cacheCore: newCacheCore("ARC", capacity, opts),
+		index:     NewHashMapWithOptions[K, *Element[cacheEntry[K, V]]](HashMapOptions{InitialCapacity: 2 * capacity}),
+		t1:        NewDoublyLinkedList[cacheEntry[K, V]](),
+		t2:        NewDoublyLinkedList[cacheEntry[K, V]](),
+		b1:        NewDoublyLinkedList[cacheEntry[K, V]](),
+		b2:        NewDoublyLinkedList[cacheEntry[K, V]](),
+	}
+}
+
+// Get returns the value associated with the given key and moves the key to the front of T2. The
+// boolean result is false if the key is absent or has expired; a key that is only remembered in a
+// ghost list is absent.
+func (c *ARCCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.unlock()
+	e := c.lookup(key)
+	if e == nil {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.promote(e)
+	return e.Value.value, true
+}
+
+// Peek returns the value associated with the given key without moving it or counting the lookup.
+// The boolean result is false if the key is absent or has expired.
+func (c *ARCCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.unlock()
+	if e := c.lookup(key); e != nil {
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put associates the given value with the given key. A key present in the cache, or remembered in
+// a ghost list, moves to the front of T2, and a ghost hit adapts the target size of T1. A new key
+// enters at the front of T1. If the cache is full, an entry is evicted from T1 or T2 and its key
+// is remembered in B1 or B2.
+func (c *ARCCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.unlock()
+	e := c.index.Get(key)
+	switch {
+	case e == nil:
+		c.admit(key, value)
+	case e.list.Load() == c.t1 || e.list.Load() == c.t2:
+		e.Value = c.newEntry(key, value)
+		c.promote(e)
+	case e.list.Load() == c.b1:
+		c.p = min(c.capacity, c.p+max(c.b2.Size()/c.b1.Size(), 1))
+		c.b1.Remove(e)
+		c.makeRoom(false)
+		c.index.Put(key, c.t2.PushFront(c.newEntry(key, value)))
+	default:
+		c.p = max(0, c.p-max(c.b1.Size()/c.b2.Size(), 1))
+		c.b2.Remove(e)
+		c.makeRoom(true)
+		c.index.Put(key, c.t2.PushFront(c.newEntry(key, value)))
+	}
+}
+
+// Remove deletes the given key, and forgets it if it is remembered in a ghost list. It reports
+// whether the key was present in the cache. The eviction callback is not called.
+func (c *ARCCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.unlock()
+	e := c.index.Get(key)
+	if e == nil {
+		return false
+	}
+	list := e.list.Load()
+	present := list == c.t1 || list == c.t2
+	list.Remove(e)
+	c.index.Remove(key)
+	return present
+}
+
+// Len returns the number of entries in the cache, including expired entries that have not been
+// removed yet but not the keys remembered in the ghost lists.
+func (c *ARCCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.unlock()
+	return c.t1.Size() + c.t2.Size()
+}
+
+// Purge removes all entries and ghost keys from the cache without calling the eviction callback,
+// and resets the target size of T1. The statistics are kept.
+func (c *ARCCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.unlock()
+	c.t1.Clear()
+	c.t2.Clear()
+	c.b1.Clear()
+	c.b2.Clear()
+	c.index.Clear()
+	c.p = 0
+}
+
+// PurgeExpired removes all expired entries, calls the eviction callback for them, and returns how
+// many there were. It examines every entry, so it takes O(N) time.
+func (c *ARCCache[K, V]) PurgeExpired() int {
+	c.mu.Lock()
+	defer c.unlock()
+	removed := 0
+	for _, l := range []*DoublyLinkedList[cacheEntry[K, V]]{c.t1, c.t2} {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if c.expired(&e.Value) {
+				c.drop(e, EvictedExpired)
+				removed++
+			}
+			e = next
+		}
+	}
+	return removed
+}
+
+// lookup returns the list element of the given key if it is in T1 or T2, or nil otherwise. An
+// expired entry is evicted without leaving a ghost and reported as absent.
+func (c *ARCCache[K, V]) lookup(key K) *Element[cacheEntry[K, V]] {
+	e := c.index.Get(key)
+	if e == nil || e.list.Load() == c.b1 || e.list.Load() == c.b2 {
+		return nil
+	}
+	if c.expired(&e.Value) {
+		c.drop(e, EvictedExpired)
+		return nil
+	}
+	return e
+}
+
+// promote moves an entry in T1 or T2 to the front of T2.
+func (c *ARCCache[K, V]) promote(e *Element[cacheEntry[K, V]]) {
+	if e.list.Load() == c.t2 {
+		c.t2.MoveToFront(e)
+		return
+	}
+	c.t1.Remove(e)
+	c.index.Put(e.Value.key, c.t2.PushFront(e.Value))
+}
+
+// admit adds a key that is neither in the cache nor in a ghost list to the front of T1. It first
+// trims the ghost lists so that T1 and B1 together, and all four lists together, stay within
+// capacity and twice the capacity, as the ARC policy requires.
+func (c *ARCCache[K, V]) admit(key K, value V) {
+	t1, t2, b1, b2 := c.t1.Size(), c.t2.Size(), c.b1.Size(), c.b2.Size()
+	if t1+b1 >= c.capacity {
+		if b1 > 0 {
+			c.forget(c.b1.Back())
+		} else {
+			c.drop(c.t1.Back(), EvictedCapacity)
+		}
+	} else if t1+t2+b1+b2 >= 2*c.capacity {
+		c.forget(c.b2.Back())
+	}
+	c.makeRoom(false)
+	c.index.Put(key, c.t1.PushFront(c.newEntry(key, value)))
+}
+
+// makeRoom evicts an entry if the cache is full. It evicts the least recently used entry of T1
+// if T1 is larger than its target size, or as large as it and the key being added was found in
+// B2, and the least recently used entry of T2 otherwise. The key of the evicted entry moves to the
+// front of the matching ghost list.
+func (c *ARCCache[K, V]) makeRoom(inB2 bool) {
+	t1, t2 := c.t1.Size(), c.t2.Size()
+	if t1+t2 < c.capacity {
+		return
+	}
+	from, to := c.t2, c.b2
+	if t1 > 0 && (t1 > c.p || (inB2 && t1 == c.p) || t2 == 0) {
+		from, to = c.t1, c.b1
+	}
+	e := from.Back()
+	from.Remove(e)
+	c.evicted(&e.Value, EvictedCapacity)
+	c.index.Put(e.Value.key, to.PushFront(cacheEntry[K, V]{key: e.Value.key}))
+}
+
+// drop removes an entry from T1 or T2 for the given reason without leaving a ghost.
+func (c *ARCCache[K, V]) drop(e *Element[cacheEntry[K, V]], reason EvictionReason) {
+	e.list.Load().Remove(e)
+	c.index.Remove(e.Value.key)
+	c.evicted(&e.Value, reason)
+}
+
+// forget removes a key from a ghost list.
+func (c *ARCCache[K, V]) forget(e *Element[cacheEntry[K, V]]) {
+	e.list.Load().Remove(e)
+	c.index.Remove(e.Value.key)
+}
@@ -0,0 +1,108 @@
+package structs
+
+import (
+	"fmt"
+	"testing"
+)
+
+// verifyARCCache checks the bounds the ARC policy places on its lists: T1 and T2 together hold at
+// most capacity entries, T1 and B1 together at most capacity keys and all four lists at most
+// twice the capacity. It also checks that every key is indexed by its element, that ghost keys carry
+// no values, and that the target size of T1 is in [0, capacity].
+func verifyARCCache[K comparable, V comparable](c *ARCCache[K, V]) error {
+	t1, t2, b1, b2 := c.t1.Size(), c.t2.Size(), c.b1.Size(), c.b2.Size()
+	if t1+t2 > c.capacity {
+		return fmt.Errorf("T1 and T2 hold %d entries, more than the capacity %d", t1+t2, c.capacity)
+	}
+	if t1+b1 > c.capacity {
+		return fmt.Errorf("T1 and B1 hold %d keys, more than the capacity %d", t1+b1, c.capacity)
+	}
+	total := t1 + t2 + b1 + b2
+	if total > 2*c.capacity {
+		return fmt.Errorf("the lists hold %d keys, more than twice the capacity %d", total, c.capacity)
+	}
+	if c.index.Size() != total {
+		return fmt.Errorf("index holds %d keys but the lists %d", c.index.Size(), total)
+	}
+	if c.p < 0 || c.p > c.capacity {
+		return fmt.Errorf("target size %d is outside [0, %d]", c.p, c.capacity)
+	}
+	var zero V
+	for _, l := range []*DoublyLinkedList[cacheEntry[K, V]]{c.t1, c.t2, c.b1, c.b2} {
+		ghost := l == c.b1 || l == c.b2
+		for e := l.Front(); e != nil; e = e.Next() {
+			if c.index.Get(e.Value.key) != e {
+				return fmt.Errorf("key %v is not indexed by its element", e.Value.key)
+			}
+			if ghost && e.Value.value != zero {
+				return fmt.Errorf("ghost key %v keeps its value", e.Value.key)
+			}
+		}
+	}
+	return nil
+}
+
+func TestARCCacheLists(t *testing.T) {
+	c := NewARCCache[int, int](2)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	if c.t1.Size() != 2 || c.t2.Size() != 0 {
+		t.Fatalf("Expected new keys to enter T1")
+	}
+	c.Get(1)
+	if c.t1.Size() != 1 || c.t2.Size() != 1 {
+		t.Fatalf("Expected a hit to move key 1 to T2")
+	}
+	c.Put(3, 3)
+	if c.b1.Size() != 1 || c.b1.Front().Value.key != 2 {
+		t.Fatalf("Expected key 2 to be evicted from T1 into B1")
+	}
+	if _, ok := c.Get(2); ok {
+		t.Errorf("Expected a ghost key to be a miss")
+	}
+}
+
+func TestARCCacheAdaptation(t *testing.T) {
+	c := NewARCCache[int, int](4)
+	for k := 1; k <= 4; k++ {
+		c.Put(k, k)
+	}
+	// With T1 full and B1 empty, the victim leaves no ghost.
+	c.Put(5, 5)
+	if _, ok := c.index.GetOk(1); ok || c.b1.Size() != 0 {
+		t.Fatalf("Expected key 1 to be evicted without a ghost")
+	}
+	c.Put(1, 1)
+	c.Get(1)
+	c.Put(6, 6)
+	if c.p != 0 || c.b1.Size() != 1 || c.b1.Front().Value.key != 3 {
+		t.Fatalf("Expected key 3 as the only ghost in B1 and target 0, got %d ghosts and target %d", c.b1.Size(), c.p)
+	}
+	// Putting the ghost key back shows that T1 was too small.
+	c.Put(3, 3)
+	if c.p != 1 {
+		t.Errorf("Expected a B1 hit to grow the target of T1 to 1 but got %d", c.p)
+	}
+	if n := c.index.Get(3); n.list.Load() != c.t2 {
+		t.Errorf("Expected key 3 to return to T2")
+	}
+
+	// Fill T2 and evict from it, then put an evicted key back to shrink the target again.
+	for k := 1; k <= 6; k++ {
+		c.Get(k)
+	}
+	for k := 7; k <= 10; k++ {
+		c.Put(k, k)
+	}
+	if c.b2.Size() == 0 {
+		t.Fatalf("Expected keys to be evicted from T2 into B2")
+	}
+	p := c.p
+	c.Put(c.b2.Front().Value.key, 0)
+	if c.p >= p {
+		t.Errorf("Expected a B2 hit to shrink the target of T1 below %d but got %d", p, c.p)
+	}
+	if err := verifyARCCache(c); err != nil {
+		t.Fatalf("Invariant violated: %v", err)
+	}
+}
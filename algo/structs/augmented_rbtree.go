@@ -0,0 +1,132 @@
+package structs
+
+// Aggregate describes a per-subtree aggregate of type A over values of type T, such as a sum
+// or a maximum. Of maps a single value to its aggregate and Combine merges the aggregates of
+// two adjacent runs of values, the left run first. Combine must be associative and Identity
+// must be its neutral element, i.e. the aggregate of an empty subtree.
+type Aggregate[T any, A any] struct {
+	Of       func(value T) A
+	Combine  func(left, right A) A
+	Identity A
+}
+
+// AugmentedRedBlackTree is a RedBlackTree in which every node also stores the aggregate of all
+// values in its subtree. The aggregates are recomputed by an augmentation hook whenever the
+// children of a node change, that is on insertion, deletion and rotation, so maintaining them
+// does not change the O(log N) cost of the tree operations. The aggregates are kept in a map
+// from node to aggregate owned by the augmented tree, so plain RedBlackTree nodes carry no space
+// for them and the aggregates are stored with their own type A rather than boxed.
+//
+// All methods of RedBlackTree are available on an AugmentedRedBlackTree. The aggregates can be
+// read with Total, queried over a range of values with Query, or used to guide a custom descent
+// with Find, which is how interval trees and similar structures locate their answers.
+type AugmentedRedBlackTree[T any, A any] struct {
+	*RedBlackTree[T]
+	agg  Aggregate[T, A]
+	aggs map[*RBNode[T]]A
+}
+
+// NewAugmentedRedBlackTree creates a new empty red-black tree ordered by cmp that maintains the
+// given aggregate for every subtree.
+func NewAugmentedRedBlackTree[T any, A any](cmp func(a, b T) int, agg Aggregate[T, A]) *AugmentedRedBlackTree[T, A] {
+	t := &AugmentedRedBlackTree[T, A]{
+		RedBlackTree: NewRedBlackTree(cmp),
+		agg:          agg,
+		aggs:         make(map[*RBNode[T]]A),
+	}
+	t.augment = func(node *RBNode[T]) {
+		t.aggs[node] = agg.Combine(agg.Combine(t.aggOf(node.Left), agg.Of(node.Value)), t.aggOf(node.Right))
+	}
+	t.discard = func(node *RBNode[T]) {
+		delete(t.aggs, node)
+	}
+	return t
+}
+
+// aggOf returns the aggregate stored in node, or the identity for an empty subtree.
+func (t *AugmentedRedBlackTree[T, A]) aggOf(node *RBNode[T]) A {
+	if node == nil {
+		return t.agg.Identity
+	}
+	return t.aggs[node]
+}
+
+// Total returns the aggregate of all values in the tree in O(1) time.
+func (t *AugmentedRedBlackTree[T, A]) Total() A {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.aggOf(t.root)
+}
+
+// Query returns the aggregate of all values v with lo <= v <= hi, combined in ascending order.
+// Whole subtrees that lie inside the range contribute their stored aggregate, so the query
+// visits O(log N) nodes. For a sum aggregate this is a range-sum query.
+func (t *AugmentedRedBlackTree[T, A]) Query(lo, hi T) A {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil {
+		if t.cmp(node.Value, lo) < 0 {
+			node = node.Right
+		} else if t.cmp(node.Value, hi) > 0 {
+			node = node.Left
+		} else {
+			left := t.queryFrom(node.Left, lo)
+			right := t.queryUpTo(node.Right, hi)
+			return t.agg.Combine(t.agg.Combine(left, t.agg.Of(node.Value)), right)
+		}
+	}
+	return t.agg.Identity
+}
+
+// queryFrom returns the aggregate of all values in the subtree rooted at node that are greater
+// than or equal to lo.
+func (t *AugmentedRedBlackTree[T, A]) queryFrom(node *RBNode[T], lo T) A {
+	if node == nil {
+		return t.agg.Identity
+	}
+	if t.cmp(node.Value, lo) < 0 {
+		return t.queryFrom(node.Right, lo)
+	}
+	return t.agg.Combine(t.agg.Combine(t.queryFrom(node.Left, lo), t.agg.Of(node.Value)), t.aggOf(node.Right))
+}
+
+// queryUpTo returns the aggregate of all values in the subtree rooted at node that are less than
+// or equal to hi.
+func (t *AugmentedRedBlackTree[T, A]) queryUpTo(node *RBNode[T], hi T) A {
+	if node == nil {
+		return t.agg.Identity
+	}
+	if t.cmp(node.Value, hi) > 0 {
+		return t.queryUpTo(node.Left, hi)
+	}
+	return t.agg.Combine(t.agg.Combine(t.aggOf(node.Left), t.agg.Of(node.Value)), t.queryUpTo(node.Right, hi))
+}
+
+// Find descends from the root, guided by fn. At every node fn receives the aggregate of the left
+// subtree, the value of the node and the aggregate of the right subtree. It returns a negative
+// number to continue in the left subtree, a positive number to continue in the right subtree, or
+// zero to stop and return the value of the node. The boolean result is false if the descent runs
+// off the tree. Find visits O(log N) nodes.
+//
+// For example, in an interval tree whose aggregate is the largest interval end in a subtree, an
+// interval overlapping a query is found by going left whenever the left aggregate reaches the
+// start of the query.
+func (t *AugmentedRedBlackTree[T, A]) Find(fn func(left A, value T, right A) int) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil {
+		c := fn(t.aggOf(node.Left), node.Value, t.aggOf(node.Right))
+		if c == 0 {
+			return node.Value, true
+		}
+		if c < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	var zero T
+	return zero, false
+}
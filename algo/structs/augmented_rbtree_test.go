@@ -0,0 +1,134 @@
+package structs
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+)
+
+func sumAggregate() Aggregate[int, int] {
+	return Aggregate[int, int]{
+		Of:      func(value int) int { return value },
+		Combine: func(left, right int) int { return left + right },
+	}
+}
+
+func TestAugmentedRedBlackTreeRangeSum(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	tree := NewAugmentedRedBlackTree(cmp.Compare[int], sumAggregate())
+	counts := make(map[int]int)
+	for i := 0; i < 3000; i++ {
+		v := r.Intn(300)
+		if r.Intn(3) == 0 {
+			if tree.Delete(v) {
+				counts[v]--
+			}
+		} else {
+			tree.Insert(v)
+			counts[v]++
+		}
+	}
+	if err := verifyRedBlackTree(tree.RedBlackTree); err != nil {
+		t.Fatalf("Invariant violated: %v", err)
+	}
+	// Deleted nodes must not keep their aggregates alive.
+	if len(tree.aggs) != tree.Len() {
+		t.Errorf("%d aggregates are stored for %d nodes", len(tree.aggs), tree.Len())
+	}
+
+	total := 0
+	for v, c := range counts {
+		total += v * c
+	}
+	if got := tree.Total(); got != total {
+		t.Errorf("Total() = %d, want %d", got, total)
+	}
+
+	for i := 0; i < 200; i++ {
+		lo := r.Intn(320) - 10
+		hi := lo + r.Intn(100)
+		want := 0
+		for v, c := range counts {
+			if v >= lo && v <= hi {
+				want += v * c
+			}
+		}
+		if got := tree.Query(lo, hi); got != want {
+			t.Fatalf("Query(%d, %d) = %d, want %d", lo, hi, got, want)
+		}
+	}
+	if got := tree.Query(10, 5); got != 0 {
+		t.Errorf("Query of an empty range = %d, want 0", got)
+	}
+}
+
+func TestAugmentedRedBlackTreeOrderedCombine(t *testing.T) {
+	// String concatenation is associative but not commutative, so it checks that the
+	// aggregates are combined in ascending order.
+	tree := NewAugmentedRedBlackTree(cmp.Compare[string], Aggregate[string, string]{
+		Of:      func(value string) string { return value },
+		Combine: func(left, right string) string { return left + right },
+	})
+	for _, v := range []string{"d", "b", "f", "a", "c", "e", "g"} {
+		tree.Insert(v)
+	}
+	tree.Delete("d")
+	if got := tree.Total(); got != "abcefg" {
+		t.Errorf("Total() = %q, want %q", got, "abcefg")
+	}
+	if got := tree.Query("b", "f"); got != "bcef" {
+		t.Errorf("Query(b, f) = %q, want %q", got, "bcef")
+	}
+}
+
+func TestAugmentedRedBlackTreeIntervals(t *testing.T) {
+	type interval struct{ start, end int }
+	tree := NewAugmentedRedBlackTree(func(a, b interval) int {
+		if c := cmp.Compare(a.start, b.start); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.end, b.end)
+	}, Aggregate[interval, int]{
+		Of:       func(value interval) int { return value.end },
+		Combine:  func(left, right int) int { return max(left, right) },
+		Identity: -1 << 63,
+	})
+	for _, iv := range []interval{{15, 20}, {10, 30}, {17, 19}, {5, 20}, {12, 15}, {30, 40}} {
+		tree.Insert(iv)
+	}
+
+	overlapping := func(start, end int) (interval, bool) {
+		return tree.Find(func(left int, value interval, right int) int {
+			if value.start <= end && start <= value.end {
+				return 0
+			}
+			if left >= start {
+				return -1
+			}
+			return 1
+		})
+	}
+
+	tests := []struct {
+		name       string
+		start, end int
+		found      bool
+	}{
+		{"OverlapsSeveral", 14, 16, true},
+		{"OverlapsLast", 35, 50, true},
+		{"Touching", 40, 45, true},
+		{"Before", 0, 4, false},
+		{"After", 41, 50, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := overlapping(tt.start, tt.end)
+			if found != tt.found {
+				t.Fatalf("Find(%d, %d) found = %v, want %v", tt.start, tt.end, found, tt.found)
+			}
+			if found && (got.start > tt.end || tt.start > got.end) {
+				t.Errorf("Find(%d, %d) = %v, which does not overlap", tt.start, tt.end, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+)
+
+// AVLNode represents a node in an AVL tree.
+// It contains the value, the left and right children, and the height of the subtree rooted at the node.
+type AVLNode[T any] struct {
+	Value  T
+	Left   *AVLNode[T]
+	Right  *AVLNode[T]
+	height int
+}
+
+// AVLTree represents an AVL tree holding values of type T.
+//
+// AVL trees are self-balancing binary search trees in which the heights of the two subtrees of
+// every node differ by at most one. They are more rigidly balanced than red-black trees, which
+// makes lookups slightly faster at the price of more rotations on insertion and deletion. All
+// operations take O(log N) time.
+//
+// The AVLTree type consists of a mutex to handle concurrent access, a root node, the number of
+// values, and a "cmp" function used to compare values. The tree implements OrderedSet.
+type AVLTree[T any] struct {
+	mu   sync.Mutex
+	root *AVLNode[T]
+	cmp  func(a, b T) int
+	size int
+}
+
+var _ OrderedSet[int] = (*AVLTree[int])(nil)
+
+// NewAVLTree creates a new empty AVL tree with the specified comparison function.
+// The comparison function returns a negative number if a is less than b, zero if they are equal,
+// and a positive number if a is greater than b, like cmp.Compare.
+func NewAVLTree[T any](cmp func(a, b T) int) *AVLTree[T] {
+	return &AVLTree[T]{
+		cmp: cmp,
+	}
+}
+
+// Insert adds a new node with the specified value to the AVL tree and rebalances every node on the
+// path back to the root.
+func (t *AVLTree[T]) Insert(value T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = t.insertNode(t.root, value)
+	t.size++
+}
+
+// insertNode inserts the value into the subtree rooted at node and returns the new, rebalanced root
+// of the subtree. Values equal to the value of a node are inserted into its right subtree.
+func (t *AVLTree[T]) insertNode(node *AVLNode[T], value T) *AVLNode[T] {
+	if node == nil {
+		return &AVLNode[T]{Value: value, height: 1}
+	}
+	if t.cmp(value, node.Value) < 0 {
+		node.Left = t.insertNode(node.Left, value)
+	} else {
+		node.Right = t.insertNode(node.Right, value)
+	}
+	return rebalanceAVL(node)
+}
+
+// Delete removes one node whose value is equal to the given value and reports whether such a node
+// was found. A node with two children is replaced by its in-order successor.
+func (t *AVLTree[T]) Delete(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var deleted bool
+	t.root, deleted = t.deleteNode(t.root, value)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// deleteNode removes one node with the given value from the subtree rooted at node and returns the
+// new, rebalanced root of the subtree together with whether a node was removed.
+func (t *AVLTree[T]) deleteNode(node *AVLNode[T], value T) (*AVLNode[T], bool) {
+	if node == nil {
+		return nil, false
+	}
+	var deleted bool
+	c := t.cmp(value, node.Value)
+	if c < 0 {
+		node.Left, deleted = t.deleteNode(node.Left, value)
+	} else if c > 0 {
+		node.Right, deleted = t.deleteNode(node.Right, value)
+	} else {
+		if node.Left == nil {
+			return node.Right, true
+		}
+		if node.Right == nil {
+			return node.Left, true
+		}
+		successor := node.Right
+		for successor.Left != nil {
+			successor = successor.Left
+		}
+		node.Value = successor.Value
+		node.Right = deleteMinAVL(node.Right)
+		deleted = true
+	}
+	return rebalanceAVL(node), deleted
+}
+
+// deleteMinAVL removes the leftmost node of the subtree rooted at node and returns the new,
+// rebalanced root of the subtree.
+func deleteMinAVL[T any](node *AVLNode[T]) *AVLNode[T] {
+	if node.Left == nil {
+		return node.Right
+	}
+	node.Left = deleteMinAVL(node.Left)
+	return rebalanceAVL(node)
+}
+
+// avlHeight returns the height of the subtree rooted at node.
+func avlHeight[T any](node *AVLNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// updateAVLHeight recomputes the height of node from the heights of its children.
+func updateAVLHeight[T any](node *AVLNode[T]) {
+	node.height = max(avlHeight(node.Left), avlHeight(node.Right)) + 1
+}
+
+// rebalanceAVL restores the AVL property at node, whose subtrees are already balanced and whose
+// balance factor is at most two, and returns the new root of the subtree. A subtree that is too
+// high on one side is fixed with a single rotation, or with a double rotation if its taller child
+// leans the other way.
+func rebalanceAVL[T any](node *AVLNode[T]) *AVLNode[T] {
+	updateAVLHeight(node)
+	balance := avlHeight(node.Left) - avlHeight(node.Right)
+	if balance > 1 {
+		if avlHeight(node.Left.Left) < avlHeight(node.Left.Right) {
+			node.Left = rotateLeftAVL(node.Left)
+		}
+		return rotateRightAVL(node)
+	}
+	if balance < -1 {
+		if avlHeight(node.Right.Right) < avlHeight(node.Right.Left) {
+			node.Right = rotateRightAVL(node.Right)
+		}
+		return rotateLeftAVL(node)
+	}
+	return node
+}
+
+// rotateLeftAVL rotates the subtree rooted at node to the left and returns its new root.
+func rotateLeftAVL[T any](node *AVLNode[T]) *AVLNode[T] {
+	right := node.Right
+	node.Right = right.Left
+	right.Left = node
+	updateAVLHeight(node)
+	updateAVLHeight(right)
+	return right
+}
+
+// rotateRightAVL rotates the subtree rooted at node to the right and returns its new root.
+func rotateRightAVL[T any](node *AVLNode[T]) *AVLNode[T] {
+	left := node.Left
+	node.Left = left.Right
+	left.Right = node
+	updateAVLHeight(node)
+	updateAVLHeight(left)
+	return left
+}
+
+// Search reports whether a value equal to the given value is present in the AVL tree.
+func (t *AVLTree[T]) Search(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil {
+		c := t.cmp(value, node.Value)
+		if c == 0 {
+			return true
+		}
+		if c < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return false
+}
+
+// Len returns the number of values stored in the AVL tree.
+func (t *AVLTree[T]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.size
+}
+
+// Height returns the number of nodes on the longest path from the root to a leaf.
+func (t *AVLTree[T]) Height() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return avlHeight(t.root)
+}
+
+// Min returns the smallest value in the AVL tree. The boolean result is false if the tree is empty.
+func (t *AVLTree[T]) Min() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil && node.Left != nil {
+		node = node.Left
+	}
+	return avlValue(node)
+}
+
+// Max returns the largest value in the AVL tree. The boolean result is false if the tree is empty.
+func (t *AVLTree[T]) Max() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil && node.Right != nil {
+		node = node.Right
+	}
+	return avlValue(node)
+}
+
+// Floor returns the largest value in the AVL tree that is less than or equal to the given value.
+// The boolean result is false if there is no such value.
+func (t *AVLTree[T]) Floor(value T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var best *AVLNode[T]
+	node := t.root
+	for node != nil {
+		if t.cmp(node.Value, value) <= 0 {
+			best = node
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return avlValue(best)
+}
+
+// Ceiling returns the smallest value in the AVL tree that is greater than or equal to the given
+// value. The boolean result is false if there is no such value.
+func (t *AVLTree[T]) Ceiling(value T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var best *AVLNode[T]
+	node := t.root
+	for node != nil {
+		if t.cmp(node.Value, value) >= 0 {
+			best = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return avlValue(best)
+}
+
+// avlValue returns the value of node and true, or the zero value of T and false if node is nil.
+func avlValue[T any](node *AVLNode[T]) (T, bool) {
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.Value, true
+}
+
+// InOrder traverses the AVL tree in ascending order and applies the provided function to each value.
+func (t *AVLTree[T]) InOrder(f func(value T)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inOrderTraverse(t.root, f)
+}
+
+// inOrderTraverse performs an in-order traversal of the subtree rooted at node.
+func (t *AVLTree[T]) inOrderTraverse(node *AVLNode[T], f func(value T)) {
+	if node != nil {
+		t.inOrderTraverse(node.Left, f)
+		f(node.Value)
+		t.inOrderTraverse(node.Right, f)
+	}
+}
+
+// All returns an iterator over the values of the AVL tree in ascending order. The AVL tree is locked
+// while the loop runs; see the package documentation.
+func (t *AVLTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.ascend(t.root, nil, nil, yield)
+	}
+}
+
+// Backward returns an iterator over the values of the AVL tree in descending order.
+func (t *AVLTree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.descend(t.root, yield)
+	}
+}
+
+// Range returns an iterator over the values v with lo <= v < hi in ascending order. Subtrees that
+// lie entirely outside the range are skipped.
+func (t *AVLTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.ascend(t.root, &lo, &hi, yield)
+	}
+}
+
+// ascend yields the values of the subtree rooted at node that are not less than *lo and less than
+// *hi in ascending order; a nil bound is unbounded. It returns false once iteration must stop,
+// either because yield returned false or because a value reached the upper bound.
+func (t *AVLTree[T]) ascend(node *AVLNode[T], lo, hi *T, yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	if lo == nil || t.cmp(node.Value, *lo) >= 0 {
+		if !t.ascend(node.Left, lo, hi, yield) {
+			return false
+		}
+		if hi != nil && t.cmp(node.Value, *hi) >= 0 {
+			return false
+		}
+		if !yield(node.Value) {
+			return false
+		}
+	}
+	return t.ascend(node.Right, lo, hi, yield)
+}
+
+// descend yields the values of the subtree rooted at node in descending order and returns false
+// if yield did.
+func (t *AVLTree[T]) descend(node *AVLNode[T], yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	return t.descend(node.Right, yield) && yield(node.Value) && t.descend(node.Left, yield)
+}
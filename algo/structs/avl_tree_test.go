@@ -0,0 +1,105 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// verifyAVLTree checks the invariants of an AVL tree: values are in search tree order, the
+// stored heights are correct, the heights of the two subtrees of every node differ by at most
+// one, and the size is correct.
+func verifyAVLTree[T any](t *AVLTree[T]) error {
+	count := 0
+	var walk func(node *AVLNode[T]) (int, error)
+	walk = func(node *AVLNode[T]) (int, error) {
+		if node == nil {
+			return 0, nil
+		}
+		count++
+		if node.Left != nil && t.cmp(node.Left.Value, node.Value) > 0 {
+			return 0, fmt.Errorf("left child of %v is greater", node.Value)
+		}
+		if node.Right != nil && t.cmp(node.Right.Value, node.Value) < 0 {
+			return 0, fmt.Errorf("right child of %v is smaller", node.Value)
+		}
+		left, err := walk(node.Left)
+		if err != nil {
+			return 0, err
+		}
+		right, err := walk(node.Right)
+		if err != nil {
+			return 0, err
+		}
+		if left-right > 1 || right-left > 1 {
+			return 0, fmt.Errorf("node %v is unbalanced: %d vs %d", node.Value, left, right)
+		}
+		if node.height != max(left, right)+1 {
+			return 0, fmt.Errorf("node %v has height %d, expected %d", node.Value, node.height, max(left, right)+1)
+		}
+		return node.height, nil
+	}
+	if _, err := walk(t.root); err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("tree has %d nodes but size %d", count, t.size)
+	}
+	return nil
+}
+
+func TestAVLTreeBalance(t *testing.T) {
+	tt := []struct {
+		name   string
+		values func(n int) []int
+	}{
+		{"Ascending", func(n int) []int {
+			values := make([]int, n)
+			for i := range values {
+				values[i] = i
+			}
+			return values
+		}},
+		{"Descending", func(n int) []int {
+			values := make([]int, n)
+			for i := range values {
+				values[i] = n - i
+			}
+			return values
+		}},
+		{"Random", func(n int) []int { return rand.New(rand.NewSource(7)).Perm(n) }},
+		{"Duplicates", func(n int) []int {
+			values := make([]int, n)
+			for i := range values {
+				values[i] = i % 5
+			}
+			return values
+		}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := NewAVLTree(cmp.Compare[int])
+			values := tc.values(1023)
+			for _, v := range values {
+				tree.Insert(v)
+			}
+			if err := verifyAVLTree(tree); err != nil {
+				t.Fatalf("After inserts: %v", err)
+			}
+			// A tree of 1023 nodes is perfect at height 10; an AVL tree is at most 44% higher.
+			if h := tree.Height(); h > 14 {
+				t.Errorf("Expected height at most 14 but got %d", h)
+			}
+			for i, v := range values {
+				if i%2 == 0 && !tree.Delete(v) {
+					t.Fatalf("Expected %d to be deleted", v)
+				}
+			}
+			if err := verifyAVLTree(tree); err != nil {
+				t.Fatalf("After deletes: %v", err)
+			}
+		})
+	}
+}
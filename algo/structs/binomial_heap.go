@@ -0,0 +1,249 @@
+package structs
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// binomialItem is a value in a BinomialHeap and the handle returned for it. DecreaseKey moves
+// values up a tree by exchanging them with their parents, so the items move between the nodes of
+// the trees, and every item points to the node that currently holds it.
+type binomialItem[T any] struct {
+	value T
+	node  *binomialNode[T]
+	tag   atomic.Pointer[heapTag]
+}
+
+// Value returns the value of the item.
+func (it *binomialItem[T]) Value() T {
+	return it.value
+}
+
+// binomialNode is a node of a binomial tree. The children of a node are linked through sibling in
+// decreasing order of degree, and so are the roots of a BinomialHeap in increasing order.
+type binomialNode[T any] struct {
+	item                   *binomialItem[T]
+	parent, child, sibling *binomialNode[T]
+	degree                 int
+}
+
+// BinomialHeap is a thread-safe binomial heap of values of type T ordered by a cmp function, as
+// described by Vuillemin. It is a list of binomial trees with distinct degrees, where a tree of
+// degree k has 2^k nodes and is made of two trees of degree k-1, one linked under the root of the
+// other, and every node comes no later than its children. The degrees present match the binary
+// digits of the number of values, so there are at most log2(N)+1 trees.
+//
+// Meld merges the two lists of trees like adding two binary numbers, linking trees of the same
+// degree as a carry, in O(log N) time, and Push is an increment that takes O(1) amortized time.
+// Pop removes the root that comes first and melds its children back in. The first value is
+// found by scanning the roots, so Peek takes O(log N) time. See Heap for the operations and their
+// running times.
+type BinomialHeap[T any] struct {
+	mu    sync.Mutex
+	roots *binomialNode[T]
+	size  int
+	cmp   func(a, b T) int
+	tag   *heapTag
+}
+
+// NewBinomialHeap creates a new empty BinomialHeap ordered by the given cmp function.
+func NewBinomialHeap[T any](cmp func(a, b T) int) *BinomialHeap[T] {
+	return &BinomialHeap[T]{cmp: cmp, tag: &heapTag{}}
+}
+
+// Push adds a value to the heap and returns its item.
+func (h *BinomialHeap[T]) Push(value T) HeapNode[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	it := &binomialItem[T]{value: value}
+	it.tag.Store(h.tag)
+	t := &binomialNode[T]{item: it}
+	it.node = t
+	for h.roots != nil && h.roots.degree == t.degree {
+		r := h.roots
+		h.roots = r.sibling
+		if h.less(r, t) {
+			t, r = r, t
+		}
+		h.linkUnder(r, t)
+	}
+	t.sibling = h.roots
+	h.roots = t
+	h.size++
+	return it
+}
+
+// Pop removes and returns the first value of the heap. The boolean result is false if the heap is
+// empty.
+func (h *BinomialHeap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.roots == nil {
+		var zero T
+		return zero, false
+	}
+	var prev, firstPrev *binomialNode[T]
+	first := h.roots
+	for t := h.roots; t != nil; prev, t = t, t.sibling {
+		if h.less(t, first) {
+			first, firstPrev = t, prev
+		}
+	}
+	if firstPrev == nil {
+		h.roots = first.sibling
+	} else {
+		firstPrev.sibling = first.sibling
+	}
+	var children *binomialNode[T]
+	for c := first.child; c != nil; {
+		next := c.sibling
+		c.parent = nil
+		c.sibling = children
+		children = c
+		c = next
+	}
+	h.roots = h.union(h.roots, children)
+	h.size--
+	it := first.item
+	it.node = nil
+	it.tag.Store(nil)
+	return it.value, true
+}
+
+// Peek returns the first value of the heap without removing it. The boolean result is false if the
+// heap is empty.
+func (h *BinomialHeap[T]) Peek() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.roots == nil {
+		var zero T
+		return zero, false
+	}
+	first := h.roots
+	for t := first.sibling; t != nil; t = t.sibling {
+		if h.less(t, first) {
+			first = t
+		}
+	}
+	return first.item.value, true
+}
+
+// DecreaseKey replaces the value of the given item with one that comes no later and moves it up
+// its tree, exchanging it with its parent while it comes before the parent. It returns false, and
+// changes nothing, if the item does not belong to the heap or the new value would come after the
+// current one.
+func (h *BinomialHeap[T]) DecreaseKey(node HeapNode[T], value T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	it, ok := node.(*binomialItem[T])
+	if !ok || !h.tag.owns(it.tag.Load()) || h.cmp(value, it.value) > 0 {
+		return false
+	}
+	it.value = value
+	for t := it.node; t.parent != nil && h.less(t, t.parent); t = t.parent {
+		p := t.parent
+		t.item, p.item = p.item, t.item
+		t.item.node = t
+		p.item.node = p
+	}
+	return true
+}
+
+// Meld moves all values of other, which must be a *BinomialHeap, into the heap and leaves other
+// empty, in O(log N) time. It returns false if other is not a *BinomialHeap or is the heap itself.
+//
+// The two heaps are never locked together; see the package documentation.
+func (h *BinomialHeap[T]) Meld(other Heap[T]) bool {
+	o, ok := other.(*BinomialHeap[T])
+	if !ok || o == h {
+		return false
+	}
+	o.mu.Lock()
+	roots, size, tag := o.roots, o.size, o.tag
+	o.roots, o.size, o.tag = nil, 0, &heapTag{}
+	o.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tag.link(h.tag)
+	h.roots = h.union(h.roots, roots)
+	h.size += size
+	return true
+}
+
+// Size returns the number of values in the heap.
+func (h *BinomialHeap[T]) Size() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.size
+}
+
+// IsEmpty returns true if the heap holds no values, false otherwise.
+func (h *BinomialHeap[T]) IsEmpty() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.roots == nil
+}
+
+// less reports whether the value in node a comes before the value in node b.
+func (h *BinomialHeap[T]) less(a, b *binomialNode[T]) bool {
+	return h.cmp(a.item.value, b.item.value) < 0
+}
+
+// linkUnder links two trees of the same degree into one tree of the next degree by making the
+// root child the first child of the root parent, which must come no later. The sibling of parent
+// is kept, and that of child is overwritten.
+func (h *BinomialHeap[T]) linkUnder(child, parent *binomialNode[T]) {
+	child.parent = parent
+	child.sibling = parent.child
+	parent.child = child
+	parent.degree++
+}
+
+// union merges two lists of roots, each in increasing order of degree, into one list with
+// distinct degrees, linking trees of the same degree.
+func (h *BinomialHeap[T]) union(a, b *binomialNode[T]) *binomialNode[T] {
+	var head binomialNode[T]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+
+	if head.sibling == nil {
+		return nil
+	}
+
+	// At most three trees of the same degree can follow each other: two from the lists and one
+	// carried over from linking the trees of the degree below. The carry is linked with the next
+	// tree only if no third tree of the same degree follows.
+	var prev *binomialNode[T]
+	x := head.sibling
+	for next := x.sibling; next != nil; next = x.sibling {
+		switch {
+		case x.degree != next.degree || next.sibling != nil && next.sibling.degree == x.degree:
+			prev, x = x, next
+		case !h.less(next, x):
+			x.sibling = next.sibling
+			h.linkUnder(next, x)
+		default:
+			if prev == nil {
+				head.sibling = next
+			} else {
+				prev.sibling = next
+			}
+			h.linkUnder(x, next)
+			x = next
+		}
+	}
+	return head.sibling
+}
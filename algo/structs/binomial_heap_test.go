@@ -0,0 +1,90 @@
+package structs
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/bits"
+	"testing"
+)
+
+// verifyBinomialHeap checks that the roots of a BinomialHeap have strictly increasing degrees that
+// match the binary digits of its size, that every tree of degree k is a binomial tree with 2^k
+// nodes in which no node comes before its parent, and that every item points to its node and
+// belongs to the heap.
+func verifyBinomialHeap[T any](h *BinomialHeap[T]) error {
+	var walk func(n *binomialNode[T]) (int, error)
+	walk = func(n *binomialNode[T]) (int, error) {
+		if n.item.node != n || !h.tag.owns(n.item.tag.Load()) {
+			return 0, errors.New("item does not point to its node or belongs to another heap")
+		}
+		size, degree := 1, n.degree
+		for c := n.child; c != nil; c = c.sibling {
+			degree--
+			if c.degree != degree || c.parent != n {
+				return 0, fmt.Errorf("child of degree %d where %d is expected", c.degree, degree)
+			}
+			if h.less(c, n) {
+				return 0, errors.New("node comes before its parent")
+			}
+			s, err := walk(c)
+			if err != nil {
+				return 0, err
+			}
+			size += s
+		}
+		if degree != 0 {
+			return 0, fmt.Errorf("node of degree %d has %d children", n.degree, n.degree-degree)
+		}
+		return size, nil
+	}
+	total, last, mask := 0, -1, 0
+	for r := h.roots; r != nil; r = r.sibling {
+		if r.degree <= last || r.parent != nil {
+			return fmt.Errorf("root of degree %d follows degree %d", r.degree, last)
+		}
+		last = r.degree
+		size, err := walk(r)
+		if err != nil {
+			return err
+		}
+		if size != 1<<r.degree {
+			return fmt.Errorf("tree of degree %d has %d nodes", r.degree, size)
+		}
+		total += size
+		mask |= 1 << r.degree
+	}
+	if total != h.size || mask != h.size || bits.OnesCount(uint(mask)) > bits.Len(uint(h.size)) {
+		return fmt.Errorf("trees hold %d nodes but size %d", total, h.size)
+	}
+	return nil
+}
+
+func TestBinomialHeapTrees(t *testing.T) {
+	h := NewBinomialHeap(cmp.Compare[int])
+	for i := 1; i <= 100; i++ {
+		h.Push(i)
+		if err := verifyBinomialHeap(h); err != nil {
+			t.Fatalf("Invariant violated after pushing %d values: %v", i, err)
+		}
+	}
+	trees := 0
+	for r := h.roots; r != nil; r = r.sibling {
+		trees++
+	}
+	if trees != bits.OnesCount(100) {
+		t.Errorf("Expected %d trees for 100 values but got %d", bits.OnesCount(100), trees)
+	}
+
+	other := NewBinomialHeap(cmp.Compare[int])
+	for i := 0; i < 28; i++ {
+		other.Push(-i)
+	}
+	h.Meld(other)
+	if err := verifyBinomialHeap(h); err != nil {
+		t.Fatalf("Invariant violated after Meld: %v", err)
+	}
+	if h.roots.degree != 7 || h.roots.sibling != nil {
+		t.Errorf("Expected 128 values to form a single tree of degree 7")
+	}
+}
@@ -0,0 +1,249 @@
+package structs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+)
+
+// ErrQueueClosed is returned by the methods of a BlockingQueue that add an item once the queue
+// is closed, and by those that take an item once the queue is closed and empty.
+var ErrQueueClosed = errors.New("structs: queue is closed")
+
+// BlockingQueue is a thread-safe first-in-first-out queue with a fixed capacity, holding items of
+// type T, for passing work between producer and consumer goroutines. Unlike Queue, whose Dequeue
+// returns the zero value at once when the queue is empty, a BlockingQueue lets goroutines wait:
+// Put blocks while the queue is full and Take blocks while it is empty. Offer and Poll wait for at
+// most a timeout, and PutContext and TakeContext until a context is done.
+//
+// Close tells the consumers that no more items will come. Once a queue is closed, adding an item
+// fails with ErrQueueClosed, while the items already in the queue can still be taken; taking from
+// a queue that is closed and empty fails with ErrQueueClosed too. Every goroutine blocked in the
+// queue wakes up when it is closed. This is the behavior of a buffered channel that is closed by
+// its producer, except that closing twice or adding to a closed queue does not panic, so any
+// producer may close the queue, and the queue can report its size and be inspected.
+//
+// The items are kept in a ring buffer allocated when the queue is created, which never grows, so
+// items that pass through the queue are not copied into new storage. Blocked goroutines wait on a
+// channel that is closed when the queue changes, and check the queue again when they wake up; the
+// first goroutine to block after such a wakeup allocates a new channel, so a queue under
+// contention does allocate a little.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	ring     ring[T]
+	closed   bool
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+// NewBlockingQueue creates a new empty BlockingQueue that holds up to capacity items. It panics
+// if capacity is less than 1.
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("structs: blocking queue capacity %d is less than 1", capacity))
+	}
+	return &BlockingQueue[T]{ring: ring[T]{buf: make([]T, capacity)}}
+}
+
+// Put adds an item at the back of the queue, waiting for room while the queue is full. It returns
+// ErrQueueClosed if the queue is closed, before or while Put waits.
+func (q *BlockingQueue[T]) Put(item T) error {
+	return q.PutContext(context.Background(), item)
+}
+
+// PutContext adds an item at the back of the queue, waiting for room while the queue is full
+// until ctx is done. It returns ErrQueueClosed if the queue is closed, and the error of ctx if ctx
+// is done before the item could be added. If there is room right away, the item is added even if
+// ctx is already done.
+func (q *BlockingQueue[T]) PutContext(ctx context.Context, item T) error {
+	q.mu.Lock()
+	for !q.closed && q.ring.size == len(q.ring.buf) {
+		if err := q.wait(ctx, &q.notFull); err != nil {
+			return err
+		}
+	}
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.ring.pushBack(item)
+	broadcast(&q.notEmpty)
+	return nil
+}
+
+// Offer adds an item at the back of the queue, waiting for at most timeout for room while the
+// queue is full. It reports whether the item was added; it returns false if the queue is full
+// after timeout or closed. A timeout of zero or less does not wait at all.
+func (q *BlockingQueue[T]) Offer(item T, timeout time.Duration) bool {
+	if timeout <= 0 {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if q.closed || q.ring.size == len(q.ring.buf) {
+			return false
+		}
+		q.ring.pushBack(item)
+		broadcast(&q.notEmpty)
+		return true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return q.PutContext(ctx, item) == nil
+}
+
+// Take removes and returns the item at the front of the queue, waiting for one while the queue
+// is empty. It returns ErrQueueClosed if the queue is closed and empty, before or while Take
+// waits.
+func (q *BlockingQueue[T]) Take() (T, error) {
+	return q.TakeContext(context.Background())
+}
+
+// TakeContext removes and returns the item at the front of the queue, waiting for one while the
+// queue is empty until ctx is done. It returns ErrQueueClosed if the queue is closed and empty,
+// and the error of ctx if ctx is done before an item arrives. If an item is available right away,
+// it is returned even if ctx is already done.
+func (q *BlockingQueue[T]) TakeContext(ctx context.Context) (T, error) {
+	var zero T
+	q.mu.Lock()
+	for !q.closed && q.ring.size == 0 {
+		if err := q.wait(ctx, &q.notEmpty); err != nil {
+			return zero, err
+		}
+	}
+	defer q.mu.Unlock()
+	if q.ring.size == 0 {
+		return zero, ErrQueueClosed
+	}
+	item := q.ring.popFront()
+	broadcast(&q.notFull)
+	return item, nil
+}
+
+// Poll removes and returns the item at the front of the queue, waiting for at most timeout for
+// one while the queue is empty. The boolean result is false if the queue is still empty after
+// timeout, or closed and empty. A timeout of zero or less does not wait at all.
+func (q *BlockingQueue[T]) Poll(timeout time.Duration) (T, bool) {
+	if timeout <= 0 {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if q.ring.size == 0 {
+			var zero T
+			return zero, false
+		}
+		item := q.ring.popFront()
+		broadcast(&q.notFull)
+		return item, true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	item, err := q.TakeContext(ctx)
+	return item, err == nil
+}
+
+// Peek returns the item at the front of the queue without removing it or waiting. The boolean
+// result is false if the queue is empty.
+func (q *BlockingQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.ring.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.ring.at(0), true
+}
+
+// Close closes the queue: items can no longer be added, and once the items in the queue have been
+// taken, taking fails too. All goroutines waiting in the queue wake up; those adding an item
+// return ErrQueueClosed. Closing a closed queue has no effect.
+func (q *BlockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	broadcast(&q.notEmpty)
+	broadcast(&q.notFull)
+}
+
+// IsClosed returns true if the queue has been closed, false otherwise.
+func (q *BlockingQueue[T]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// Size returns the number of items in the queue.
+func (q *BlockingQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.ring.size
+}
+
+// Capacity returns the maximum number of items the queue holds.
+func (q *BlockingQueue[T]) Capacity() int {
+	return len(q.ring.buf)
+}
+
+// IsEmpty returns true if the queue holds no items, false otherwise.
+func (q *BlockingQueue[T]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.ring.size == 0
+}
+
+// All returns an iterator over the items of the queue from the front to the back, without
+// removing them. The queue is locked while the loop runs; see the package documentation.
+func (q *BlockingQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.ring.all()(yield)
+	}
+}
+
+// Consume returns an iterator that takes the items from the queue as they arrive, waiting while
+// the queue is empty, until the queue is closed and empty, like a range loop over a channel:
+//
+//	for job := range jobs.Consume() {
+//		process(job)
+//	}
+//
+// Unlike All, Consume does not keep the queue locked, so producers and other consumers can use
+// the queue while the loop runs, and every item is yielded to only one consumer.
+func (q *BlockingQueue[T]) Consume() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			item, err := q.Take()
+			if err != nil || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// wait waits until the channel in ch is closed or ctx is done. It is called with q.mu held and
+// returns with q.mu held, unless it returns the error of ctx, in which case q.mu is released. The
+// channel is created if no goroutine is waiting on it yet.
+func (q *BlockingQueue[T]) wait(ctx context.Context, ch *chan struct{}) error {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	c := *ch
+	q.mu.Unlock()
+	select {
+	case <-c:
+		q.mu.Lock()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// broadcast wakes all goroutines waiting on the channel in ch by closing it. The next goroutine to
+// wait creates a new channel, so that no channel is allocated while no goroutine waits.
+func broadcast(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
@@ -0,0 +1,253 @@
+package structs
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	q := NewBlockingQueue[int](3)
+	for i := 1; i <= 3; i++ {
+		if err := q.Put(i); err != nil {
+			t.Fatalf("Put(%d) returned %v", i, err)
+		}
+	}
+	if q.Offer(4, 0) {
+		t.Errorf("Expected Offer to a full queue to fail")
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3] but got %v", got)
+	}
+	if v, ok := q.Peek(); !ok || v != 1 {
+		t.Errorf("Expected Peek to return (1, true) but got (%d, %v)", v, ok)
+	}
+	if q.Size() != 3 || q.Capacity() != 3 || q.IsEmpty() {
+		t.Errorf("Expected a full queue of 3 items")
+	}
+	for i := 1; i <= 3; i++ {
+		if v, err := q.Take(); err != nil || v != i {
+			t.Errorf("Take: expected (%d, nil) but got (%d, %v)", i, v, err)
+		}
+	}
+	if _, ok := q.Poll(0); ok {
+		t.Errorf("Expected Poll of an empty queue to fail")
+	}
+	if !q.Offer(5, 0) {
+		t.Errorf("Expected Offer to a queue with room to succeed")
+	}
+	if v, ok := q.Poll(0); !ok || v != 5 {
+		t.Errorf("Expected Poll to return (5, true) but got (%d, %v)", v, ok)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for capacity 0")
+		}
+	}()
+	NewBlockingQueue[int](0)
+}
+
+func TestBlockingQueueTimeouts(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	start := time.Now()
+	if _, ok := q.Poll(20 * time.Millisecond); ok {
+		t.Errorf("Expected Poll of an empty queue to time out")
+	}
+	q.Put(1)
+	if q.Offer(2, 20*time.Millisecond) {
+		t.Errorf("Expected Offer to a full queue to time out")
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Expected Poll and Offer to wait for their timeouts, but they took %v", elapsed)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Take()
+	}()
+	if !q.Offer(3, time.Second) {
+		t.Errorf("Expected Offer to succeed once a consumer makes room")
+	}
+	if v, err := q.Take(); err != nil || v != 3 {
+		t.Errorf("Expected Take to return (3, nil) but got (%d, %v)", v, err)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Put(4)
+	}()
+	if v, ok := q.Poll(time.Second); !ok || v != 4 {
+		t.Errorf("Expected Poll to receive 4 but got (%d, %v)", v, ok)
+	}
+}
+
+func TestBlockingQueueContext(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := q.TakeContext(ctx)
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected TakeContext to return context.Canceled but got %v", err)
+	}
+
+	q.Put(1)
+	if err := q.PutContext(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected PutContext to a full queue to return context.Canceled but got %v", err)
+	}
+	if v, err := q.TakeContext(ctx); err != nil || v != 1 {
+		t.Errorf("Expected an available item to be taken despite the context, got (%d, %v)", v, err)
+	}
+	if err := q.PutContext(ctx, 3); err != nil {
+		t.Errorf("Expected an item to be added despite the context while there is room, got %v", err)
+	}
+
+	deadline, stop := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer stop()
+	if err := q.PutContext(deadline, 4); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected PutContext to return context.DeadlineExceeded but got %v", err)
+	}
+	if q.Size() != 1 {
+		t.Errorf("Expected cancelled operations to leave the queue alone")
+	}
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	q.Put(1)
+	q.Put(2)
+
+	errs := make(chan error, 1)
+	go func() { errs <- q.Put(3) }()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	if err := <-errs; !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected a blocked Put to return ErrQueueClosed but got %v", err)
+	}
+	q.Close()
+	if !q.IsClosed() {
+		t.Errorf("Expected the queue to be closed")
+	}
+	if err := q.Put(4); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected Put to a closed queue to return ErrQueueClosed but got %v", err)
+	}
+	if q.Offer(4, 0) || q.Offer(4, time.Second) {
+		t.Errorf("Expected Offer to a closed queue to fail")
+	}
+	if got := slices.Collect(q.Consume()); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Expected the remaining items [1 2] to be consumed but got %v", got)
+	}
+	if _, err := q.Take(); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected Take from a closed, empty queue to return ErrQueueClosed but got %v", err)
+	}
+	if _, ok := q.Poll(time.Second); ok {
+		t.Errorf("Expected Poll from a closed, empty queue to fail")
+	}
+
+	waiting := NewBlockingQueue[int](1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		waiting.Close()
+	}()
+	if _, err := waiting.Take(); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected a blocked Take to return ErrQueueClosed but got %v", err)
+	}
+}
+
+func TestBlockingQueueProducersConsumers(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 2000
+	q := NewBlockingQueue[int](16)
+
+	var produced sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		produced.Add(1)
+		go func(p int) {
+			defer produced.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := q.Put(p*perProducer + i); err != nil {
+					t.Errorf("Put returned %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+	go func() {
+		produced.Wait()
+		q.Close()
+	}()
+
+	results := make([][]int, consumers)
+	var consumed sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consumed.Add(1)
+		go func(c int) {
+			defer consumed.Done()
+			for v := range q.Consume() {
+				results[c] = append(results[c], v)
+			}
+		}(c)
+	}
+	consumed.Wait()
+
+	seen := make([]bool, producers*perProducer)
+	for _, got := range results {
+		last := make([]int, producers)
+		for p := range last {
+			last[p] = -1
+		}
+		for _, v := range got {
+			if seen[v] {
+				t.Fatalf("Item %d was consumed twice", v)
+			}
+			seen[v] = true
+			p, i := v/perProducer, v%perProducer
+			if i <= last[p] {
+				t.Fatalf("Items of producer %d were consumed out of order", p)
+			}
+			last[p] = i
+		}
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("Item %d was never consumed", v)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Errorf("Expected the queue to be drained")
+	}
+}
+
+// BenchmarkBlockingQueue passes b.N items from one producer to one consumer through a
+// BlockingQueue and, for comparison, through a buffered channel of the same capacity.
+func BenchmarkBlockingQueue(b *testing.B) {
+	const capacity = 64
+	b.Run("BlockingQueue", func(b *testing.B) {
+		q := NewBlockingQueue[int](capacity)
+		go func() {
+			for i := 0; i < b.N; i++ {
+				q.Put(i)
+			}
+			q.Close()
+		}()
+		for range q.Consume() {
+		}
+	})
+	b.Run("Channel", func(b *testing.B) {
+		ch := make(chan int, capacity)
+		go func() {
+			for i := 0; i < b.N; i++ {
+				ch <- i
+			}
+			close(ch)
+		}()
+		for range ch {
+		}
+	})
+}
@@ -1,36 +1,49 @@
 package structs
 
-import "sync"
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"sync"
+)
 
 // TreeNode represents a node in a binary tree.
-type TreeNode struct {
-	Value interface{}
-	Left  *TreeNode
-	Right *TreeNode
+// Each node also records the number of nodes in its subtree, which lets Select and Rank
+// run in time proportional to the height of the tree.
+type TreeNode[T any] struct {
+	Value T
+	Left  *TreeNode[T]
+	Right *TreeNode[T]
+	size  int
 }
 
-// BinarySearchTree represents a binary search tree data structure. It has a mutex for thread safety
-// and a root node. The less function is used to compare values for determining the position of nodes.
-// The type also includes methods for inserting values, searching for values, and performing an
-// in-order traversal of the tree.
-type BinarySearchTree struct {
+// BinarySearchTree represents a binary search tree data structure holding values of type T. It has a
+// mutex for thread safety and a root node. The cmp function is used to compare values for determining
+// the position of nodes. The type also includes methods for inserting, searching and deleting values,
+// for pre-order, in-order, post-order and level-order traversals of the tree, and for inspecting and
+// restoring its balance.
+//
+// The tree is not self-balancing: its height, and with it the cost of most operations, depends on
+// the order of insertions. Rebalance rebuilds it into a tree of minimal height.
+type BinarySearchTree[T any] struct {
 	mu   sync.Mutex
-	root *TreeNode
-	less func(a, b interface{}) bool
+	root *TreeNode[T]
+	cmp  func(a, b T) int
 }
 
 // NewBinarySearchTree creates a new instance of BinarySearchTree and returns it.
-// The BinarySearchTree is initialized with the provided less function, which is used to determine the ordering of values in the tree.
-// The less function should take two arguments of type interface{} and return a boolean indicating whether the first argument is less than the second argument.
+// The BinarySearchTree is initialized with the provided cmp function, which is used to determine the ordering of values in the tree.
+// The cmp function should take two arguments of type T and return a negative number if the first argument is less than
+// the second, zero if they are equal, and a positive number if it is greater, like cmp.Compare.
 // The BinarySearchTree struct is thread-safe and supports concurrent operations.
 // To insert a value into the tree, use the Insert method.
 // To search for a value in the tree, use the Search method.
 // To traverse the tree in inorder, use the InOrder method.
 // The tree is implemented using the TreeNode struct, which consists of a value, a left child, and a right child.
 // The BinarySearchTree methods manipulate the tree by creating, inserting, searching, and traversing the TreeNode instances.
-func NewBinarySearchTree(less func(a, b interface{}) bool) *BinarySearchTree {
-	return &BinarySearchTree{
-		less: less,
+func NewBinarySearchTree[T any](cmp func(a, b T) int) *BinarySearchTree[T] {
+	return &BinarySearchTree[T]{
+		cmp: cmp,
 	}
 }
 
@@ -50,7 +63,7 @@ func NewBinarySearchTree(less func(a, b interface{}) bool) *BinarySearchTree {
 //
 // The method does not return any value. It updates the BinarySearchTree's root by assigning
 // it the result of the insertNode operation.
-func (bst *BinarySearchTree) Insert(value interface{}) {
+func (bst *BinarySearchTree[T]) Insert(value T) {
 	bst.mu.Lock()
 	defer bst.mu.Unlock()
 	bst.root = bst.insertNode(bst.root, value)
@@ -60,12 +73,14 @@ func (bst *BinarySearchTree) Insert(value interface{}) {
 // If the tree is empty, it creates a new tree node with the given value as the root.
 // Otherwise, it recursively inserts the new node to the left subtree if the value is less than the current node's value,
 // or to the right subtree if the value is greater than or equal to the current node's value.
+// The subtree size of every node on the way down is incremented.
 // The method returns the modified tree with the new node inserted.
-func (bst *BinarySearchTree) insertNode(node *TreeNode, value interface{}) *TreeNode {
+func (bst *BinarySearchTree[T]) insertNode(node *TreeNode[T], value T) *TreeNode[T] {
 	if node == nil {
-		return &TreeNode{Value: value}
+		return &TreeNode[T]{Value: value, size: 1}
 	}
-	if bst.less(value, node.Value) {
+	node.size++
+	if bst.cmp(value, node.Value) < 0 {
 		node.Left = bst.insertNode(node.Left, value)
 	} else {
 		node.Right = bst.insertNode(node.Right, value)
@@ -85,7 +100,7 @@ func (bst *BinarySearchTree) insertNode(node *TreeNode, value interface{}) *Tree
 // value     - The value to search for in the tree.
 //
 // Returns true if the value is found in the tree, false otherwise.
-func (bst *BinarySearchTree) Search(value interface{}) bool {
+func (bst *BinarySearchTree[T]) Search(value T) bool {
 	bst.mu.Lock()
 	defer bst.mu.Unlock()
 	return bst.searchNode(bst.root, value)
@@ -97,19 +112,20 @@ func (bst *BinarySearchTree) Search(value interface{}) bool {
 // The search starts at the given node, recursively following the left or right child
 // depending on whether the value is less than or greater than the current node's value.
 // If the given node is nil, the search returns false.
-// The less method defined on the BinarySearchTree is used to compare values.
+// The cmp function of the BinarySearchTree is used to compare values; a value is found when cmp returns zero.
 // The search is performed in a concurrent-safe manner using a mutex.
 // Refer to the BinarySearchTree's Insert method for an example of how to use this method.
 // The value parameter represents the value to be searched for in the tree.
 // The method returns a boolean value indicating whether the value was found or not.
-func (bst *BinarySearchTree) searchNode(node *TreeNode, value interface{}) bool {
+func (bst *BinarySearchTree[T]) searchNode(node *TreeNode[T], value T) bool {
 	if node == nil {
 		return false
 	}
-	if node.Value == value {
+	c := bst.cmp(value, node.Value)
+	if c == 0 {
 		return true
 	}
-	if bst.less(value, node.Value) {
+	if c < 0 {
 		return bst.searchNode(node.Left, value)
 	}
 	return bst.searchNode(node.Right, value)
@@ -117,7 +133,7 @@ func (bst *BinarySearchTree) searchNode(node *TreeNode, value interface{}) bool
 
 // InOrder performs an in-order traversal of the binary search tree and applies the given function
 // to each value in ascending order. It locks the mutex before traversing and unlocks it afterwards.
-func (bst *BinarySearchTree) InOrder(f func(value interface{})) {
+func (bst *BinarySearchTree[T]) InOrder(f func(value T)) {
 	bst.mu.Lock()
 	defer bst.mu.Unlock()
 	bst.inOrderTraverse(bst.root, f)
@@ -131,10 +147,387 @@ func (bst *BinarySearchTree) InOrder(f func(value interface{})) {
 // The method is used internally by the InOrder method.
 // The node parameter represents the current node being visited during the traversal.
 // The f parameter represents the function to apply to each visited node's value.
-func (bst *BinarySearchTree) inOrderTraverse(node *TreeNode, f func(value interface{})) {
+func (bst *BinarySearchTree[T]) inOrderTraverse(node *TreeNode[T], f func(value T)) {
 	if node != nil {
 		bst.inOrderTraverse(node.Left, f)
 		f(node.Value)
 		bst.inOrderTraverse(node.Right, f)
 	}
 }
+
+// Select returns the k-th smallest value in the binary search tree, counting from zero. The
+// boolean result is false if k is out of range. It follows a single path down the tree guided
+// by the subtree sizes, so it runs in time proportional to the height of the tree.
+func (bst *BinarySearchTree[T]) Select(k int) (T, bool) {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	node := bst.root
+	if k >= 0 && k < treeSize(node) {
+		for node != nil {
+			left := treeSize(node.Left)
+			if k < left {
+				node = node.Left
+			} else if k == left {
+				return node.Value, true
+			} else {
+				k -= left + 1
+				node = node.Right
+			}
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Rank returns the number of values in the binary search tree that are strictly less than the
+// given value. The value does not need to be present. It runs in time proportional to the
+// height of the tree.
+func (bst *BinarySearchTree[T]) Rank(value T) int {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	rank := 0
+	node := bst.root
+	for node != nil {
+		if bst.cmp(node.Value, value) < 0 {
+			rank += treeSize(node.Left) + 1
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return rank
+}
+
+// treeSize returns the number of nodes in the subtree rooted at node.
+func treeSize[T any](node *TreeNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// Delete removes one node whose value is equal to the given value according to the cmp function
+// and reports whether such a node was found. A node with two children is replaced by its in-order
+// successor. The subtree sizes along the path are updated.
+func (bst *BinarySearchTree[T]) Delete(value T) bool {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	var deleted bool
+	bst.root, deleted = bst.deleteNode(bst.root, value)
+	return deleted
+}
+
+// deleteNode removes one node with the given value from the subtree rooted at node and returns
+// the new root of the subtree together with whether a node was removed.
+func (bst *BinarySearchTree[T]) deleteNode(node *TreeNode[T], value T) (*TreeNode[T], bool) {
+	if node == nil {
+		return nil, false
+	}
+	var deleted bool
+	c := bst.cmp(value, node.Value)
+	if c < 0 {
+		node.Left, deleted = bst.deleteNode(node.Left, value)
+	} else if c > 0 {
+		node.Right, deleted = bst.deleteNode(node.Right, value)
+	} else {
+		if node.Left == nil {
+			return node.Right, true
+		}
+		if node.Right == nil {
+			return node.Left, true
+		}
+		successor := node.Right
+		for successor.Left != nil {
+			successor = successor.Left
+		}
+		node.Value = successor.Value
+		node.Right = deleteMin(node.Right)
+		deleted = true
+	}
+	if deleted {
+		node.size--
+	}
+	return node, deleted
+}
+
+// deleteMin removes the leftmost node of the subtree rooted at node and returns the new root of
+// the subtree.
+func deleteMin[T any](node *TreeNode[T]) *TreeNode[T] {
+	if node.Left == nil {
+		return node.Right
+	}
+	node.Left = deleteMin(node.Left)
+	node.size--
+	return node
+}
+
+// PreOrder performs a pre-order traversal of the binary search tree, visiting each node before its
+// left and right subtrees, and applies the given function to each value.
+func (bst *BinarySearchTree[T]) PreOrder(f func(value T)) {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	bst.preOrderTraverse(bst.root, f)
+}
+
+// preOrderTraverse visits the current node, then the left subtree, then the right subtree.
+func (bst *BinarySearchTree[T]) preOrderTraverse(node *TreeNode[T], f func(value T)) {
+	if node != nil {
+		f(node.Value)
+		bst.preOrderTraverse(node.Left, f)
+		bst.preOrderTraverse(node.Right, f)
+	}
+}
+
+// PostOrder performs a post-order traversal of the binary search tree, visiting each node after its
+// left and right subtrees, and applies the given function to each value.
+func (bst *BinarySearchTree[T]) PostOrder(f func(value T)) {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	bst.postOrderTraverse(bst.root, f)
+}
+
+// postOrderTraverse visits the left subtree, then the right subtree, then the current node.
+func (bst *BinarySearchTree[T]) postOrderTraverse(node *TreeNode[T], f func(value T)) {
+	if node != nil {
+		bst.postOrderTraverse(node.Left, f)
+		bst.postOrderTraverse(node.Right, f)
+		f(node.Value)
+	}
+}
+
+// LevelOrder performs a breadth-first traversal of the binary search tree, visiting the nodes level
+// by level from the root downwards and from left to right within a level, and applies the given
+// function to each value.
+func (bst *BinarySearchTree[T]) LevelOrder(f func(value T)) {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	if bst.root == nil {
+		return
+	}
+	level := []*TreeNode[T]{bst.root}
+	for len(level) > 0 {
+		var next []*TreeNode[T]
+		for _, node := range level {
+			f(node.Value)
+			if node.Left != nil {
+				next = append(next, node.Left)
+			}
+			if node.Right != nil {
+				next = append(next, node.Right)
+			}
+		}
+		level = next
+	}
+}
+
+// Height returns the number of nodes on the longest path from the root to a leaf. An empty tree
+// has height 0 and a tree with a single node has height 1.
+func (bst *BinarySearchTree[T]) Height() int {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	return height(bst.root)
+}
+
+// height returns the height of the subtree rooted at node.
+func height[T any](node *TreeNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return max(height(node.Left), height(node.Right)) + 1
+}
+
+// IsBalanced reports whether the binary search tree is height-balanced, i.e. whether the heights
+// of the two subtrees of every node differ by at most one.
+func (bst *BinarySearchTree[T]) IsBalanced() bool {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	return balancedHeight(bst.root) >= 0
+}
+
+// balancedHeight returns the height of the subtree rooted at node, or -1 if the subtree is not
+// height-balanced.
+func balancedHeight[T any](node *TreeNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	left := balancedHeight(node.Left)
+	if left < 0 {
+		return -1
+	}
+	right := balancedHeight(node.Right)
+	if right < 0 || left-right > 1 || right-left > 1 {
+		return -1
+	}
+	return max(left, right) + 1
+}
+
+// Validate checks the structure of the binary search tree. It returns an error if a value in a
+// left subtree is greater than its ancestor, if a value in a right subtree is less than its
+// ancestor, or if a stored subtree size is wrong, and nil otherwise. Equal values may appear in
+// either subtree, as rotations performed by Rebalance can move duplicates to the left.
+func (bst *BinarySearchTree[T]) Validate() error {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	_, err := bst.validateNode(bst.root, nil, nil)
+	return err
+}
+
+// validateNode checks the subtree rooted at node, whose values must lie between *lo and *hi
+// inclusive where these bounds are set, and returns the size of the subtree.
+func (bst *BinarySearchTree[T]) validateNode(node *TreeNode[T], lo, hi *T) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	if lo != nil && bst.cmp(node.Value, *lo) < 0 {
+		return 0, fmt.Errorf("structs: value %v is less than its ancestor %v", node.Value, *lo)
+	}
+	if hi != nil && bst.cmp(node.Value, *hi) > 0 {
+		return 0, fmt.Errorf("structs: value %v is greater than its ancestor %v", node.Value, *hi)
+	}
+	left, err := bst.validateNode(node.Left, lo, &node.Value)
+	if err != nil {
+		return 0, err
+	}
+	right, err := bst.validateNode(node.Right, &node.Value, hi)
+	if err != nil {
+		return 0, err
+	}
+	if node.size != left+right+1 {
+		return 0, fmt.Errorf("structs: node %v has size %d but its subtree holds %d nodes", node.Value, node.size, left+right+1)
+	}
+	return node.size, nil
+}
+
+// Rebalance rebuilds the binary search tree into a tree of minimal height using the
+// Day–Stout–Warren algorithm. It runs in O(N) time: right rotations first flatten the tree into
+// a sorted "vine" of right children, and a series of left rotations along the vine then folds it
+// into a balanced tree in which every level except the last is full. The rotations need O(1)
+// extra space; the subtree sizes are then recomputed recursively over the balanced tree, which
+// takes O(log N) stack.
+func (bst *BinarySearchTree[T]) Rebalance() {
+	bst.mu.Lock()
+	defer bst.mu.Unlock()
+	pseudoRoot := &TreeNode[T]{Right: bst.root}
+	n := treeToVine(pseudoRoot)
+	vineToTree(pseudoRoot, n)
+	bst.root = pseudoRoot.Right
+	resize(bst.root)
+}
+
+// treeToVine turns the tree hanging off the right child of root into a vine, a linked list of
+// right children in ascending order, by rotating right at every node that has a left child. It
+// returns the number of nodes in the vine.
+func treeToVine[T any](root *TreeNode[T]) int {
+	tail := root
+	rest := tail.Right
+	count := 0
+	for rest != nil {
+		if rest.Left == nil {
+			tail = rest
+			rest = rest.Right
+			count++
+		} else {
+			left := rest.Left
+			rest.Left = left.Right
+			left.Right = rest
+			rest = left
+			tail.Right = left
+		}
+	}
+	return count
+}
+
+// vineToTree folds the vine of n nodes hanging off the right child of root into a balanced tree.
+// The first pass creates the partial bottom level, so that the remaining nodes form a perfect
+// tree, and each further pass halves the length of the vine.
+func vineToTree[T any](root *TreeNode[T], n int) {
+	leaves := n + 1 - 1<<(bits.Len(uint(n+1))-1)
+	compress(root, leaves)
+	n -= leaves
+	for n > 1 {
+		n /= 2
+		compress(root, n)
+	}
+}
+
+// compress performs count left rotations along the vine hanging off the right child of root,
+// rotating every second node down to become the left child of its successor.
+func compress[T any](root *TreeNode[T], count int) {
+	scanner := root
+	for i := 0; i < count; i++ {
+		child := scanner.Right
+		scanner.Right = child.Right
+		scanner = scanner.Right
+		child.Right = scanner.Left
+		scanner.Left = child
+	}
+}
+
+// resize recomputes the subtree sizes of all nodes in the subtree rooted at node and returns the
+// size of the subtree.
+func resize[T any](node *TreeNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	node.size = resize(node.Left) + resize(node.Right) + 1
+	return node.size
+}
+
+// All returns an iterator over the values of the binary search tree in ascending order. The binary search tree is locked
+// while the loop runs; see the package documentation.
+func (bst *BinarySearchTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		bst.mu.Lock()
+		defer bst.mu.Unlock()
+		bst.ascend(bst.root, nil, nil, yield)
+	}
+}
+
+// Backward returns an iterator over the values of the binary search tree in descending order.
+func (bst *BinarySearchTree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		bst.mu.Lock()
+		defer bst.mu.Unlock()
+		bst.descend(bst.root, yield)
+	}
+}
+
+// Range returns an iterator over the values v with lo <= v < hi in ascending order. Subtrees that
+// lie entirely outside the range are skipped.
+func (bst *BinarySearchTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		bst.mu.Lock()
+		defer bst.mu.Unlock()
+		bst.ascend(bst.root, &lo, &hi, yield)
+	}
+}
+
+// ascend yields the values of the subtree rooted at node that are not less than *lo and less than
+// *hi in ascending order; a nil bound is unbounded. It returns false once iteration must stop,
+// either because yield returned false or because a value reached the upper bound.
+func (bst *BinarySearchTree[T]) ascend(node *TreeNode[T], lo, hi *T, yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	if lo == nil || bst.cmp(node.Value, *lo) >= 0 {
+		if !bst.ascend(node.Left, lo, hi, yield) {
+			return false
+		}
+		if hi != nil && bst.cmp(node.Value, *hi) >= 0 {
+			return false
+		}
+		if !yield(node.Value) {
+			return false
+		}
+	}
+	return bst.ascend(node.Right, lo, hi, yield)
+}
+
+// descend yields the values of the subtree rooted at node in descending order and returns false
+// if yield did.
+func (bst *BinarySearchTree[T]) descend(node *TreeNode[T], yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	return bst.descend(node.Right, yield) && yield(node.Value) && bst.descend(node.Left, yield)
+}
@@ -1,6 +1,10 @@
 package structs
 
 import (
+	"cmp"
+	"math/bits"
+	"reflect"
+	"slices"
 	"sync"
 	"testing"
 )
@@ -8,22 +12,22 @@ import (
 func TestNewBinarySearchTree(t *testing.T) {
 	tests := []struct {
 		name string
-		less func(a, b interface{}) bool
-		want *BinarySearchTree
+		cmp  func(a, b int) int
+		want *BinarySearchTree[int]
 	}{
 		{
 			name: "Should Create New BST",
-			less: func(a, b interface{}) bool { return a.(int) < b.(int) },
-			want: &BinarySearchTree{
-				less: func(a, b interface{}) bool { return a.(int) < b.(int) },
-				mu:   sync.Mutex{},
+			cmp:  cmp.Compare[int],
+			want: &BinarySearchTree[int]{
+				cmp: cmp.Compare[int],
+				mu:  sync.Mutex{},
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewBinarySearchTree(tt.less); got.less(1, 2) != tt.want.less(1, 2) {
+			if got := NewBinarySearchTree(tt.cmp); got.cmp(1, 2) != tt.want.cmp(1, 2) {
 				t.Errorf("NewBinarySearchTree() = %v, want %v", got, tt.want)
 			}
 		})
@@ -31,10 +35,10 @@ func TestNewBinarySearchTree(t *testing.T) {
 }
 
 func TestBinarySearchTree_Insert(t *testing.T) {
-	bst := NewBinarySearchTree(func(a, b interface{}) bool { return a.(int) < b.(int) })
+	bst := NewBinarySearchTree(cmp.Compare[int])
 	tests := []struct {
 		name  string
-		value interface{}
+		value int
 	}{
 		{
 			name:  "Insert Value Into BST",
@@ -52,11 +56,11 @@ func TestBinarySearchTree_Insert(t *testing.T) {
 }
 
 func TestBinarySearchTree_Search(t *testing.T) {
-	bst := NewBinarySearchTree(func(a, b interface{}) bool { return a.(int) < b.(int) })
+	bst := NewBinarySearchTree(cmp.Compare[int])
 	bst.Insert(5)
 	tests := []struct {
 		name  string
-		value interface{}
+		value int
 		want  bool
 	}{
 		{
@@ -80,17 +84,17 @@ func TestBinarySearchTree_Search(t *testing.T) {
 }
 
 func TestBinarySearchTree_InOrder(t *testing.T) {
-	bst := NewBinarySearchTree(func(a, b interface{}) bool { return a.(int) < b.(int) })
+	bst := NewBinarySearchTree(cmp.Compare[int])
 	for _, v := range []int{5, 3, 7, 2, 4, 6, 8} {
 		bst.Insert(v)
 	}
 	tests := []struct {
 		name string
-		f    func(value interface{})
+		f    func(value int)
 	}{
 		{
 			name: "InOrder Traversal Of BST",
-			f: func(value interface{}) {
+			f: func(value int) {
 				_ = value // ignoring the function as implementation will vary based on use-cases
 			},
 		},
@@ -102,3 +106,192 @@ func TestBinarySearchTree_InOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestBinarySearchTree_SelectRank(t *testing.T) {
+	bst := NewBinarySearchTree(cmp.Compare[int])
+	for _, v := range []int{5, 3, 7, 2, 4, 6, 8, 4} {
+		bst.Insert(v)
+	}
+	sorted := []int{2, 3, 4, 4, 5, 6, 7, 8}
+	for k, want := range sorted {
+		if got, ok := bst.Select(k); !ok || got != want {
+			t.Errorf("BinarySearchTree.Select(%d) = (%v, %v), want (%v, true)", k, got, ok, want)
+		}
+	}
+	if _, ok := bst.Select(len(sorted)); ok {
+		t.Errorf("BinarySearchTree.Select() out of range should return false")
+	}
+
+	tests := []struct {
+		value int
+		want  int
+	}{
+		{1, 0}, {2, 0}, {4, 2}, {5, 4}, {8, 7}, {9, 8},
+	}
+	for _, tt := range tests {
+		if got := bst.Rank(tt.value); got != tt.want {
+			t.Errorf("BinarySearchTree.Rank(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestBinarySearchTree_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		insert  []int
+		remove  []int
+		deleted []bool
+		want    []int
+	}{
+		{"Empty", []int{}, []int{1}, []bool{false}, []int{}},
+		{"Leaf", []int{5, 3, 7}, []int{3}, []bool{true}, []int{5, 7}},
+		{"OneChild", []int{5, 3, 2}, []int{3}, []bool{true}, []int{2, 5}},
+		{"TwoChildren", []int{5, 3, 7, 6, 8}, []int{7}, []bool{true}, []int{3, 5, 6, 8}},
+		{"Root", []int{5, 3, 7, 6}, []int{5}, []bool{true}, []int{3, 6, 7}},
+		{"Missing", []int{5, 3, 7}, []int{4}, []bool{false}, []int{3, 5, 7}},
+		{"Duplicate", []int{5, 5, 5}, []int{5, 5}, []bool{true, true}, []int{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bst := NewBinarySearchTree(cmp.Compare[int])
+			for _, v := range tt.insert {
+				bst.Insert(v)
+			}
+			for i, v := range tt.remove {
+				if got := bst.Delete(v); got != tt.deleted[i] {
+					t.Errorf("BinarySearchTree.Delete(%v) = %v, want %v", v, got, tt.deleted[i])
+				}
+			}
+			if err := bst.Validate(); err != nil {
+				t.Errorf("BinarySearchTree.Validate() = %v", err)
+			}
+			got := make([]int, 0)
+			bst.InOrder(func(value int) { got = append(got, value) })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BinarySearchTree.InOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinarySearchTree_Traversals(t *testing.T) {
+	bst := NewBinarySearchTree(cmp.Compare[int])
+	for _, v := range []int{5, 3, 7, 2, 4, 6, 8} {
+		bst.Insert(v)
+	}
+	tests := []struct {
+		name     string
+		traverse func(f func(value int))
+		want     []int
+	}{
+		{"PreOrder", bst.PreOrder, []int{5, 3, 2, 4, 7, 6, 8}},
+		{"InOrder", bst.InOrder, []int{2, 3, 4, 5, 6, 7, 8}},
+		{"PostOrder", bst.PostOrder, []int{2, 4, 3, 6, 8, 7, 5}},
+		{"LevelOrder", bst.LevelOrder, []int{5, 3, 7, 2, 4, 6, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]int, 0)
+			tt.traverse(func(value int) { got = append(got, value) })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BinarySearchTree.%s() = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinarySearchTree_Balance(t *testing.T) {
+	tests := []struct {
+		name     string
+		insert   []int
+		height   int
+		balanced bool
+	}{
+		{"Empty", []int{}, 0, true},
+		{"Single", []int{1}, 1, true},
+		{"Complete", []int{5, 3, 7, 2, 4, 6, 8}, 3, true},
+		{"Unbalanced", []int{5, 3, 2}, 3, false},
+		{"Degenerate", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bst := NewBinarySearchTree(cmp.Compare[int])
+			for _, v := range tt.insert {
+				bst.Insert(v)
+			}
+			if got := bst.Height(); got != tt.height {
+				t.Errorf("BinarySearchTree.Height() = %v, want %v", got, tt.height)
+			}
+			if got := bst.IsBalanced(); got != tt.balanced {
+				t.Errorf("BinarySearchTree.IsBalanced() = %v, want %v", got, tt.balanced)
+			}
+		})
+	}
+}
+
+func TestBinarySearchTree_Rebalance(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 100, 1000} {
+		bst := NewBinarySearchTree(cmp.Compare[int])
+		want := make([]int, 0)
+		for v := 0; v < n; v++ {
+			bst.Insert(v)
+			want = append(want, v)
+		}
+		bst.Rebalance()
+
+		if err := bst.Validate(); err != nil {
+			t.Fatalf("n=%d: BinarySearchTree.Validate() = %v", n, err)
+		}
+		if !bst.IsBalanced() {
+			t.Errorf("n=%d: tree is not balanced after Rebalance()", n)
+		}
+		if got, want := bst.Height(), bits.Len(uint(n)); got != want {
+			t.Errorf("n=%d: BinarySearchTree.Height() = %v, want %v", n, got, want)
+		}
+		got := make([]int, 0)
+		bst.InOrder(func(value int) { got = append(got, value) })
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("n=%d: values changed by Rebalance()", n)
+		}
+		if n > 0 {
+			if v, ok := bst.Select(n / 2); !ok || v != n/2 {
+				t.Errorf("n=%d: BinarySearchTree.Select() = %v after Rebalance(), want %v", n, v, n/2)
+			}
+		}
+	}
+}
+
+func TestBinarySearchTree_Validate(t *testing.T) {
+	bst := NewBinarySearchTree(cmp.Compare[int])
+	for _, v := range []int{5, 3, 7} {
+		bst.Insert(v)
+	}
+	if err := bst.Validate(); err != nil {
+		t.Fatalf("BinarySearchTree.Validate() = %v", err)
+	}
+	bst.root.Left.Value = 9
+	if err := bst.Validate(); err == nil {
+		t.Errorf("BinarySearchTree.Validate() should report an out-of-order value")
+	}
+	bst.root.Left.Value = 3
+	bst.root.size = 4
+	if err := bst.Validate(); err == nil {
+		t.Errorf("BinarySearchTree.Validate() should report a wrong size")
+	}
+}
+
+func TestBinarySearchTree_Iterators(t *testing.T) {
+	bst := NewBinarySearchTree(cmp.Compare[int])
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 40} {
+		bst.Insert(v)
+	}
+	if got := slices.Collect(bst.All()); !slices.Equal(got, []int{20, 30, 40, 40, 50, 60, 70, 80}) {
+		t.Errorf("All: unexpected values %v", got)
+	}
+	if got := slices.Collect(bst.Backward()); !slices.Equal(got, []int{80, 70, 60, 50, 40, 40, 30, 20}) {
+		t.Errorf("Backward: unexpected values %v", got)
+	}
+	if got := slices.Collect(bst.Range(35, 70)); !slices.Equal(got, []int{40, 40, 50, 60}) {
+		t.Errorf("Range: unexpected values %v", got)
+	}
+}
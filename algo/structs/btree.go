@@ -0,0 +1,612 @@
+package structs
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// bTreeNode is a node of a BTree. A leaf holds sorted keys with their values and a link to the
+// next leaf; an internal node holds separator keys and one more child than it has keys. Every
+// key in children[i] is less than or equal to keys[i], and every key in children[i+1] is greater
+// than or equal to it.
+type bTreeNode[K any, V any] struct {
+	keys     []K
+	values   []V
+	children []*bTreeNode[K, V]
+	next     *bTreeNode[K, V]
+}
+
+// leaf reports whether the node is a leaf.
+func (n *bTreeNode[K, V]) leaf() bool {
+	return n.children == nil
+}
+
+// BTree is a thread-safe B+ tree that maps keys of type K to values of type V.
+//
+// A B-tree stores many keys per node, so a lookup in a tree of N keys visits only O(log_t N)
+// nodes, and the keys of a node lie next to each other in memory, which makes the tree far more
+// cache-friendly than a binary search tree. In the B+ variant all entries live in the leaves and
+// the leaves are linked in key order, so ordered scans and range queries walk the leaves
+// sequentially instead of climbing up and down the tree.
+//
+// The minimum degree t given to NewBTree bounds the size of the nodes: every node except the root
+// holds between t-1 and 2t-1 keys, and every internal node has one child more than it has keys.
+//
+// The tree can be used as an ordered map through Put and Get, and as an ordered set of keys
+// through the OrderedSet methods. Like the other OrderedSet implementations, Insert adds a key
+// even if an equal key is already present, storing it with the zero value of V, while Put
+// replaces the value of an existing key. Delete removes one entry with the given key.
+type BTree[K any, V any] struct {
+	mu     sync.Mutex
+	root   *bTreeNode[K, V]
+	cmp    func(a, b K) int
+	degree int
+	size   int
+}
+
+var _ OrderedSet[int] = (*BTree[int, struct{}])(nil)
+
+// NewBTree creates a new empty B+ tree with the given minimum degree whose keys are ordered by
+// the given cmp function. The comparison function returns a negative number if a is less than b,
+// zero if they are equal, and a positive number if a is greater than b, like cmp.Compare.
+// NewBTree panics if the degree is less than 2.
+func NewBTree[K any, V any](degree int, cmp func(a, b K) int) *BTree[K, V] {
+	if degree < 2 {
+		panic(fmt.Sprintf("structs: B-tree degree %d is less than 2", degree))
+	}
+	return &BTree[K, V]{
+		root:   &bTreeNode[K, V]{},
+		cmp:    cmp,
+		degree: degree,
+	}
+}
+
+// maxKeys returns the largest number of keys a node may hold.
+func (t *BTree[K, V]) maxKeys() int {
+	return 2*t.degree - 1
+}
+
+// lowerBound returns the number of keys in keys that are less than key.
+func (t *BTree[K, V]) lowerBound(keys []K, key K) int {
+	i, _ := slices.BinarySearchFunc(keys, key, t.cmp)
+	return i
+}
+
+// upperBound returns the number of keys in keys that are less than or equal to key.
+func (t *BTree[K, V]) upperBound(keys []K, key K) int {
+	lo, hi := 0, len(keys)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if t.cmp(keys[mid], key) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// seek returns the leaf and the position within it of the first entry whose key is greater than
+// or equal to key, or a nil leaf if there is no such entry. The descent follows the first child
+// that may hold the key; all entries in the leaves to its left are less than the key, so if the
+// leaf has no such entry, the answer is the first entry of the next leaf.
+func (t *BTree[K, V]) seek(key K) (*bTreeNode[K, V], int) {
+	node := t.root
+	for !node.leaf() {
+		node = node.children[t.lowerBound(node.keys, key)]
+	}
+	i := t.lowerBound(node.keys, key)
+	for node != nil && i == len(node.keys) {
+		node, i = node.next, 0
+	}
+	return node, i
+}
+
+// find returns the leaf and the position within it of the first entry with the given key, or a
+// nil leaf if the key is not present.
+func (t *BTree[K, V]) find(key K) (*bTreeNode[K, V], int) {
+	node, i := t.seek(key)
+	if node == nil || t.cmp(node.keys[i], key) != 0 {
+		return nil, 0
+	}
+	return node, i
+}
+
+// Put associates the given value with the given key. If the key is already present, the value of
+// its first entry is replaced; otherwise a new entry is inserted.
+func (t *BTree[K, V]) Put(key K, value V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if node, i := t.find(key); node != nil {
+		node.values[i] = value
+		return
+	}
+	t.insert(key, value)
+}
+
+// Get returns the value of the first entry with the given key. The boolean result is false if the
+// key is not present, in which case the zero value of V is returned.
+func (t *BTree[K, V]) Get(key K) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if node, i := t.find(key); node != nil {
+		return node.values[i], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Insert adds an entry with the given key and the zero value of V, after any entries with an
+// equal key.
+func (t *BTree[K, V]) Insert(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var zero V
+	t.insert(key, zero)
+}
+
+// insert adds an entry to the tree. If the root overflows, it is split and the tree grows by one
+// level at the top, which keeps all leaves at the same depth.
+func (t *BTree[K, V]) insert(key K, value V) {
+	if sep, right := t.insertNode(t.root, key, value); right != nil {
+		t.root = &bTreeNode[K, V]{
+			keys:     []K{sep},
+			children: []*bTreeNode[K, V]{t.root, right},
+		}
+	}
+	t.size++
+}
+
+// insertNode adds an entry to the subtree rooted at node. If the node overflows, it is split in
+// two, and the separator key and the new right node are returned so that the caller can add them
+// to the parent; otherwise the returned node is nil.
+func (t *BTree[K, V]) insertNode(node *bTreeNode[K, V], key K, value V) (K, *bTreeNode[K, V]) {
+	i := t.upperBound(node.keys, key)
+	if node.leaf() {
+		node.keys = slices.Insert(node.keys, i, key)
+		node.values = slices.Insert(node.values, i, value)
+	} else {
+		sep, right := t.insertNode(node.children[i], key, value)
+		if right != nil {
+			node.keys = slices.Insert(node.keys, i, sep)
+			node.children = slices.Insert(node.children, i+1, right)
+		}
+	}
+	if len(node.keys) > t.maxKeys() {
+		return t.split(node)
+	}
+	var zero K
+	return zero, nil
+}
+
+// split divides an overflowing node with 2t keys into two nodes and returns the separator key
+// and the new right node. A leaf keeps t entries and moves t entries to the right, and the first
+// key of the right leaf becomes the separator, which stays in the leaf as well. An internal node
+// keeps t keys, moves its middle key up as the separator, and moves the remaining t-1 keys to the
+// right.
+func (t *BTree[K, V]) split(node *bTreeNode[K, V]) (K, *bTreeNode[K, V]) {
+	mid := t.degree
+	right := &bTreeNode[K, V]{}
+	var sep K
+	if node.leaf() {
+		right.keys = slices.Clone(node.keys[mid:])
+		right.values = slices.Clone(node.values[mid:])
+		right.next = node.next
+		node.next = right
+		node.keys = slices.Clip(node.keys[:mid])
+		node.values = slices.Clip(node.values[:mid])
+		sep = right.keys[0]
+	} else {
+		sep = node.keys[mid]
+		right.keys = slices.Clone(node.keys[mid+1:])
+		right.children = slices.Clone(node.children[mid+1:])
+		node.keys = slices.Clip(node.keys[:mid])
+		node.children = slices.Clip(node.children[:mid+1])
+	}
+	return sep, right
+}
+
+// Delete removes one entry with the given key and reports whether such an entry was found.
+// Nodes that fall below t-1 keys borrow a key from a sibling or are merged with it, and the tree
+// shrinks by one level when the root is left without keys.
+func (t *BTree[K, V]) Delete(key K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.deleteNode(t.root, key) {
+		return false
+	}
+	if !t.root.leaf() && len(t.root.keys) == 0 {
+		t.root = t.root.children[0]
+	}
+	t.size--
+	return true
+}
+
+// deleteNode removes one entry with the given key from the subtree rooted at node and reports
+// whether it was found. Entries with the key may be spread over all children between the first
+// and the last child whose range includes the key, so these are tried in turn; usually there is
+// only one.
+func (t *BTree[K, V]) deleteNode(node *bTreeNode[K, V], key K) bool {
+	if node.leaf() {
+		i := t.lowerBound(node.keys, key)
+		if i == len(node.keys) || t.cmp(node.keys[i], key) != 0 {
+			return false
+		}
+		node.keys = slices.Delete(node.keys, i, i+1)
+		node.values = slices.Delete(node.values, i, i+1)
+		return true
+	}
+	last := t.upperBound(node.keys, key)
+	for i := t.lowerBound(node.keys, key); i <= last; i++ {
+		if t.deleteNode(node.children[i], key) {
+			if len(node.children[i].keys) < t.degree-1 {
+				t.fixChild(node, i)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// fixChild restores the minimum number of keys in child i of node, which has one key too few.
+// The child borrows a key from a sibling that can spare one, or is otherwise merged with a
+// sibling, which removes a separator from node.
+func (t *BTree[K, V]) fixChild(node *bTreeNode[K, V], i int) {
+	child := node.children[i]
+	if i > 0 && len(node.children[i-1].keys) >= t.degree {
+		left := node.children[i-1]
+		last := len(left.keys) - 1
+		if child.leaf() {
+			child.keys = slices.Insert(child.keys, 0, left.keys[last])
+			child.values = slices.Insert(child.values, 0, left.values[last])
+			left.values = left.values[:last]
+			node.keys[i-1] = child.keys[0]
+		} else {
+			child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+			child.children = slices.Insert(child.children, 0, left.children[last+1])
+			left.children = left.children[:last+1]
+			node.keys[i-1] = left.keys[last]
+		}
+		left.keys = left.keys[:last]
+		return
+	}
+	if i < len(node.children)-1 && len(node.children[i+1].keys) >= t.degree {
+		right := node.children[i+1]
+		if child.leaf() {
+			child.keys = append(child.keys, right.keys[0])
+			child.values = append(child.values, right.values[0])
+			right.values = slices.Delete(right.values, 0, 1)
+			right.keys = slices.Delete(right.keys, 0, 1)
+			node.keys[i] = right.keys[0]
+		} else {
+			child.keys = append(child.keys, node.keys[i])
+			child.children = append(child.children, right.children[0])
+			node.keys[i] = right.keys[0]
+			right.keys = slices.Delete(right.keys, 0, 1)
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+		return
+	}
+	if i > 0 {
+		i--
+	}
+	t.merge(node, i)
+}
+
+// merge joins child i+1 of node into child i and removes the separator between them. Merged
+// leaves simply concatenate their entries, while merged internal nodes pull the separator down
+// between their keys.
+func (t *BTree[K, V]) merge(node *bTreeNode[K, V], i int) {
+	left, right := node.children[i], node.children[i+1]
+	if left.leaf() {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+	} else {
+		left.keys = append(append(left.keys, node.keys[i]), right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.children = slices.Delete(node.children, i+1, i+2)
+}
+
+// Search reports whether an entry with the given key is present in the tree.
+func (t *BTree[K, V]) Search(key K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node, _ := t.find(key)
+	return node != nil
+}
+
+// Len returns the number of entries in the tree.
+func (t *BTree[K, V]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.size
+}
+
+// Height returns the number of levels of the tree, counting the leaves. An empty tree consists
+// of a single empty leaf and has height 1.
+func (t *BTree[K, V]) Height() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	height := 1
+	for node := t.root; !node.leaf(); node = node.children[0] {
+		height++
+	}
+	return height
+}
+
+// Min returns the smallest key in the tree. The boolean result is false if the tree is empty.
+func (t *BTree[K, V]) Min() (K, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for !node.leaf() {
+		node = node.children[0]
+	}
+	return t.keyAt(node, 0)
+}
+
+// Max returns the largest key in the tree. The boolean result is false if the tree is empty.
+func (t *BTree[K, V]) Max() (K, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.maxKey(t.root)
+}
+
+// maxKey returns the largest key in the subtree rooted at node.
+func (t *BTree[K, V]) maxKey(node *bTreeNode[K, V]) (K, bool) {
+	for !node.leaf() {
+		node = node.children[len(node.children)-1]
+	}
+	return t.keyAt(node, len(node.keys)-1)
+}
+
+// Floor returns the largest key in the tree that is less than or equal to the given key. The
+// boolean result is false if there is no such key.
+//
+// The descent follows the last child that may hold the key and remembers the subtree to the left
+// of the deepest turn away from the first child. If the leaf reached holds no key less than or
+// equal to the given key, the answer is the largest key of that subtree.
+func (t *BTree[K, V]) Floor(key K) (K, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var left *bTreeNode[K, V]
+	node := t.root
+	for !node.leaf() {
+		i := t.upperBound(node.keys, key)
+		if i > 0 {
+			left = node.children[i-1]
+		}
+		node = node.children[i]
+	}
+	if i := t.upperBound(node.keys, key); i > 0 {
+		return node.keys[i-1], true
+	}
+	if left == nil {
+		var zero K
+		return zero, false
+	}
+	return t.maxKey(left)
+}
+
+// Ceiling returns the smallest key in the tree that is greater than or equal to the given key.
+// The boolean result is false if there is no such key.
+func (t *BTree[K, V]) Ceiling(key K) (K, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.keyAt(t.seek(key))
+}
+
+// keyAt returns the key at position i of the leaf node and true, or the zero value of K and
+// false if node is nil or i is out of range.
+func (t *BTree[K, V]) keyAt(node *bTreeNode[K, V], i int) (K, bool) {
+	if node == nil || i < 0 || i >= len(node.keys) {
+		var zero K
+		return zero, false
+	}
+	return node.keys[i], true
+}
+
+// InOrder calls f for the key of every entry in ascending order, walking the linked leaves.
+func (t *BTree[K, V]) InOrder(f func(key K)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for !node.leaf() {
+		node = node.children[0]
+	}
+	for ; node != nil; node = node.next {
+		for _, key := range node.keys {
+			f(key)
+		}
+	}
+}
+
+// Ascend calls fn for every entry in ascending key order until fn returns false.
+// The tree is locked for the duration of the call, so fn must not modify the tree.
+func (t *BTree[K, V]) Ascend(fn func(key K, value V) bool) {
+	t.All()(fn)
+}
+
+// All returns an iterator over the entries of the tree in ascending key order, walking the linked
+// leaves. The tree is locked while the loop runs; see the package documentation.
+func (t *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		node := t.root
+		for !node.leaf() {
+			node = node.children[0]
+		}
+		t.scan(node, 0, nil, yield)
+	}
+}
+
+// Backward returns an iterator over the entries of the tree in descending key order. The leaves
+// only link forward, so the loop walks the tree from right to left instead.
+func (t *BTree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.descend(t.root, yield)
+	}
+}
+
+// descend yields the entries of the subtree rooted at node in descending key order and returns
+// false if yield did.
+func (t *BTree[K, V]) descend(node *bTreeNode[K, V], yield func(K, V) bool) bool {
+	if node.leaf() {
+		for i := len(node.keys) - 1; i >= 0; i-- {
+			if !yield(node.keys[i], node.values[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := len(node.children) - 1; i >= 0; i-- {
+		if !t.descend(node.children[i], yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Range returns an iterator over the entries whose key lies in the half-open range [lo, hi), in
+// ascending key order. The first entry is located in O(log N) time, after which the loop walks the
+// linked leaves without going back up the tree.
+func (t *BTree[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		node, i := t.seek(lo)
+		t.scan(node, i, &hi, yield)
+	}
+}
+
+// Keys returns an iterator over the keys of the tree in ascending order.
+func (t *BTree[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range t.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the tree in ascending key order.
+func (t *BTree[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range t.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// scan calls fn for the entries starting at position i of the leaf node and following the leaf
+// links, until fn returns false or, if hi is not nil, a key is not less than *hi.
+func (t *BTree[K, V]) scan(node *bTreeNode[K, V], i int, hi *K, fn func(key K, value V) bool) {
+	for ; node != nil; node, i = node.next, 0 {
+		for ; i < len(node.keys); i++ {
+			if hi != nil && t.cmp(node.keys[i], *hi) >= 0 {
+				return
+			}
+			if !fn(node.keys[i], node.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// BulkLoad replaces the contents of the tree with the given entries, which must be sorted by key.
+// keys[i] is stored with values[i]; if values is nil, every key is stored with the zero value of
+// V. It returns an error and leaves the tree unchanged if the lengths differ or the keys are not
+// sorted.
+//
+// Instead of inserting the entries one by one, BulkLoad builds the tree bottom-up in O(N) time:
+// the entries are spread evenly over as few leaves as possible, and each level above is built by
+// spreading the nodes of the level below evenly over as few parents as possible. The resulting
+// nodes are nearly full, which makes the tree shallower than one built by insertions.
+func (t *BTree[K, V]) BulkLoad(keys []K, values []V) error {
+	if values != nil && len(values) != len(keys) {
+		return fmt.Errorf("structs: %d keys but %d values", len(keys), len(values))
+	}
+	for i := 1; i < len(keys); i++ {
+		if t.cmp(keys[i-1], keys[i]) > 0 {
+			return fmt.Errorf("structs: key %v at index %d is less than its predecessor %v", keys[i], i, keys[i-1])
+		}
+	}
+	if values == nil {
+		values = make([]V, len(keys))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	level := make([]*bTreeNode[K, V], 0, blockCount(len(keys), t.maxKeys()))
+	// lows[i] is the smallest key below level[i], which becomes the separator in front of it.
+	var lows []K
+	var prev *bTreeNode[K, V]
+	for _, r := range evenSplit(len(keys), t.maxKeys()) {
+		leaf := &bTreeNode[K, V]{
+			keys:   slices.Clone(keys[r[0]:r[1]]),
+			values: slices.Clone(values[r[0]:r[1]]),
+		}
+		if prev != nil {
+			prev.next = leaf
+		}
+		prev = leaf
+		level = append(level, leaf)
+		lows = append(lows, keys[r[0]])
+	}
+	for len(level) > 1 {
+		var parents []*bTreeNode[K, V]
+		var parentLows []K
+		for _, r := range evenSplit(len(level), t.maxKeys()+1) {
+			parents = append(parents, &bTreeNode[K, V]{
+				keys:     slices.Clone(lows[r[0]+1 : r[1]]),
+				children: slices.Clone(level[r[0]:r[1]]),
+			})
+			parentLows = append(parentLows, lows[r[0]])
+		}
+		level, lows = parents, parentLows
+	}
+
+	if len(level) == 0 {
+		t.root = &bTreeNode[K, V]{}
+	} else {
+		t.root = level[0]
+	}
+	t.size = len(keys)
+	return nil
+}
+
+// blockCount returns the number of blocks of at most size items needed to hold n items.
+func blockCount(n, size int) int {
+	return (n + size - 1) / size
+}
+
+// evenSplit divides n items into as few runs of at most size items as possible, with lengths that
+// differ by at most one, and returns the half-open index range of every run. If there is more than
+// one run, every run holds at least size/2 items, rounded down, so leaves built from runs of 2t-1
+// entries hold at least t-1 keys and internal nodes built from runs of 2t children hold at least
+// t children.
+func evenSplit(n, size int) [][2]int {
+	count := blockCount(n, size)
+	runs := make([][2]int, count)
+	start := 0
+	for i := range runs {
+		end := start + n/count
+		if i < n%count {
+			end++
+		}
+		runs[i] = [2]int{start, end}
+		start = end
+	}
+	return runs
+}
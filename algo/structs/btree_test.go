@@ -0,0 +1,318 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// verifyBTree checks the invariants of a B+ tree: every node except the root holds between t-1
+// and 2t-1 keys, internal nodes have one child more than keys, the keys of every node are sorted
+// and lie within the bounds given by the separators above them, all leaves are at the same depth,
+// the leaf links visit the leaves from left to right, and the size is correct.
+func verifyBTree[K any, V any](t *BTree[K, V]) error {
+	var leaves []*bTreeNode[K, V]
+	leafDepth := -1
+	var walk func(node *bTreeNode[K, V], depth int, lo, hi *K) error
+	walk = func(node *bTreeNode[K, V], depth int, lo, hi *K) error {
+		if node != t.root && (len(node.keys) < t.degree-1 || len(node.keys) > t.maxKeys()) {
+			return fmt.Errorf("node %v has %d keys", node.keys, len(node.keys))
+		}
+		for i, key := range node.keys {
+			if i > 0 && t.cmp(node.keys[i-1], key) > 0 {
+				return fmt.Errorf("keys %v are not sorted", node.keys)
+			}
+			if lo != nil && t.cmp(key, *lo) < 0 || hi != nil && t.cmp(key, *hi) > 0 {
+				return fmt.Errorf("key %v is outside the bounds of its parent", key)
+			}
+		}
+		if node.leaf() {
+			if len(node.values) != len(node.keys) {
+				return fmt.Errorf("leaf %v has %d values", node.keys, len(node.values))
+			}
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if depth != leafDepth {
+				return fmt.Errorf("leaf %v is at depth %d instead of %d", node.keys, depth, leafDepth)
+			}
+			leaves = append(leaves, node)
+			return nil
+		}
+		if len(node.children) != len(node.keys)+1 {
+			return fmt.Errorf("node %v has %d children", node.keys, len(node.children))
+		}
+		if len(node.keys) == 0 {
+			return fmt.Errorf("internal node without keys")
+		}
+		for i, child := range node.children {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = &node.keys[i-1]
+			}
+			if i < len(node.keys) {
+				childHi = &node.keys[i]
+			}
+			if err := walk(child, depth+1, childLo, childHi); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(t.root, 0, nil, nil); err != nil {
+		return err
+	}
+	count := 0
+	for i, leaf := range leaves {
+		var next *bTreeNode[K, V]
+		if i+1 < len(leaves) {
+			next = leaves[i+1]
+		}
+		if leaf.next != next {
+			return fmt.Errorf("leaf %v is not linked to its right neighbour", leaf.keys)
+		}
+		count += len(leaf.keys)
+	}
+	if count != t.size {
+		return fmt.Errorf("tree has %d entries but size %d", count, t.size)
+	}
+	return nil
+}
+
+func TestBTreePutGet(t *testing.T) {
+	tree := NewBTree[string, int](2, cmp.Compare[string])
+	words := []string{"pear", "apple", "fig", "kiwi", "plum", "date", "lime", "cherry"}
+	for i, w := range words {
+		tree.Put(w, i)
+	}
+	tree.Put("fig", 100)
+	if tree.Len() != len(words) {
+		t.Errorf("Expected length %d but got %d", len(words), tree.Len())
+	}
+	if v, ok := tree.Get("fig"); v != 100 || !ok {
+		t.Errorf("Expected (100, true) but got (%d, %v)", v, ok)
+	}
+	if v, ok := tree.Get("plum"); v != 4 || !ok {
+		t.Errorf("Expected (4, true) but got (%d, %v)", v, ok)
+	}
+	if _, ok := tree.Get("grape"); ok {
+		t.Errorf("Expected grape not to be found")
+	}
+	if err := verifyBTree(tree); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBTreeRandomOperations(t *testing.T) {
+	for _, degree := range []int{2, 3, 5} {
+		t.Run(fmt.Sprintf("Degree%d", degree), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(int64(degree)))
+			tree := NewBTree[int, int](degree, cmp.Compare[int])
+			model := map[int]int{}
+			for i := 0; i < 5000; i++ {
+				key := rng.Intn(500)
+				if rng.Intn(3) == 0 {
+					_, found := model[key]
+					if deleted := tree.Delete(key); deleted != found {
+						t.Fatalf("Delete(%d): expected %v but got %v", key, found, deleted)
+					}
+					delete(model, key)
+				} else {
+					tree.Put(key, i)
+					model[key] = i
+				}
+				if i%500 == 0 {
+					if err := verifyBTree(tree); err != nil {
+						t.Fatalf("After %d operations: %v", i, err)
+					}
+				}
+			}
+			if err := verifyBTree(tree); err != nil {
+				t.Fatal(err)
+			}
+			for key, value := range model {
+				if v, ok := tree.Get(key); v != value || !ok {
+					t.Fatalf("Get(%d): expected (%d, true) but got (%d, %v)", key, value, v, ok)
+				}
+			}
+			for key := range model {
+				tree.Delete(key)
+			}
+			if err := verifyBTree(tree); err != nil || tree.Len() != 0 || tree.Height() != 1 {
+				t.Errorf("Expected an empty tree of height 1 but got length %d, height %d, error %v", tree.Len(), tree.Height(), err)
+			}
+		})
+	}
+}
+
+func TestBTreeDuplicates(t *testing.T) {
+	tree := NewBTree[int, struct{}](2, cmp.Compare[int])
+	for i := 0; i < 50; i++ {
+		tree.Insert(i % 3)
+	}
+	if err := verifyBTree(tree); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 17; i++ {
+		if !tree.Delete(1) {
+			t.Fatalf("Expected deletion %d of 1 to succeed", i)
+		}
+		if err := verifyBTree(tree); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tree.Search(1) || tree.Delete(1) {
+		t.Errorf("Expected no 1 to be left")
+	}
+	if v, ok := tree.Floor(1); v != 0 || !ok {
+		t.Errorf("Floor(1): expected (0, true) but got (%d, %v)", v, ok)
+	}
+	if v, ok := tree.Ceiling(1); v != 2 || !ok {
+		t.Errorf("Ceiling(1): expected (2, true) but got (%d, %v)", v, ok)
+	}
+}
+
+func TestBTreeRange(t *testing.T) {
+	tree := NewBTree[int, int](3, cmp.Compare[int])
+	for i := 0; i < 100; i++ {
+		tree.Put(i*2, i)
+	}
+
+	tt := []struct {
+		name     string
+		lo, hi   int
+		limit    int
+		expected []int
+	}{
+		{"Inner", 10, 20, -1, []int{10, 12, 14, 16, 18}},
+		{"Unaligned", 11, 19, -1, []int{12, 14, 16, 18}},
+		{"Empty", 11, 12, -1, []int{}},
+		{"BelowAll", -10, 3, -1, []int{0, 2}},
+		{"AboveAll", 195, 300, -1, []int{196, 198}},
+		{"Stopped", 0, 200, 3, []int{0, 2, 4}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []int{}
+			tree.Range(tc.lo, tc.hi)(func(key, value int) bool {
+				got = append(got, key)
+				return len(got) != tc.limit
+			})
+			if !slices.Equal(got, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, got)
+			}
+		})
+	}
+
+	var backward []int
+	for key := range tree.Backward() {
+		backward = append(backward, key)
+		if len(backward) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(backward, []int{198, 196, 194}) {
+		t.Errorf("Backward: expected [198 196 194] but got %v", backward)
+	}
+	if keys := slices.Collect(tree.Keys()); len(keys) != 100 || keys[99] != 198 {
+		t.Errorf("Keys: unexpected keys %v", keys)
+	}
+
+	count := 0
+	tree.Ascend(func(key, value int) bool {
+		if key != value*2 {
+			t.Errorf("Key %d has value %d", key, value)
+		}
+		count++
+		return true
+	})
+	if count != 100 {
+		t.Errorf("Expected 100 entries but got %d", count)
+	}
+}
+
+func TestBTreeBulkLoad(t *testing.T) {
+	for _, degree := range []int{2, 3, 16} {
+		for _, n := range []int{0, 1, 2, 3, 7, 31, 32, 33, 100, 1000} {
+			t.Run(fmt.Sprintf("Degree%dN%d", degree, n), func(t *testing.T) {
+				keys := make([]int, n)
+				values := make([]string, n)
+				for i := range keys {
+					keys[i] = i / 2
+					values[i] = fmt.Sprint(i)
+				}
+				tree := NewBTree[int, string](degree, cmp.Compare[int])
+				tree.Put(-1, "gone")
+				if err := tree.BulkLoad(keys, values); err != nil {
+					t.Fatal(err)
+				}
+				if err := verifyBTree(tree); err != nil {
+					t.Fatal(err)
+				}
+				if tree.Len() != n || tree.Search(-1) {
+					t.Errorf("Expected exactly the %d loaded entries", n)
+				}
+				got := []string{}
+				tree.Ascend(func(key int, value string) bool {
+					got = append(got, value)
+					return true
+				})
+				if !slices.Equal(got, values) {
+					t.Errorf("Expected values %v but got %v", values, got)
+				}
+				// The loaded tree must stay valid under further modifications.
+				for i := 0; i < n; i += 3 {
+					tree.Delete(keys[i])
+					tree.Insert(keys[i] + 1)
+				}
+				if err := verifyBTree(tree); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
+
+func TestBTreeBulkLoadErrors(t *testing.T) {
+	tree := NewBTree[int, int](2, cmp.Compare[int])
+	tree.Put(1, 1)
+	if err := tree.BulkLoad([]int{1, 2}, []int{1}); err == nil {
+		t.Errorf("Expected an error for mismatched lengths")
+	}
+	if err := tree.BulkLoad([]int{1, 3, 2}, nil); err == nil {
+		t.Errorf("Expected an error for unsorted keys")
+	}
+	if v, ok := tree.Get(1); v != 1 || !ok || tree.Len() != 1 {
+		t.Errorf("Expected the tree to be unchanged")
+	}
+	if err := tree.BulkLoad([]int{4, 5}, nil); err != nil || tree.Search(1) || !tree.Search(5) {
+		t.Errorf("Expected nil values to load zero values, got error %v", err)
+	}
+}
+
+func TestBTreeHeight(t *testing.T) {
+	keys := make([]int, 100000)
+	for i := range keys {
+		keys[i] = i
+	}
+	tree := NewBTree[int, struct{}](16, cmp.Compare[int])
+	if err := tree.BulkLoad(keys, nil); err != nil {
+		t.Fatal(err)
+	}
+	// 100000 keys in leaves of 31 keys need 3226 leaves, which fit under two levels of
+	// nodes with 32 children.
+	if h := tree.Height(); h != 4 {
+		t.Errorf("Expected height 4 but got %d", h)
+	}
+}
+
+func TestNewBTreePanicsOnSmallDegree(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+	NewBTree[int, int](1, cmp.Compare[int])
+}
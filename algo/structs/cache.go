@@ -0,0 +1,176 @@
+package structs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Cache is the interface shared by the bounded caches in this package: LRUCache, LFUCache and
+// ARCCache. They differ only in which entry they evict when a new key does not fit, so they can
+// be swapped for one another to find the policy that suits a workload best.
+//
+// Get returns the value of a key and counts a hit, or counts a miss if the key is absent or has
+// expired; a hit also tells the eviction policy that the key was used. Peek returns the value of
+// a key without counting or recording the access. Put adds or replaces a value, evicting another
+// entry if the cache is full, and restarts the time to live of the key. Remove deletes a key and
+// reports whether it was present. Len returns the number of entries and Capacity the maximum
+// number. Purge removes all entries, and Stats returns the hit and miss counters.
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Peek(key K) (V, bool)
+	Put(key K, value V)
+	Remove(key K) bool
+	Len() int
+	Capacity() int
+	Purge()
+	Stats() CacheStats
+}
+
+var (
+	_ Cache[int, int] = (*LRUCache[int, int])(nil)
+	_ Cache[int, int] = (*LFUCache[int, int])(nil)
+	_ Cache[int, int] = (*ARCCache[int, int])(nil)
+)
+
+// EvictionReason tells an eviction callback why an entry left the cache.
+type EvictionReason int
+
+const (
+	// EvictedCapacity means the entry was evicted to make room for another one.
+	EvictedCapacity EvictionReason = iota
+	// EvictedExpired means the time to live of the entry ran out.
+	EvictedExpired
+)
+
+// String returns the name of the reason.
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictedCapacity:
+		return "capacity"
+	case EvictedExpired:
+		return "expired"
+	}
+	return "unknown"
+}
+
+// CacheOptions configures a cache.
+// TTL is the time to live of an entry, counted from the last Put of its key; zero or a negative
+// value means entries never expire. Expired entries are removed when they are next looked up or
+// when PurgeExpired is called, and they count as misses. OnEvict, if not nil, is called for every
+// entry that is evicted because the cache is full or because it expired, but not for entries
+// that are removed with Remove or Purge or whose value is replaced by Put. It is called after the
+// cache has been unlocked, so it may use the cache.
+type CacheOptions[K comparable, V any] struct {
+	TTL     time.Duration
+	OnEvict func(key K, value V, reason EvictionReason)
+}
+
+// CacheStats counts the lookups of a cache and the entries it evicted. Hits and Misses count the
+// calls to Get; Evictions counts the entries evicted for capacity and Expirations the entries
+// removed because they expired.
+type CacheStats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+}
+
+// HitRatio returns the fraction of lookups that were hits, or zero if there were none.
+func (s CacheStats) HitRatio() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+// cacheEntry is a key-value pair held by a cache. expires is the zero time if the entry never
+// expires.
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// cacheCore holds the state and the bookkeeping shared by all caches: the lock, the options, the
+// statistics and the evictions whose callbacks are still to be run.
+type cacheCore[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	onEvict  func(key K, value V, reason EvictionReason)
+	now      func() time.Time
+	stats    CacheStats
+	pending  []evictedEntry[K, V]
+}
+
+// evictedEntry is an evicted entry whose callback has not been run yet.
+type evictedEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+// newCacheCore validates the capacity and returns the shared state of a cache.
+func newCacheCore[K comparable, V any](kind string, capacity int, opts CacheOptions[K, V]) cacheCore[K, V] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("structs: %s cache capacity %d is less than 1", kind, capacity))
+	}
+	return cacheCore[K, V]{capacity: capacity, ttl: opts.TTL, onEvict: opts.OnEvict, now: time.Now}
+}
+
+// newEntry returns an entry for the given key and value that expires after the time to live.
+func (c *cacheCore[K, V]) newEntry(key K, value V) cacheEntry[K, V] {
+	e := cacheEntry[K, V]{key: key, value: value}
+	if c.ttl > 0 {
+		e.expires = c.now().Add(c.ttl)
+	}
+	return e
+}
+
+// expired reports whether the given entry has expired.
+func (c *cacheCore[K, V]) expired(e *cacheEntry[K, V]) bool {
+	return !e.expires.IsZero() && !c.now().Before(e.expires)
+}
+
+// evicted counts an evicted entry and queues its callback.
+func (c *cacheCore[K, V]) evicted(e *cacheEntry[K, V], reason EvictionReason) {
+	if reason == EvictedExpired {
+		c.stats.Expirations++
+	} else {
+		c.stats.Evictions++
+	}
+	if c.onEvict != nil {
+		c.pending = append(c.pending, evictedEntry[K, V]{key: e.key, value: e.value, reason: reason})
+	}
+}
+
+// unlock releases the lock and then runs the callbacks of the entries evicted while it was held,
+// so that the callbacks may use the cache.
+func (c *cacheCore[K, V]) unlock() {
+	pending := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+	for _, e := range pending {
+		c.onEvict(e.key, e.value, e.reason)
+	}
+}
+
+// Capacity returns the maximum number of entries the cache holds.
+func (c *cacheCore[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Stats returns the hit, miss and eviction counters of the cache.
+func (c *cacheCore[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// ResetStats sets the hit, miss and eviction counters of the cache to zero.
+func (c *cacheCore[K, V]) ResetStats() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats = CacheStats{}
+}
@@ -0,0 +1,332 @@
+package structs
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+// caches lists a constructor for every Cache implementation and a function that checks the
+// structure of a cache built by it. Besides the cache, the constructor returns the shared state,
+// so that the tests can replace the clock.
+var caches = []struct {
+	name   string
+	new    func(capacity int, opts CacheOptions[int, int]) (Cache[int, int], *cacheCore[int, int])
+	verify func(c Cache[int, int]) error
+}{
+	{
+		name: "LRUCache",
+		new: func(capacity int, opts CacheOptions[int, int]) (Cache[int, int], *cacheCore[int, int]) {
+			c := NewLRUCacheWithOptions(capacity, opts)
+			return c, &c.cacheCore
+		},
+		verify: func(c Cache[int, int]) error { return verifyLRUCache(c.(*LRUCache[int, int])) },
+	},
+	{
+		name: "LFUCache",
+		new: func(capacity int, opts CacheOptions[int, int]) (Cache[int, int], *cacheCore[int, int]) {
+			c := NewLFUCacheWithOptions(capacity, opts)
+			return c, &c.cacheCore
+		},
+		verify: func(c Cache[int, int]) error { return verifyLFUCache(c.(*LFUCache[int, int])) },
+	},
+	{
+		name: "ARCCache",
+		new: func(capacity int, opts CacheOptions[int, int]) (Cache[int, int], *cacheCore[int, int]) {
+			c := NewARCCacheWithOptions(capacity, opts)
+			return c, &c.cacheCore
+		},
+		verify: func(c Cache[int, int]) error { return verifyARCCache(c.(*ARCCache[int, int])) },
+	},
+}
+
+// fakeClock is a clock for the tests that only moves when it is told to.
+type fakeClock struct {
+	t time.Time
+}
+
+func (f *fakeClock) now() time.Time {
+	return f.t
+}
+
+func TestCacheBasics(t *testing.T) {
+	for _, impl := range caches {
+		t.Run(impl.name, func(t *testing.T) {
+			c, _ := impl.new(3, CacheOptions[int, int]{})
+			if c.Capacity() != 3 || c.Len() != 0 {
+				t.Fatalf("Expected an empty cache of capacity 3")
+			}
+			for i := 1; i <= 3; i++ {
+				c.Put(i, i*10)
+			}
+			if v, ok := c.Get(2); !ok || v != 20 {
+				t.Errorf("Expected (20, true) but got (%d, %v)", v, ok)
+			}
+			c.Put(2, 21)
+			if v, ok := c.Peek(2); !ok || v != 21 {
+				t.Errorf("Expected Put to replace the value, got (%d, %v)", v, ok)
+			}
+			if _, ok := c.Get(4); ok {
+				t.Errorf("Expected key 4 to be absent")
+			}
+			for i := 4; i <= 10; i++ {
+				c.Put(i, i*10)
+				if c.Len() > 3 {
+					t.Fatalf("Cache holds %d entries, more than its capacity", c.Len())
+				}
+			}
+			if !c.Remove(10) || c.Remove(10) {
+				t.Errorf("Expected key 10 to be removed exactly once")
+			}
+			stats := c.Stats()
+			if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 7 || stats.HitRatio() != 0.5 {
+				t.Errorf("Unexpected statistics %+v", stats)
+			}
+			c.Purge()
+			if c.Len() != 0 {
+				t.Errorf("Expected Purge to empty the cache")
+			}
+			if _, ok := c.Peek(9); ok {
+				t.Errorf("Expected key 9 to be purged")
+			}
+		})
+	}
+}
+
+func TestCacheCapacityPanics(t *testing.T) {
+	for _, impl := range caches {
+		t.Run(impl.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic for capacity 0")
+				}
+			}()
+			impl.new(0, CacheOptions[int, int]{})
+		})
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	for _, impl := range caches {
+		t.Run(impl.name, func(t *testing.T) {
+			var evicted []string
+			clock := &fakeClock{t: time.Unix(0, 0)}
+			c, core := impl.new(4, CacheOptions[int, int]{
+				TTL: time.Minute,
+				OnEvict: func(key, value int, reason EvictionReason) {
+					evicted = append(evicted, fmt.Sprintf("%d=%d:%v", key, value, reason))
+				},
+			})
+			core.now = clock.now
+
+			c.Put(1, 10)
+			clock.t = clock.t.Add(30 * time.Second)
+			c.Put(2, 20)
+			c.Put(3, 30)
+			clock.t = clock.t.Add(30 * time.Second)
+
+			if _, ok := c.Get(1); ok {
+				t.Errorf("Expected key 1 to have expired")
+			}
+			if v, ok := c.Get(2); !ok || v != 20 {
+				t.Errorf("Expected key 2 to be alive, got (%d, %v)", v, ok)
+			}
+			c.Put(3, 31)
+			clock.t = clock.t.Add(45 * time.Second)
+			if n := core.stats.Expirations; n != 1 {
+				t.Errorf("Expected 1 expiration but got %d", n)
+			}
+			if n := c.(interface{ PurgeExpired() int }).PurgeExpired(); n != 1 {
+				t.Errorf("Expected PurgeExpired to remove key 2 only but it removed %d entries", n)
+			}
+			if v, ok := c.Peek(3); !ok || v != 31 || c.Len() != 1 {
+				t.Errorf("Expected only key 3 to survive, got (%d, %v) and %d entries", v, ok, c.Len())
+			}
+			want := "[1=10:expired 2=20:expired]"
+			if got := fmt.Sprint(evicted); got != want {
+				t.Errorf("Expected callbacks %s but got %s", want, got)
+			}
+			if stats := c.Stats(); stats.Hits != 1 || stats.Misses != 1 || stats.Expirations != 2 {
+				t.Errorf("Unexpected statistics %+v", stats)
+			}
+		})
+	}
+}
+
+func TestCacheEvictionCallback(t *testing.T) {
+	for _, impl := range caches {
+		t.Run(impl.name, func(t *testing.T) {
+			var c Cache[int, int]
+			var evicted []int
+			c, _ = impl.new(2, CacheOptions[int, int]{
+				OnEvict: func(key, value int, reason EvictionReason) {
+					if reason != EvictedCapacity || value != key*10 {
+						t.Errorf("Unexpected eviction of %d=%d for %v", key, value, reason)
+					}
+					// The cache is unlocked while the callback runs.
+					if c.Len() != 2 {
+						t.Errorf("Expected the cache to be full again when the callback runs")
+					}
+					evicted = append(evicted, key)
+				},
+			})
+			for i := 1; i <= 5; i++ {
+				c.Put(i, i*10)
+			}
+			c.Remove(5)
+			c.Purge()
+			if fmt.Sprint(evicted) != "[1 2 3]" {
+				t.Errorf("Expected keys [1 2 3] to be evicted but got %v", evicted)
+			}
+		})
+	}
+}
+
+func TestCacheScanResistance(t *testing.T) {
+	// A hot set of 50 keys is used over and over while a scan touches 1000 other keys once each.
+	// LRU lets the scan flush the hot keys; LFU and ARC keep them.
+	resistant := map[string]bool{"LRUCache": false, "LFUCache": true, "ARCCache": true}
+	for _, impl := range caches {
+		t.Run(impl.name, func(t *testing.T) {
+			c, _ := impl.new(100, CacheOptions[int, int]{})
+			get := func(key int) {
+				if _, ok := c.Get(key); !ok {
+					c.Put(key, key)
+				}
+			}
+			for round := 0; round < 5; round++ {
+				for k := 0; k < 50; k++ {
+					get(k)
+				}
+			}
+			for i := 0; i < 1000; i++ {
+				get(1000 + i)
+				if i%10 == 0 {
+					get(i / 10 % 50)
+				}
+			}
+			hits := 0
+			for k := 0; k < 50; k++ {
+				if _, ok := c.Peek(k); ok {
+					hits++
+				}
+			}
+			if survived := hits >= 45; survived != resistant[impl.name] {
+				t.Errorf("Expected scan resistance %v, but %d of 50 hot keys survived the scan", resistant[impl.name], hits)
+			}
+		})
+	}
+}
+
+func TestCacheConcurrentUse(t *testing.T) {
+	for _, impl := range caches {
+		t.Run(impl.name, func(t *testing.T) {
+			c, _ := impl.new(64, CacheOptions[int, int]{TTL: time.Hour})
+			var wg sync.WaitGroup
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					r := rand.New(rand.NewSource(int64(g)))
+					for i := 0; i < 2000; i++ {
+						k := r.Intn(256)
+						switch r.Intn(4) {
+						case 0:
+							c.Put(k, k)
+						case 1:
+							c.Remove(k)
+						default:
+							if v, ok := c.Get(k); ok && v != k {
+								t.Errorf("Expected value %d for key %d but got %d", k, k, v)
+							}
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+			if c.Len() > 64 {
+				t.Errorf("Cache holds %d entries, more than its capacity", c.Len())
+			}
+		})
+	}
+}
+
+// TestCacheRandomOperations runs a random mix of operations against every cache and a map of the
+// entries it should hold. The policies evict different entries, so the map learns which entries
+// were evicted after every Put, and every other operation must leave the entries unchanged.
+func TestCacheRandomOperations(t *testing.T) {
+	for _, impl := range caches {
+		t.Run(impl.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(5))
+			c, _ := impl.new(16, CacheOptions[int, int]{})
+			model := map[int]int{}
+			for i := 0; i < 10000; i++ {
+				// Mix a small set of hot keys with a wider range, so that keys are evicted and
+				// come back.
+				k := int(r.ExpFloat64() * 15)
+				if r.Intn(2) == 0 {
+					k = r.Intn(100)
+				}
+				switch r.Intn(5) {
+				case 0:
+					_, want := model[k]
+					if got := c.Remove(k); got != want {
+						t.Fatalf("Remove(%d) = %v, want %v", k, got, want)
+					}
+					delete(model, k)
+				case 1, 2:
+					v := r.Intn(1000)
+					c.Put(k, v)
+					model[k] = v
+					for mk, mv := range model {
+						got, ok := c.Peek(mk)
+						if mk == k && (!ok || got != v) {
+							t.Fatalf("Peek(%d) = (%d, %v) right after Put, want (%d, true)", k, got, ok, v)
+						}
+						if ok && got != mv {
+							t.Fatalf("Peek(%d) = %d, want %d", mk, got, mv)
+						}
+						if !ok {
+							delete(model, mk)
+						}
+					}
+				default:
+					want, present := model[k]
+					if got, ok := c.Get(k); ok != present || ok && got != want {
+						t.Fatalf("Get(%d) = (%d, %v), want (%d, %v)", k, got, ok, want, present)
+					}
+				}
+				if c.Len() != len(model) || c.Len() > c.Capacity() {
+					t.Fatalf("Len() = %d, want %d within the capacity %d", c.Len(), len(model), c.Capacity())
+				}
+				if err := impl.verify(c); err != nil {
+					t.Fatalf("Invariant violated after operation %d: %v", i, err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCache(b *testing.B) {
+	for _, impl := range caches {
+		b.Run(impl.name, func(b *testing.B) {
+			c, _ := impl.new(1000, CacheOptions[int, int]{})
+			r := rand.New(rand.NewSource(1))
+			keys := make([]int, 4096)
+			for i := range keys {
+				// A skewed key distribution, so that the hit ratio depends on the policy.
+				keys[i] = int(r.ExpFloat64() * 500)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				k := keys[i%len(keys)]
+				if _, ok := c.Get(k); !ok {
+					c.Put(k, k)
+				}
+			}
+			b.ReportMetric(c.Stats().HitRatio(), "hit-ratio")
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package structs
+
+import (
+	"iter"
+	"math/bits"
+	"runtime"
+	"sync"
+)
+
+// cacheLineSize is the assumed size of a CPU cache line. Shards are padded to it so that the
+// locks of neighbouring shards do not share a cache line and contend through false sharing.
+const cacheLineSize = 64
+
+// mapShard is one shard of a ConcurrentHashMap: a hash table with its own read-write lock.
+type mapShard[K comparable, V any] struct {
+	mu    sync.RWMutex
+	table table[K, V]
+	_     [cacheLineSize]byte
+}
+
+// ConcurrentHashMapOptions configures a ConcurrentHashMap.
+// Shards is the number of independently locked shards, rounded up to a power of two. Zero or a
+// negative number selects four shards per available CPU. The embedded HashMapOptions apply to the
+// map as a whole: the initial capacity is divided evenly between the shards, and every shard uses
+// the maximum load factor.
+type ConcurrentHashMapOptions struct {
+	Shards int
+	HashMapOptions
+}
+
+// ConcurrentHashMap is a thread-safe hash map with keys of type K and values of type V that is
+// designed for heavy concurrent use.
+//
+// Where HashMap serializes every operation behind a single mutex, ConcurrentHashMap splits its
+// keys over a number of shards by the high bits of their hashes. Each shard is a Robin Hood hash
+// table, like the one behind HashMap, guarded by its own sync.RWMutex, so operations on keys in
+// different shards never wait for each other and lookups in the same shard run in parallel. Keys
+// are hashed and compared like in HashMap; keys that implement base.Object use their Hash and
+// Equals methods.
+//
+// GetOrPut, ComputeIfAbsent and CompareAndSwap perform their check and their update atomically.
+// All and Range iterate over a consistent snapshot of the whole map.
+type ConcurrentHashMap[K comparable, V any] struct {
+	hasher hasher[K]
+	shards []mapShard[K, V]
+	shift  uint
+}
+
+// NewConcurrentHashMap returns an empty ConcurrentHashMap with the default options.
+func NewConcurrentHashMap[K comparable, V any]() *ConcurrentHashMap[K, V] {
+	return NewConcurrentHashMapWithOptions[K, V](ConcurrentHashMapOptions{})
+}
+
+// NewConcurrentHashMapWithOptions returns an empty ConcurrentHashMap configured by opts.
+func NewConcurrentHashMapWithOptions[K comparable, V any](opts ConcurrentHashMapOptions) *ConcurrentHashMap[K, V] {
+	n := opts.Shards
+	if n <= 0 {
+		n = 4 * runtime.GOMAXPROCS(0)
+	}
+	shardBits := bits.Len(uint(n - 1))
+	m := &ConcurrentHashMap[K, V]{
+		hasher: newHasher[K](),
+		shards: make([]mapShard[K, V], 1<<shardBits),
+		shift:  uint(64 - shardBits),
+	}
+	capacity := (opts.InitialCapacity + len(m.shards) - 1) / len(m.shards)
+	for i := range m.shards {
+		m.shards[i].table = newTable[K, V](m.hasher, capacity, opts.MaxLoadFactor)
+	}
+	return m
+}
+
+// shard returns the shard responsible for the given hash. Shards are picked by the high bits of
+// the hash, while the tables use the low bits, so the keys of a shard still spread over all of its
+// slots.
+func (m *ConcurrentHashMap[K, V]) shard(hash uint64) *mapShard[K, V] {
+	return &m.shards[hash>>m.shift]
+}
+
+// Get returns the value associated with the given key. The boolean result is false if the key is
+// not present, in which case the zero value of V is returned.
+func (m *ConcurrentHashMap[K, V]) Get(key K) (V, bool) {
+	hash := m.hasher.hash(key)
+	s := m.shard(hash)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.table.get(key, hash)
+}
+
+// ContainsKey reports whether the given key is present in the map.
+func (m *ConcurrentHashMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Put associates the given value with the given key, replacing any previous value.
+func (m *ConcurrentHashMap[K, V]) Put(key K, value V) {
+	hash := m.hasher.hash(key)
+	s := m.shard(hash)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.table.put(key, hash, value)
+}
+
+// Remove deletes the given key and reports whether it was present.
+func (m *ConcurrentHashMap[K, V]) Remove(key K) bool {
+	hash := m.hasher.hash(key)
+	s := m.shard(hash)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.table.remove(key, hash)
+}
+
+// GetOrPut returns the value associated with the given key if it is present. Otherwise it
+// associates the given value with the key and returns it. The boolean result is true if the value
+// was already present and false if it was stored. The check and the store are atomic.
+func (m *ConcurrentHashMap[K, V]) GetOrPut(key K, value V) (V, bool) {
+	return m.ComputeIfAbsent(key, func() V {
+		return value
+	})
+}
+
+// ComputeIfAbsent returns the value associated with the given key if it is present. Otherwise it
+// calls fn, associates the result with the key and returns it. The boolean result is true if the
+// value was already present and false if it was computed.
+//
+// The lookup first takes only the read lock of the shard, so keys that are present are returned
+// without blocking other readers. If the key is absent, the write lock is taken and the key is
+// looked up again, so fn is called at most once per key even if many goroutines ask for the same
+// missing key at once. fn runs while the shard is locked, so it must not use the map.
+func (m *ConcurrentHashMap[K, V]) ComputeIfAbsent(key K, fn func() V) (V, bool) {
+	hash := m.hasher.hash(key)
+	s := m.shard(hash)
+	s.mu.RLock()
+	value, ok := s.table.get(key, hash)
+	s.mu.RUnlock()
+	if ok {
+		return value, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value, ok := s.table.get(key, hash); ok {
+		return value, true
+	}
+	value = fn()
+	s.table.put(key, hash, value)
+	return value, false
+}
+
+// CompareAndSwap replaces the value associated with the given key with new if the key is present
+// and its current value equals old, and reports whether it did. Values are compared with ==, so
+// CompareAndSwap panics if the values are not comparable, like sync.Map.CompareAndSwap.
+func (m *ConcurrentHashMap[K, V]) CompareAndSwap(key K, old, new V) bool {
+	hash := m.hasher.hash(key)
+	s := m.shard(hash)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.table.find(key, hash)
+	if i < 0 || any(s.table.slots[i].value) != any(old) {
+		return false
+	}
+	s.table.slots[i].value = new
+	return true
+}
+
+// Size returns the number of entries in the map. The shards are counted one after another, so
+// under concurrent modification the result need not match the size at any single moment.
+func (m *ConcurrentHashMap[K, V]) Size() int {
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += s.table.size
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// IsEmpty returns true if the map is empty, false otherwise. Like Size, it counts the shards one
+// after another.
+func (m *ConcurrentHashMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// All returns an iterator over a consistent snapshot of the map, in unspecified order.
+//
+// When a loop starts, the read locks of all shards are taken together and the entries are copied,
+// so the snapshot reflects the state of the whole map at a single moment even while other
+// goroutines are writing. The locks are released before the first entry is yielded, so unlike the
+// iterators of the other containers, the loop body may use and modify the map. Taking a snapshot
+// costs time and memory proportional to the size of the map and briefly blocks all writers.
+func (m *ConcurrentHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, e := range m.snapshot() {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Range calls fn for every entry of a consistent snapshot of the map, as described for All, until
+// fn returns false.
+func (m *ConcurrentHashMap[K, V]) Range(fn func(key K, value V) bool) {
+	m.All()(fn)
+}
+
+// snapshot copies all entries while holding the read locks of all shards. The locks are always
+// taken in shard order, so concurrent snapshots cannot deadlock.
+func (m *ConcurrentHashMap[K, V]) snapshot() []mapEntry[K, V] {
+	for i := range m.shards {
+		m.shards[i].mu.RLock()
+	}
+	n := 0
+	for i := range m.shards {
+		n += m.shards[i].table.size
+	}
+	entries := make([]mapEntry[K, V], 0, n)
+	for i := range m.shards {
+		for key, value := range m.shards[i].table.all() {
+			entries = append(entries, mapEntry[K, V]{key: key, value: value})
+		}
+		m.shards[i].mu.RUnlock()
+	}
+	return entries
+}
+
+// Stats returns the combined occupancy and probe-length statistics of all shards. The shards are
+// examined one after another, each under its read lock.
+func (m *ConcurrentHashMap[K, V]) Stats() HashMapStats {
+	var total HashMapStats
+	probes := 0.0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		stats := s.table.stats()
+		s.mu.RUnlock()
+		total.Len += stats.Len
+		total.Capacity += stats.Capacity
+		total.MaxLoadFactor = stats.MaxLoadFactor
+		total.MaxProbeLength = max(total.MaxProbeLength, stats.MaxProbeLength)
+		probes += stats.AverageProbeLength * float64(stats.Len)
+	}
+	if total.Capacity > 0 {
+		total.LoadFactor = float64(total.Len) / float64(total.Capacity)
+	}
+	if total.Len > 0 {
+		total.AverageProbeLength = probes / float64(total.Len)
+	}
+	return total
+}
@@ -0,0 +1,309 @@
+package structs
+
+import (
+	"maps"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ooyeku/algo/algo/base"
+)
+
+func TestConcurrentHashMap(t *testing.T) {
+	m := NewConcurrentHashMapWithOptions[string, int](ConcurrentHashMapOptions{Shards: 3})
+	if len(m.shards) != 4 {
+		t.Errorf("Expected 3 shards to be rounded up to 4 but got %d", len(m.shards))
+	}
+	if !m.IsEmpty() {
+		t.Errorf("Expected a new map to be empty")
+	}
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("a", 3)
+	if v, ok := m.Get("a"); !ok || v != 3 {
+		t.Errorf("Expected (3, true) for key a but got (%d, %v)", v, ok)
+	}
+	if _, ok := m.Get("c"); ok {
+		t.Errorf("Expected key c to be absent")
+	}
+	if !m.ContainsKey("b") || m.ContainsKey("c") {
+		t.Errorf("ContainsKey disagrees with the keys present")
+	}
+	if m.Size() != 2 {
+		t.Errorf("Expected size 2 but got %d", m.Size())
+	}
+	if !m.Remove("a") || m.Remove("a") {
+		t.Errorf("Expected key a to be removed exactly once")
+	}
+	if got := maps.Collect(m.All()); !maps.Equal(got, map[string]int{"b": 2}) {
+		t.Errorf("Expected map[b:2] but got %v", got)
+	}
+}
+
+func TestConcurrentHashMapOptions(t *testing.T) {
+	m := NewConcurrentHashMapWithOptions[int, int](ConcurrentHashMapOptions{
+		Shards:         1,
+		HashMapOptions: HashMapOptions{InitialCapacity: 1000, MaxLoadFactor: 0.9},
+	})
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i)
+	}
+	stats := m.Stats()
+	if stats.Len != 1000 || stats.Capacity != 2048 || stats.MaxLoadFactor != 0.9 {
+		t.Errorf("Expected 1000 keys in 2048 slots but got %+v", stats)
+	}
+
+	m = NewConcurrentHashMap[int, int]()
+	if n := len(m.shards); n < 4 || n&(n-1) != 0 {
+		t.Errorf("Expected a power of two of at least 4 default shards but got %d", n)
+	}
+	for i := 0; i < 10000; i++ {
+		m.Put(i, i)
+	}
+	if stats := m.Stats(); stats.Len != 10000 || stats.LoadFactor > stats.MaxLoadFactor {
+		t.Errorf("Unexpected statistics %+v", stats)
+	}
+}
+
+func TestConcurrentHashMapObjectKeys(t *testing.T) {
+	m := NewConcurrentHashMap[*base.Atom, string]()
+	m.Put(base.NewAtom("x"), "first")
+	m.Put(base.NewAtom("x"), "second")
+	if m.Size() != 1 {
+		t.Errorf("Expected equal atoms to share a key but got size %d", m.Size())
+	}
+	if v, ok := m.Get(base.NewAtom("x")); !ok || v != "second" {
+		t.Errorf("Expected (second, true) but got (%s, %v)", v, ok)
+	}
+}
+
+func TestConcurrentHashMapAtomicOperations(t *testing.T) {
+	m := NewConcurrentHashMap[string, int]()
+
+	if v, loaded := m.GetOrPut("a", 1); loaded || v != 1 {
+		t.Errorf("Expected GetOrPut to store 1 but got (%d, %v)", v, loaded)
+	}
+	if v, loaded := m.GetOrPut("a", 2); !loaded || v != 1 {
+		t.Errorf("Expected GetOrPut to load 1 but got (%d, %v)", v, loaded)
+	}
+
+	calls := 0
+	compute := func() int {
+		calls++
+		return 10
+	}
+	if v, loaded := m.ComputeIfAbsent("b", compute); loaded || v != 10 {
+		t.Errorf("Expected ComputeIfAbsent to compute 10 but got (%d, %v)", v, loaded)
+	}
+	if v, loaded := m.ComputeIfAbsent("b", compute); !loaded || v != 10 || calls != 1 {
+		t.Errorf("Expected ComputeIfAbsent to load 10 without calling fn but got (%d, %v) after %d calls", v, loaded, calls)
+	}
+
+	cases := []struct {
+		name     string
+		key      string
+		old, new int
+		swapped  bool
+		want     int
+	}{
+		{"Match", "a", 1, 5, true, 5},
+		{"Mismatch", "a", 1, 6, false, 5},
+		{"Absent", "c", 0, 7, false, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := m.CompareAndSwap(c.key, c.old, c.new); got != c.swapped {
+				t.Errorf("Expected CompareAndSwap to return %v but got %v", c.swapped, got)
+			}
+			if got, _ := m.Get(c.key); got != c.want {
+				t.Errorf("Expected value %d after CompareAndSwap but got %d", c.want, got)
+			}
+		})
+	}
+}
+
+func TestConcurrentHashMapComputeIfAbsentOnce(t *testing.T) {
+	m := NewConcurrentHashMap[int, int]()
+	var calls [100]atomic.Int32
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range calls {
+				v, _ := m.ComputeIfAbsent(k, func() int {
+					calls[k].Add(1)
+					return k * k
+				})
+				if v != k*k {
+					t.Errorf("Expected %d for key %d but got %d", k*k, k, v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for k := range calls {
+		if n := calls[k].Load(); n != 1 {
+			t.Errorf("Expected fn to run once for key %d but it ran %d times", k, n)
+		}
+	}
+}
+
+func TestConcurrentHashMapCompareAndSwapCounter(t *testing.T) {
+	m := NewConcurrentHashMap[string, int]()
+	m.Put("n", 0)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				for {
+					v, _ := m.Get("n")
+					if m.CompareAndSwap("n", v, v+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if v, _ := m.Get("n"); v != 8000 {
+		t.Errorf("Expected the counter to reach 8000 but got %d", v)
+	}
+}
+
+func TestConcurrentHashMapConsistentRange(t *testing.T) {
+	// The writer increments the keys 0..n-1 one after another, round after round, so at any
+	// moment the values are non-increasing by key and differ by at most one. A snapshot that
+	// mixed shards from different moments would break that.
+	const n = 256
+	m := NewConcurrentHashMapWithOptions[int, int](ConcurrentHashMapOptions{Shards: 16})
+	for k := 0; k < n; k++ {
+		m.Put(k, 0)
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for round := 1; ; round++ {
+			for k := 0; k < n; k++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				m.Put(k, round)
+			}
+		}
+	}()
+
+	for i := 0; i < 200; i++ {
+		values := make([]int, n)
+		count := 0
+		m.Range(func(key, value int) bool {
+			values[key] = value
+			count++
+			return true
+		})
+		if count != n {
+			t.Fatalf("Expected %d entries in the snapshot but got %d", n, count)
+		}
+		for k := 1; k < n; k++ {
+			if values[k] > values[k-1] || values[0]-values[k] > 1 {
+				t.Fatalf("Inconsistent snapshot: key %d has %d, key %d has %d, key 0 has %d",
+					k-1, values[k-1], k, values[k], values[0])
+			}
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	// The loop body may modify the map, since the snapshot is taken before the first entry.
+	for k := range m.All() {
+		m.Remove(k)
+	}
+	if !m.IsEmpty() {
+		t.Errorf("Expected removing every key during Range to empty the map")
+	}
+}
+
+func TestConcurrentHashMapRandomOperations(t *testing.T) {
+	m := NewConcurrentHashMapWithOptions[int, int](ConcurrentHashMapOptions{Shards: 8})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(g)))
+			// Every goroutine owns the keys congruent to g modulo 8, so it can check its own view.
+			want := make(map[int]int)
+			for i := 0; i < 5000; i++ {
+				k := r.Intn(500)*8 + g
+				switch r.Intn(3) {
+				case 0:
+					_, present := want[k]
+					if m.Remove(k) != present {
+						t.Errorf("Remove(%d) disagrees with the expected contents", k)
+					}
+					delete(want, k)
+				case 1:
+					m.Put(k, i)
+					want[k] = i
+				default:
+					v, ok := m.Get(k)
+					if w, present := want[k]; ok != present || v != w {
+						t.Errorf("Get(%d) = (%d, %v), want (%d, %v)", k, v, ok, w, present)
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// benchmarkMapParallel runs a parallel workload in which every tenth operation is a Put when
+// writes is false and every second operation is a Put when writes is true.
+func benchmarkMapParallel(b *testing.B, get func(int), put func(int), writes bool) {
+	const keys = 1 << 16
+	for i := 0; i < keys; i++ {
+		put(i)
+	}
+	every := 10
+	if writes {
+		every = 2
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for i := 0; pb.Next(); i++ {
+			k := r.Intn(keys)
+			if i%every == 0 {
+				put(k)
+			} else {
+				get(k)
+			}
+		}
+	})
+}
+
+func BenchmarkMapContention(b *testing.B) {
+	for _, workload := range []struct {
+		name   string
+		writes bool
+	}{
+		{"ReadHeavy", false},
+		{"WriteHeavy", true},
+	} {
+		b.Run("HashMap/"+workload.name, func(b *testing.B) {
+			m := NewHashMap[int, int]()
+			benchmarkMapParallel(b, func(k int) { m.Get(k) }, func(k int) { m.Put(k, k) }, workload.writes)
+		})
+		b.Run("ConcurrentHashMap/"+workload.name, func(b *testing.B) {
+			m := NewConcurrentHashMap[int, int]()
+			benchmarkMapParallel(b, func(k int) { m.Get(k) }, func(k int) { m.Put(k, k) }, workload.writes)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package structs
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// daryNode is a node of a DaryHeap: a value and its index in the slice of the heap.
+type daryNode[T any] struct {
+	value T
+	index int
+	tag   atomic.Pointer[heapTag]
+}
+
+// Value returns the value of the node.
+func (n *daryNode[T]) Value() T {
+	return n.value
+}
+
+// DaryHeap is a thread-safe d-ary heap of values of type T ordered by a cmp function. It is the
+// generalization of the binary heap behind PriorityQueue to nodes with d children, stored in one
+// slice so that the children of the node at index i are at indexes d*i+1 to d*i+d.
+//
+// A larger arity makes the heap shallower, so Push and DecreaseKey, which move a node towards the
+// root, compare fewer nodes, while Pop, which moves a node towards the leaves and compares all
+// children on every level, compares more. Heaps with an arity of 4 or 8 often beat binary heaps in
+// practice, since the children of a node share cache lines, and algorithms that decrease keys more
+// often than they pop, such as Dijkstra's on dense graphs, benefit from an even larger arity.
+// Meld appends the values of the other heap and rebuilds the heap bottom-up in O(N + M) time.
+// See Heap for the operations and their running times.
+type DaryHeap[T any] struct {
+	mu    sync.Mutex
+	arity int
+	nodes []*daryNode[T]
+	cmp   func(a, b T) int
+	tag   *heapTag
+}
+
+// NewDaryHeap creates a new empty DaryHeap whose nodes have up to arity children, ordered by the
+// given cmp function. It panics if arity is less than 2.
+func NewDaryHeap[T any](arity int, cmp func(a, b T) int) *DaryHeap[T] {
+	if arity < 2 {
+		panic(fmt.Sprintf("structs: d-ary heap arity %d is less than 2", arity))
+	}
+	return &DaryHeap[T]{arity: arity, cmp: cmp, tag: &heapTag{}}
+}
+
+// Arity returns the maximum number of children of a node.
+func (h *DaryHeap[T]) Arity() int {
+	return h.arity
+}
+
+// Push adds a value to the heap and returns its node.
+func (h *DaryHeap[T]) Push(value T) HeapNode[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := &daryNode[T]{value: value, index: len(h.nodes)}
+	n.tag.Store(h.tag)
+	h.nodes = append(h.nodes, n)
+	h.up(n.index)
+	return n
+}
+
+// Pop removes and returns the first value of the heap. The boolean result is false if the heap is
+// empty.
+func (h *DaryHeap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.nodes) == 0 {
+		var zero T
+		return zero, false
+	}
+	first, last := h.nodes[0], len(h.nodes)-1
+	h.swap(0, last)
+	h.nodes[last] = nil
+	h.nodes = h.nodes[:last]
+	h.down(0)
+	first.tag.Store(nil)
+	return first.value, true
+}
+
+// Peek returns the first value of the heap without removing it. The boolean result is false if the
+// heap is empty.
+func (h *DaryHeap[T]) Peek() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.nodes) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.nodes[0].value, true
+}
+
+// DecreaseKey replaces the value of the given node with one that comes no later and moves the node
+// towards the root. It returns false, and changes nothing, if the node does not belong to the heap
+// or the new value would come after the current one.
+func (h *DaryHeap[T]) DecreaseKey(node HeapNode[T], value T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n, ok := node.(*daryNode[T])
+	if !ok || !h.tag.owns(n.tag.Load()) || h.cmp(value, n.value) > 0 {
+		return false
+	}
+	n.value = value
+	h.up(n.index)
+	return true
+}
+
+// Meld moves all values of other, which must be a *DaryHeap, into the heap and leaves other empty,
+// in O(N + M) time. The arities of the heaps may differ. It returns false if other is not a
+// *DaryHeap or is the heap itself.
+//
+// The two heaps are never locked together; see the package documentation.
+func (h *DaryHeap[T]) Meld(other Heap[T]) bool {
+	o, ok := other.(*DaryHeap[T])
+	if !ok || o == h {
+		return false
+	}
+	o.mu.Lock()
+	moved, tag := o.nodes, o.tag
+	o.nodes, o.tag = nil, &heapTag{}
+	o.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tag.link(h.tag)
+	for _, n := range moved {
+		n.index = len(h.nodes)
+		h.nodes = append(h.nodes, n)
+	}
+	for i := (len(h.nodes) - 2) / h.arity; i >= 0; i-- {
+		h.down(i)
+	}
+	return true
+}
+
+// Size returns the number of values in the heap.
+func (h *DaryHeap[T]) Size() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.nodes)
+}
+
+// IsEmpty returns true if the heap holds no values, false otherwise.
+func (h *DaryHeap[T]) IsEmpty() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.nodes) == 0
+}
+
+// less reports whether the node at index i comes before the node at index j.
+func (h *DaryHeap[T]) less(i, j int) bool {
+	return h.cmp(h.nodes[i].value, h.nodes[j].value) < 0
+}
+
+// swap exchanges the nodes at indexes i and j and keeps their indexes up to date.
+func (h *DaryHeap[T]) swap(i, j int) {
+	h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i]
+	h.nodes[i].index = i
+	h.nodes[j].index = j
+}
+
+// up moves the node at index i towards the root while it comes before its parent.
+func (h *DaryHeap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / h.arity
+		if !h.less(i, parent) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the node at index i towards the leaves while one of its children comes before it.
+func (h *DaryHeap[T]) down(i int) {
+	n := len(h.nodes)
+	for {
+		first := i
+		for c := h.arity*i + 1; c <= h.arity*i+h.arity && c < n; c++ {
+			if h.less(c, first) {
+				first = c
+			}
+		}
+		if first == i {
+			return
+		}
+		h.swap(i, first)
+		i = first
+	}
+}
@@ -0,0 +1,56 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// verifyDaryHeap checks that no node of a DaryHeap comes before its parent and that every node
+// knows its index and belongs to the heap.
+func verifyDaryHeap[T any](h *DaryHeap[T]) error {
+	for i, n := range h.nodes {
+		if n.index != i || !h.tag.owns(n.tag.Load()) {
+			return fmt.Errorf("node at index %d has index %d or belongs to another heap", i, n.index)
+		}
+		if i > 0 && h.less(i, (i-1)/h.arity) {
+			return fmt.Errorf("node at index %d comes before its parent", i)
+		}
+	}
+	return nil
+}
+
+func TestDaryHeapArities(t *testing.T) {
+	values := rand.New(rand.NewSource(10)).Perm(500)
+	for arity := 2; arity <= 9; arity++ {
+		t.Run(fmt.Sprintf("Arity=%d", arity), func(t *testing.T) {
+			h := NewDaryHeap(arity, cmp.Compare[int])
+			if h.Arity() != arity {
+				t.Errorf("Expected arity %d but got %d", arity, h.Arity())
+			}
+			for _, v := range values[:250] {
+				h.Push(v)
+			}
+			other := NewDaryHeap(11-arity, cmp.Compare[int])
+			for _, v := range values[250:] {
+				other.Push(v)
+			}
+			h.Meld(other)
+			if err := verifyDaryHeap(h); err != nil {
+				t.Fatalf("Invariant violated: %v", err)
+			}
+			if got := drainHeap[int](h); !slices.Equal(got, slices.Sorted(slices.Values(values))) {
+				t.Errorf("Expected the values in ascending order")
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for arity 1")
+		}
+	}()
+	NewDaryHeap(1, cmp.Compare[int])
+}
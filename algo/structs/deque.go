@@ -0,0 +1,159 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+)
+
+// minDequeCapacity is the smallest number of slots of a Deque that holds any items.
+const minDequeCapacity = 8
+
+// Deque represents a thread-safe double-ended queue holding items of type T.
+//
+// The items are kept in a ring buffer, a slice used circularly, so items can be added and
+// removed at both ends in amortized O(1) time and any position can be read in O(1) time. When the
+// buffer is full its capacity doubles, and when no more than a quarter of it is used its capacity
+// halves, down to a minimum of eight slots, so the memory of a Deque stays proportional to the
+// number of items it holds, however many items pass through it. Removed items are cleared from
+// their slots right away, so the Deque does not keep them from being garbage collected.
+//
+// The zero value is an empty Deque ready to use.
+type Deque[T any] struct {
+	mu   sync.Mutex
+	ring ring[T]
+}
+
+// NewDeque creates a new empty Deque. No memory is allocated until the first item is added.
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// PushFront adds an item at the front of the deque.
+func (d *Deque[T]) PushFront(item T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.grow()
+	d.ring.pushFront(item)
+}
+
+// PushBack adds an item at the back of the deque.
+func (d *Deque[T]) PushBack(item T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.grow()
+	d.ring.pushBack(item)
+}
+
+// PopFront removes and returns the item at the front of the deque. The boolean result is false
+// if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ring.size == 0 {
+		var zero T
+		return zero, false
+	}
+	item := d.ring.popFront()
+	d.shrink()
+	return item, true
+}
+
+// PopBack removes and returns the item at the back of the deque. The boolean result is false if
+// the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ring.size == 0 {
+		var zero T
+		return zero, false
+	}
+	item := d.ring.popBack()
+	d.shrink()
+	return item, true
+}
+
+// Front returns the item at the front of the deque without removing it. The boolean result is
+// false if the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	return d.At(0)
+}
+
+// Back returns the item at the back of the deque without removing it. The boolean result is
+// false if the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ring.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.ring.at(d.ring.size - 1), true
+}
+
+// At returns the item at position i, counting from zero at the front, in O(1) time. The boolean
+// result is false if i is out of range.
+func (d *Deque[T]) At(i int) (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i < 0 || i >= d.ring.size {
+		var zero T
+		return zero, false
+	}
+	return d.ring.at(i), true
+}
+
+// Size returns the number of items in the deque.
+func (d *Deque[T]) Size() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.ring.size
+}
+
+// IsEmpty returns true if the deque holds no items, false otherwise.
+func (d *Deque[T]) IsEmpty() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.ring.size == 0
+}
+
+// Clear removes all items from the deque and releases its memory.
+func (d *Deque[T]) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ring = ring[T]{}
+}
+
+// All returns an iterator over the items of the deque from the front to the back. The deque is
+// locked while the loop runs; see the package documentation.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.ring.all()(yield)
+	}
+}
+
+// Backward returns an iterator over the items of the deque from the back to the front.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.ring.backward()(yield)
+	}
+}
+
+// grow doubles the capacity of the buffer if it is full, so that one more item fits.
+func (d *Deque[T]) grow() {
+	if d.ring.size == len(d.ring.buf) {
+		d.ring.resize(max(2*len(d.ring.buf), minDequeCapacity))
+	}
+}
+
+// shrink halves the capacity of the buffer if no more than a quarter of it is used. Waiting for a
+// quarter rather than a half keeps a deque whose size hovers around a power of two from resizing
+// on every operation.
+func (d *Deque[T]) shrink() {
+	if len(d.ring.buf) > minDequeCapacity && d.ring.size <= len(d.ring.buf)/4 {
+		d.ring.resize(len(d.ring.buf) / 2)
+	}
+}
@@ -0,0 +1,210 @@
+package structs
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// verifyDeque checks that the capacity of a Deque is zero or a power of two of at least the
+// minimum, that the items fit, and that every slot not holding an item is cleared.
+func verifyDeque[T comparable](d *Deque[T]) error {
+	n := len(d.ring.buf)
+	if n != 0 && (n < minDequeCapacity || n&(n-1) != 0) {
+		return fmt.Errorf("capacity %d is not a power of two of at least %d", n, minDequeCapacity)
+	}
+	if d.ring.size > n {
+		return fmt.Errorf("%d items exceed the capacity %d", d.ring.size, n)
+	}
+	var zero T
+	for i := d.ring.size; i < n; i++ {
+		if v := d.ring.buf[d.ring.slot(i)]; v != zero {
+			return fmt.Errorf("free slot %d still holds %v", d.ring.slot(i), v)
+		}
+	}
+	return nil
+}
+
+func TestDeque(t *testing.T) {
+	cases := []struct {
+		name     string
+		ops      func(d *Deque[int])
+		expected []int
+	}{
+		{
+			name:     "Empty",
+			ops:      func(d *Deque[int]) {},
+			expected: []int{},
+		},
+		{
+			name: "PushBoth",
+			ops: func(d *Deque[int]) {
+				d.PushBack(2)
+				d.PushFront(1)
+				d.PushBack(3)
+				d.PushFront(0)
+			},
+			expected: []int{0, 1, 2, 3},
+		},
+		{
+			name: "PopBoth",
+			ops: func(d *Deque[int]) {
+				for i := 0; i < 5; i++ {
+					d.PushBack(i)
+				}
+				d.PopFront()
+				d.PopBack()
+			},
+			expected: []int{1, 2, 3},
+		},
+		{
+			name: "WrapAround",
+			ops: func(d *Deque[int]) {
+				for i := 0; i < 6; i++ {
+					d.PushBack(i)
+				}
+				for i := 0; i < 4; i++ {
+					d.PopFront()
+				}
+				for i := 6; i < 12; i++ {
+					d.PushBack(i)
+				}
+			},
+			expected: []int{4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		{
+			name: "GrowWhileWrapped",
+			ops: func(d *Deque[int]) {
+				for i := 4; i < 10; i++ {
+					d.PushBack(i)
+				}
+				for i := 3; i >= 0; i-- {
+					d.PushFront(i)
+				}
+			},
+			expected: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDeque[int]()
+			tc.ops(d)
+			if err := verifyDeque(d); err != nil {
+				t.Fatalf("Invariant violated: %v", err)
+			}
+			if got := slices.Collect(d.All()); !slices.Equal(got, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, got)
+			}
+			reversed := slices.Clone(tc.expected)
+			slices.Reverse(reversed)
+			if got := slices.Collect(d.Backward()); !slices.Equal(got, reversed) {
+				t.Errorf("Expected %v backwards but got %v", reversed, got)
+			}
+			for i, want := range tc.expected {
+				if got, ok := d.At(i); !ok || got != want {
+					t.Errorf("At(%d): expected (%d, true) but got (%d, %v)", i, want, got, ok)
+				}
+			}
+			if d.Size() != len(tc.expected) || d.IsEmpty() != (len(tc.expected) == 0) {
+				t.Errorf("Expected size %d but got %d", len(tc.expected), d.Size())
+			}
+		})
+	}
+}
+
+func TestDequeEmpty(t *testing.T) {
+	var d Deque[string]
+	if _, ok := d.PopFront(); ok {
+		t.Errorf("Expected PopFront of an empty deque to report false")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Errorf("Expected PopBack of an empty deque to report false")
+	}
+	if _, ok := d.Front(); ok {
+		t.Errorf("Expected Front of an empty deque to report false")
+	}
+	if _, ok := d.Back(); ok {
+		t.Errorf("Expected Back of an empty deque to report false")
+	}
+	d.PushBack("a")
+	for _, i := range []int{-1, 1} {
+		if _, ok := d.At(i); ok {
+			t.Errorf("At(%d) should be out of range", i)
+		}
+	}
+	if v, ok := d.Back(); !ok || v != "a" {
+		t.Errorf("Expected Back to return a, got (%q, %v)", v, ok)
+	}
+	d.Clear()
+	if !d.IsEmpty() || len(d.ring.buf) != 0 {
+		t.Errorf("Expected Clear to empty the deque and release its memory")
+	}
+}
+
+func TestDequeResize(t *testing.T) {
+	d := NewDeque[int]()
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	if got := len(d.ring.buf); got != 1024 {
+		t.Errorf("Expected 1000 items to fit 1024 slots but got %d", got)
+	}
+	for i := 0; i < 990; i++ {
+		d.PopFront()
+	}
+	if got := len(d.ring.buf); got > 64 {
+		t.Errorf("Expected the deque to shrink with 10 items but it has %d slots", got)
+	}
+	for i := 0; i < 10; i++ {
+		d.PopBack()
+	}
+	if got := len(d.ring.buf); got != minDequeCapacity {
+		t.Errorf("Expected an empty deque to keep %d slots but got %d", minDequeCapacity, got)
+	}
+}
+
+func TestDequeRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	d := NewDeque[int]()
+	var model []int
+	for i := 1; i <= 20000; i++ {
+		switch r.Intn(5) {
+		case 0:
+			d.PushFront(i)
+			model = slices.Insert(model, 0, i)
+		case 1:
+			d.PushBack(i)
+			model = append(model, i)
+		case 2:
+			v, ok := d.PopFront()
+			if ok != (len(model) > 0) || ok && v != model[0] {
+				t.Fatalf("PopFront = (%d, %v) disagrees with the model", v, ok)
+			}
+			if ok {
+				model = model[1:]
+			}
+		case 3:
+			v, ok := d.PopBack()
+			if ok != (len(model) > 0) || ok && v != model[len(model)-1] {
+				t.Fatalf("PopBack = (%d, %v) disagrees with the model", v, ok)
+			}
+			if ok {
+				model = model[:len(model)-1]
+			}
+		default:
+			if len(model) > 0 {
+				at := r.Intn(len(model))
+				if v, ok := d.At(at); !ok || v != model[at] {
+					t.Fatalf("At(%d) = (%d, %v), want %d", at, v, ok, model[at])
+				}
+			}
+		}
+		if err := verifyDeque(d); err != nil {
+			t.Fatalf("Invariant violated after operation %d: %v", i, err)
+		}
+	}
+	if got := slices.Collect(d.All()); !slices.Equal(got, model) {
+		t.Errorf("Expected %v but got %v", model, got)
+	}
+}
@@ -0,0 +1,54 @@
+// Package structs provides generic, thread-safe data structures: stacks, queues, deques and ring
+// buffers, a priority queue and mergeable heaps, singly and doubly linked lists, hash maps and
+// sets, a family of ordered sets and maps built on search trees and skip lists, bounded caches
+// with LRU, LFU and ARC eviction, and persistent versions of some of them.
+//
+// Every mutable container guards its state with a mutex, so its methods may be called
+// concurrently from multiple goroutines. ConcurrentHashMap splits its keys over independently
+// locked shards for workloads in which many goroutines use the same map at once, and
+// BlockingQueue lets producer and consumer goroutines wait for room and for items, with timeouts,
+// context cancellation and closing, instead of polling. LockFreeStack and LockFreeQueue use no
+// mutex at all; their operations are built on atomic compare-and-swap instead.
+//
+// # Persistent containers
+//
+// PersistentStack, PersistentQueue, PersistentRedBlackTree and PersistentMap are immutable: every
+// update returns a new version and leaves the old one unchanged. The versions share all parts
+// that the update did not touch, so an update copies only O(log N) nodes, or O(1) for the stack
+// and queue, and keeping a version around is a free snapshot. Since versions never change, they
+// need no locks and may be read from any number of goroutines at once; to share the latest
+// version between goroutines, publish it through a channel, a mutex or an atomic.Pointer.
+//
+// # Iteration
+//
+// The containers can be iterated with range-over-func loops. All yields every element, Backward
+// yields them in reverse order, and the ordered containers also provide Range, which yields the
+// elements in a half-open range [lo, hi) in ascending order:
+//
+//	for v := range tree.All() {
+//		fmt.Println(v)
+//	}
+//
+// A mutable container is locked for as long as a loop over one of its iterators runs, so other
+// goroutines that use the container block until the loop ends, and the loop body must neither
+// modify the container nor call any of its methods, which would deadlock. Leaving the loop early
+// with break or return, or by a panic, releases the lock. To modify a container based on its
+// contents, collect the elements first, for example with slices.Collect, and modify the container
+// afterwards. ConcurrentHashMap is an exception: its iterators yield a snapshot taken when the
+// loop starts, and the loop body may use the map freely. LockFreeStack and LockFreeQueue are never
+// locked either.
+//
+// Iterators are evaluated lazily: the lock is taken when a loop starts, not when the iterator is
+// created, and every loop over the same iterator sees the contents of the container at the time
+// the loop starts. Persistent containers are never locked, and a loop over a version always sees
+// that version, whatever updates the loop body makes.
+//
+// # Operations on two containers
+//
+// Methods that combine a container with another one of the same type, such as HashMap.Merge,
+// DoublyLinkedList.Splice, PriorityQueue.Merge, the Meld methods of the heaps and the set
+// operations of HashSet and TreeSet, never hold the locks of both containers at once. They first
+// copy or detach what they need from the other container under its lock and release it, and only
+// then update the container under its own lock. Two goroutines that combine the same two
+// containers in opposite directions therefore cannot deadlock.
+package structs
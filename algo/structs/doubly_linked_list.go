@@ -0,0 +1,401 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// Element is an element of a DoublyLinkedList. The handles returned by the list stay valid while
+// the element is moved around the list or the list is reversed, so an element can be found once,
+// for example through a map, and later removed or moved in O(1) time.
+//
+// Value may be read and written directly. Next and Prev do not lock the list, so walking a list
+// through its elements, and accessing Value, must not happen while other goroutines modify the
+// list; use All, Backward or Find for that, or guard the list with a lock of your own.
+type Element[T any] struct {
+	Value      T
+	next, prev *Element[T]
+	list       atomic.Pointer[DoublyLinkedList[T]]
+}
+
+// Next returns the element after e, or nil if e is the last element or in no list.
+func (e *Element[T]) Next() *Element[T] {
+	l := e.list.Load()
+	if l == nil || e.next == &l.root {
+		return nil
+	}
+	return e.next
+}
+
+// Prev returns the element before e, or nil if e is the first element or in no list.
+func (e *Element[T]) Prev() *Element[T] {
+	l := e.list.Load()
+	if l == nil || e.prev == &l.root {
+		return nil
+	}
+	return e.prev
+}
+
+// DoublyLinkedList represents a thread-safe doubly linked list of values of type T.
+//
+// Unlike LinkedList, every element links to both of its neighbours, so values can be added and
+// removed at both ends in O(1) time, the list can be walked in both directions, and an element can
+// be removed, moved or used as the position of an insertion in O(1) time given its handle. The
+// positional operations Get, InsertAt and RemoveAt walk from the nearer end of the list and take
+// O(min(i, N-i)) time. The list is circular, with a sentinel element between the last and the
+// first element, so none of the operations needs special cases for the ends.
+//
+// Operations that take an element handle do nothing, and report failure where they return a
+// result, if the element does not belong to the list. An element records its list in an atomic
+// pointer, so a handle may be passed to another list while its own list is changed concurrently.
+type DoublyLinkedList[T any] struct {
+	mu   sync.Mutex
+	root Element[T]
+	size int
+}
+
+// NewDoublyLinkedList creates a new empty DoublyLinkedList.
+//
+// Example usage:
+//
+//	dl := NewDoublyLinkedList[int]()
+//	e := dl.PushBack(1)
+//	dl.PushFront(2)
+//	dl.InsertAfter(3, e)
+//	dl.MoveToFront(e)
+//	value, ok := dl.Get(1)
+func NewDoublyLinkedList[T any]() *DoublyLinkedList[T] {
+	l := &DoublyLinkedList[T]{}
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	return l
+}
+
+// Size returns the number of values in the list.
+func (l *DoublyLinkedList[T]) Size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.size
+}
+
+// IsEmpty returns true if the list holds no values, false otherwise.
+func (l *DoublyLinkedList[T]) IsEmpty() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.size == 0
+}
+
+// Front returns the first element of the list, or nil if the list is empty.
+func (l *DoublyLinkedList[T]) Front() *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last element of the list, or nil if the list is empty.
+func (l *DoublyLinkedList[T]) Back() *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PushFront adds a value at the front of the list and returns its element.
+func (l *DoublyLinkedList[T]) PushFront(value T) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.insert(&Element[T]{Value: value}, &l.root)
+}
+
+// PushBack adds a value at the back of the list and returns its element.
+func (l *DoublyLinkedList[T]) PushBack(value T) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.insert(&Element[T]{Value: value}, l.root.prev)
+}
+
+// InsertBefore adds a value immediately before mark and returns its element. It returns nil if
+// mark does not belong to the list.
+func (l *DoublyLinkedList[T]) InsertBefore(value T, mark *Element[T]) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if mark.list.Load() != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: value}, mark.prev)
+}
+
+// InsertAfter adds a value immediately after mark and returns its element. It returns nil if mark
+// does not belong to the list.
+func (l *DoublyLinkedList[T]) InsertAfter(value T, mark *Element[T]) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if mark.list.Load() != l {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: value}, mark)
+}
+
+// Remove removes an element from the list and reports whether it belonged to the list. The
+// element keeps its Value but can no longer be used as a handle.
+func (l *DoublyLinkedList[T]) Remove(e *Element[T]) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list.Load() != l {
+		return false
+	}
+	l.unlink(e)
+	return true
+}
+
+// MoveToFront moves an element to the front of the list.
+func (l *DoublyLinkedList[T]) MoveToFront(e *Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list.Load() == l && l.root.next != e {
+		l.move(e, &l.root)
+	}
+}
+
+// MoveToBack moves an element to the back of the list.
+func (l *DoublyLinkedList[T]) MoveToBack(e *Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list.Load() == l && l.root.prev != e {
+		l.move(e, l.root.prev)
+	}
+}
+
+// MoveBefore moves an element immediately before mark. Nothing happens if e and mark are the same
+// element or either does not belong to the list.
+func (l *DoublyLinkedList[T]) MoveBefore(e, mark *Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list.Load() == l && mark.list.Load() == l && e != mark {
+		l.move(e, mark.prev)
+	}
+}
+
+// MoveAfter moves an element immediately after mark. Nothing happens if e and mark are the same
+// element or either does not belong to the list.
+func (l *DoublyLinkedList[T]) MoveAfter(e, mark *Element[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list.Load() == l && mark.list.Load() == l && e != mark {
+		l.move(e, mark)
+	}
+}
+
+// Get returns the value at position i, counting from zero at the front. The boolean result is
+// false if i is out of range.
+func (l *DoublyLinkedList[T]) Get(i int) (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i < 0 || i >= l.size {
+		var zero T
+		return zero, false
+	}
+	return l.at(i).Value, true
+}
+
+// InsertAt adds a value so that it ends up at position i and returns its element. An i equal to
+// the size of the list adds the value at the back. It returns nil if i is out of range.
+func (l *DoublyLinkedList[T]) InsertAt(i int, value T) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i < 0 || i > l.size {
+		return nil
+	}
+	return l.insert(&Element[T]{Value: value}, l.at(i).prev)
+}
+
+// RemoveAt removes the value at position i and returns it. The boolean result is false if i is
+// out of range.
+func (l *DoublyLinkedList[T]) RemoveAt(i int) (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i < 0 || i >= l.size {
+		var zero T
+		return zero, false
+	}
+	e := l.at(i)
+	l.unlink(e)
+	return e.Value, true
+}
+
+// Find returns the first element, from the front, whose value satisfies match, and its position.
+// It returns nil and -1 if there is none. match is called with the list locked and must not use
+// the list.
+func (l *DoublyLinkedList[T]) Find(match func(value T) bool) (*Element[T], int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i := 0
+	for e := l.root.next; e != &l.root; e = e.next {
+		if match(e.Value) {
+			return e, i
+		}
+		i++
+	}
+	return nil, -1
+}
+
+// Reverse reverses the order of the values in O(N) time. The element handles stay valid.
+func (l *DoublyLinkedList[T]) Reverse() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := &l.root
+	for {
+		e.next, e.prev = e.prev, e.next
+		e = e.prev
+		if e == &l.root {
+			return
+		}
+	}
+}
+
+// Splice moves all values of other into the list so that the first of them ends up at position
+// i, leaving other empty, and reports whether it did. An i equal to the size of the list appends
+// the values. The elements of other are reused, so their handles stay valid and now belong to the
+// list. Splice takes O(M + min(i, N-i)) time for M values in other. It returns false, and changes
+// nothing, if i is out of range or other is the list itself.
+//
+// The two lists are never locked together; see the package documentation.
+func (l *DoublyLinkedList[T]) Splice(i int, other *DoublyLinkedList[T]) bool {
+	if other == l {
+		return false
+	}
+	l.mu.Lock()
+	ok := i >= 0 && i <= l.size
+	l.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	other.mu.Lock()
+	first, last, n := other.root.next, other.root.prev, other.size
+	for e := first; e != &other.root; e = e.next {
+		e.list.Store(nil)
+	}
+	other.root.next = &other.root
+	other.root.prev = &other.root
+	other.size = 0
+	other.mu.Unlock()
+	if n == 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// The list may have shrunk while it was unlocked.
+	at := l.at(min(i, l.size)).prev
+	for e := first; ; e = e.next {
+		e.list.Store(l)
+		if e == last {
+			break
+		}
+	}
+	first.prev = at
+	last.next = at.next
+	at.next.prev = last
+	at.next = first
+	l.size += n
+	return true
+}
+
+// Clear removes all values from the list in O(N) time. The removed elements can no longer be used
+// as handles.
+func (l *DoublyLinkedList[T]) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for e := l.root.next; e != &l.root; e = e.next {
+		e.list.Store(nil)
+	}
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.size = 0
+}
+
+// All returns an iterator over the values of the list from the front to the back. The list is
+// locked while the loop runs; see the package documentation.
+func (l *DoublyLinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for e := l.root.next; e != &l.root; e = e.next {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of the list from the back to the front. Unlike
+// LinkedList.Backward, it follows the backward links and needs no extra memory.
+func (l *DoublyLinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for e := l.root.prev; e != &l.root; e = e.prev {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// at returns the element at position i, which must be in [0, size]; position size is the
+// sentinel. It walks from the nearer end.
+func (l *DoublyLinkedList[T]) at(i int) *Element[T] {
+	if i <= l.size/2 {
+		e := l.root.next
+		for ; i > 0; i-- {
+			e = e.next
+		}
+		return e
+	}
+	e := &l.root
+	for i = l.size - i; i > 0; i-- {
+		e = e.prev
+	}
+	return e
+}
+
+// insert links e, which belongs to no list, after at and returns it.
+func (l *DoublyLinkedList[T]) insert(e, at *Element[T]) *Element[T] {
+	e.prev = at
+	e.next = at.next
+	at.next.prev = e
+	at.next = e
+	e.list.Store(l)
+	l.size++
+	return e
+}
+
+// unlink removes e from the list.
+func (l *DoublyLinkedList[T]) unlink(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next = nil
+	e.prev = nil
+	e.list.Store(nil)
+	l.size--
+}
+
+// move moves e, which belongs to the list, after at.
+func (l *DoublyLinkedList[T]) move(e, at *Element[T]) {
+	if e == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = at
+	e.next = at.next
+	at.next.prev = e
+	at.next = e
+}
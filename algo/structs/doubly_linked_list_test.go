@@ -0,0 +1,358 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// verifyDoublyLinkedList checks that the forward and backward links of a DoublyLinkedList agree,
+// that every element points to the list and that the size is correct.
+func verifyDoublyLinkedList[T any](l *DoublyLinkedList[T]) error {
+	count := 0
+	for e := l.root.next; e != &l.root; e = e.next {
+		if e.next.prev != e {
+			return fmt.Errorf("broken backward link after position %d", count)
+		}
+		if e.list.Load() != l {
+			return fmt.Errorf("element at position %d does not belong to the list", count)
+		}
+		count++
+		if count > l.size {
+			return errors.New("the list has more elements than its size")
+		}
+	}
+	if l.root.next.prev != &l.root {
+		return errors.New("broken backward link to the sentinel")
+	}
+	if count != l.size {
+		return fmt.Errorf("list holds %d elements but size %d", count, l.size)
+	}
+	return nil
+}
+
+func TestDoublyLinkedList(t *testing.T) {
+	cases := []struct {
+		name     string
+		ops      func(l *DoublyLinkedList[int])
+		expected []int
+	}{
+		{
+			name:     "Empty",
+			ops:      func(l *DoublyLinkedList[int]) {},
+			expected: []int{},
+		},
+		{
+			name: "PushFrontAndBack",
+			ops: func(l *DoublyLinkedList[int]) {
+				l.PushBack(2)
+				l.PushFront(1)
+				l.PushBack(3)
+			},
+			expected: []int{1, 2, 3},
+		},
+		{
+			name: "InsertBeforeAndAfter",
+			ops: func(l *DoublyLinkedList[int]) {
+				e := l.PushBack(2)
+				l.InsertBefore(1, e)
+				l.InsertAfter(4, e)
+				l.InsertAfter(3, e)
+			},
+			expected: []int{1, 2, 3, 4},
+		},
+		{
+			name: "InsertAt",
+			ops: func(l *DoublyLinkedList[int]) {
+				l.InsertAt(0, 2)
+				l.InsertAt(0, 0)
+				l.InsertAt(1, 1)
+				l.InsertAt(3, 4)
+				l.InsertAt(3, 3)
+			},
+			expected: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name: "RemoveAndRemoveAt",
+			ops: func(l *DoublyLinkedList[int]) {
+				for i := 0; i < 6; i++ {
+					l.PushBack(i)
+				}
+				l.Remove(l.Front())
+				l.RemoveAt(2)
+				l.RemoveAt(3)
+			},
+			expected: []int{1, 2, 4},
+		},
+		{
+			name: "Moves",
+			ops: func(l *DoublyLinkedList[int]) {
+				one := l.PushBack(1)
+				two := l.PushBack(2)
+				three := l.PushBack(3)
+				four := l.PushBack(4)
+				l.MoveToFront(three)
+				l.MoveToBack(one)
+				l.MoveBefore(four, two)
+				l.MoveAfter(two, one)
+			},
+			expected: []int{3, 4, 1, 2},
+		},
+		{
+			name: "Reverse",
+			ops: func(l *DoublyLinkedList[int]) {
+				for i := 1; i <= 5; i++ {
+					l.PushBack(i)
+				}
+				l.Reverse()
+			},
+			expected: []int{5, 4, 3, 2, 1},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewDoublyLinkedList[int]()
+			tc.ops(l)
+			if err := verifyDoublyLinkedList(l); err != nil {
+				t.Fatalf("Invariant violated: %v", err)
+			}
+			if got := slices.Collect(l.All()); !slices.Equal(got, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, got)
+			}
+			reversed := slices.Clone(tc.expected)
+			slices.Reverse(reversed)
+			if got := slices.Collect(l.Backward()); !slices.Equal(got, reversed) {
+				t.Errorf("Expected %v backwards but got %v", reversed, got)
+			}
+			for i, want := range tc.expected {
+				if got, ok := l.Get(i); !ok || got != want {
+					t.Errorf("Get(%d): expected (%d, true) but got (%d, %v)", i, want, got, ok)
+				}
+			}
+			if l.Size() != len(tc.expected) || l.IsEmpty() != (len(tc.expected) == 0) {
+				t.Errorf("Expected size %d but got %d", len(tc.expected), l.Size())
+			}
+		})
+	}
+}
+
+func TestDoublyLinkedListBounds(t *testing.T) {
+	l := NewDoublyLinkedList[string]()
+	if l.Front() != nil || l.Back() != nil {
+		t.Errorf("Expected no front or back element in an empty list")
+	}
+	l.PushBack("a")
+	for _, i := range []int{-1, 1} {
+		if _, ok := l.Get(i); ok {
+			t.Errorf("Get(%d) should be out of range", i)
+		}
+		if _, ok := l.RemoveAt(i); ok {
+			t.Errorf("RemoveAt(%d) should be out of range", i)
+		}
+	}
+	for _, i := range []int{-1, 2} {
+		if l.InsertAt(i, "x") != nil {
+			t.Errorf("InsertAt(%d) should be out of range", i)
+		}
+	}
+	if l.Size() != 1 {
+		t.Errorf("Expected out-of-range operations to leave the list alone")
+	}
+}
+
+func TestDoublyLinkedListForeignElements(t *testing.T) {
+	l := NewDoublyLinkedList[int]()
+	other := NewDoublyLinkedList[int]()
+	mine := l.PushBack(1)
+	foreign := other.PushBack(2)
+
+	if l.Remove(foreign) || l.InsertBefore(3, foreign) != nil || l.InsertAfter(3, foreign) != nil {
+		t.Errorf("Expected operations on a foreign element to fail")
+	}
+	l.MoveToFront(foreign)
+	l.MoveBefore(mine, foreign)
+	if !l.Remove(mine) || l.Remove(mine) {
+		t.Errorf("Expected an element to be removed exactly once")
+	}
+	if mine.Next() != nil || mine.Prev() != nil || mine.Value != 1 {
+		t.Errorf("Expected a removed element to keep its value and lose its links")
+	}
+	if err := verifyDoublyLinkedList(other); err != nil || other.Size() != 1 {
+		t.Errorf("Expected the other list to be unchanged: %v", err)
+	}
+}
+
+func TestDoublyLinkedListConcurrentForeignElements(t *testing.T) {
+	// The elements of one list are handed to another list while their own list removes and
+	// splices them, each under its own lock. Run with -race.
+	a, b, c := NewDoublyLinkedList[int](), NewDoublyLinkedList[int](), NewDoublyLinkedList[int]()
+	var elements []*Element[int]
+	for i := 0; i < 200; i++ {
+		elements = append(elements, a.PushBack(i))
+	}
+	mark := b.PushBack(-1)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for _, e := range elements[:100] {
+			if !a.Remove(e) {
+				t.Errorf("Expected Remove on the owning list to succeed")
+				return
+			}
+		}
+		c.Splice(0, a)
+		c.Clear()
+	}()
+	go func() {
+		defer wg.Done()
+		for _, e := range elements {
+			if b.Remove(e) || b.InsertBefore(0, e) != nil {
+				t.Errorf("Expected operations on a foreign element to fail")
+				return
+			}
+			b.MoveAfter(e, mark)
+			b.MoveToFront(e)
+		}
+	}()
+	wg.Wait()
+	if !a.IsEmpty() || !c.IsEmpty() || b.Size() != 1 {
+		t.Errorf("Expected only the mark to remain, got sizes %d, %d and %d", a.Size(), b.Size(), c.Size())
+	}
+}
+
+func TestDoublyLinkedListElementHandles(t *testing.T) {
+	l := NewDoublyLinkedList[int]()
+	elements := make([]*Element[int], 5)
+	for i := range elements {
+		elements[i] = l.PushBack(i)
+	}
+	l.Reverse()
+	if l.Front() != elements[4] || l.Back() != elements[0] {
+		t.Errorf("Expected the handles to survive Reverse")
+	}
+	var walked []int
+	for e := l.Front(); e != nil; e = e.Next() {
+		walked = append(walked, e.Value)
+	}
+	for e := l.Back(); e != nil; e = e.Prev() {
+		walked = append(walked, e.Value)
+	}
+	if fmt.Sprint(walked) != "[4 3 2 1 0 0 1 2 3 4]" {
+		t.Errorf("Unexpected walk %v", walked)
+	}
+
+	e, i := l.Find(func(v int) bool { return v < 3 })
+	if e != elements[2] || i != 2 {
+		t.Errorf("Expected Find to return the element of 2 at position 2, got position %d", i)
+	}
+	if e, i := l.Find(func(v int) bool { return v > 10 }); e != nil || i != -1 {
+		t.Errorf("Expected Find to report no match")
+	}
+	e.Value = 20
+	if got, _ := l.Get(2); got != 20 {
+		t.Errorf("Expected a write through the handle to change the list, got %d", got)
+	}
+}
+
+func TestDoublyLinkedListSplice(t *testing.T) {
+	cases := []struct {
+		name     string
+		at       int
+		ok       bool
+		expected []int
+	}{
+		{"Front", 0, true, []int{7, 8, 1, 2, 3}},
+		{"Middle", 2, true, []int{1, 2, 7, 8, 3}},
+		{"Back", 3, true, []int{1, 2, 3, 7, 8}},
+		{"OutOfRange", 4, false, []int{1, 2, 3}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewDoublyLinkedList[int]()
+			for i := 1; i <= 3; i++ {
+				l.PushBack(i)
+			}
+			other := NewDoublyLinkedList[int]()
+			seven := other.PushBack(7)
+			other.PushBack(8)
+			if got := l.Splice(tc.at, other); got != tc.ok {
+				t.Fatalf("Expected Splice to return %v but got %v", tc.ok, got)
+			}
+			if err := verifyDoublyLinkedList(l); err != nil {
+				t.Fatalf("Invariant violated: %v", err)
+			}
+			if got := slices.Collect(l.All()); !slices.Equal(got, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, got)
+			}
+			if tc.ok {
+				if !other.IsEmpty() {
+					t.Errorf("Expected the spliced list to be empty")
+				}
+				l.MoveToBack(seven)
+				if l.Back() != seven {
+					t.Errorf("Expected a spliced handle to belong to the list")
+				}
+			}
+		})
+	}
+
+	l := NewDoublyLinkedList[int]()
+	l.PushBack(1)
+	if l.Splice(0, l) || l.Size() != 1 {
+		t.Errorf("Expected splicing a list into itself to fail")
+	}
+}
+
+func TestDoublyLinkedListRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	l := NewDoublyLinkedList[int]()
+	var model []int
+	for i := 0; i < 5000; i++ {
+		switch r.Intn(6) {
+		case 0:
+			at := r.Intn(len(model) + 1)
+			l.InsertAt(at, i)
+			model = slices.Insert(model, at, i)
+		case 1:
+			if len(model) > 0 {
+				at := r.Intn(len(model))
+				v, ok := l.RemoveAt(at)
+				if !ok || v != model[at] {
+					t.Fatalf("RemoveAt(%d) = (%d, %v), want %d", at, v, ok, model[at])
+				}
+				model = slices.Delete(model, at, at+1)
+			}
+		case 2:
+			l.PushFront(i)
+			model = slices.Insert(model, 0, i)
+		case 3:
+			if e := l.Back(); e != nil {
+				l.MoveToFront(e)
+				model = slices.Insert(model[:len(model)-1], 0, model[len(model)-1])
+			}
+		case 4:
+			if r.Intn(10) == 0 {
+				l.Reverse()
+				slices.Reverse(model)
+			}
+		default:
+			if len(model) > 0 {
+				at := r.Intn(len(model))
+				if v, ok := l.Get(at); !ok || v != model[at] {
+					t.Fatalf("Get(%d) = (%d, %v), want %d", at, v, ok, model[at])
+				}
+			}
+		}
+		if err := verifyDoublyLinkedList(l); err != nil {
+			t.Fatalf("Invariant violated after operation %d: %v", i, err)
+		}
+	}
+	if got := slices.Collect(l.All()); !slices.Equal(got, model) {
+		t.Errorf("Expected %v but got %v", model, got)
+	}
+}
@@ -0,0 +1,256 @@
+package structs
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// fibonacciNode is a node of a FibonacciHeap. The roots of the heap, and the children of every
+// node, form circular doubly linked lists through left and right. A node is marked when it has
+// lost a child since it became the child of its parent.
+type fibonacciNode[T any] struct {
+	value                      T
+	parent, child, left, right *fibonacciNode[T]
+	degree                     int
+	marked                     bool
+	tag                        atomic.Pointer[heapTag]
+}
+
+// Value returns the value of the node.
+func (n *fibonacciNode[T]) Value() T {
+	return n.value
+}
+
+// FibonacciHeap is a thread-safe Fibonacci heap of values of type T ordered by a cmp function, as
+// described by Fredman and Tarjan. It is a list of trees in which every node comes no later than
+// its children, together with a pointer to the root that comes first.
+//
+// The heap is even lazier than PairingHeap: Push adds a tree of one node to the list of roots and
+// Meld concatenates two lists, both in O(1) time. DecreaseKey cuts the node out of its tree and
+// adds it to the roots, and if the parent had already lost a child, cuts the parent too, and so on
+// up the tree; these cascading cuts keep a tree with a root of degree k at least as large as the
+// (k+2)th Fibonacci number, which gives the heap its name, so DecreaseKey takes O(1) amortized
+// time. Pop consolidates the roots, linking roots of the same degree until all degrees differ,
+// which takes O(log N) amortized time.
+//
+// These are the best bounds of any heap in this package, and they make Dijkstra's and Prim's
+// algorithms run in O(E + V log V) time. The constant factors are high, however, so a DaryHeap or
+// a PairingHeap is often faster on graphs of practical size. See Heap for the operations and their
+// running times.
+type FibonacciHeap[T any] struct {
+	mu      sync.Mutex
+	first   *fibonacciNode[T]
+	size    int
+	cmp     func(a, b T) int
+	tag     *heapTag
+	degrees []*fibonacciNode[T]
+}
+
+// NewFibonacciHeap creates a new empty FibonacciHeap ordered by the given cmp function.
+func NewFibonacciHeap[T any](cmp func(a, b T) int) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{cmp: cmp, tag: &heapTag{}}
+}
+
+// Push adds a value to the heap and returns its node.
+func (h *FibonacciHeap[T]) Push(value T) HeapNode[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := &fibonacciNode[T]{value: value}
+	n.tag.Store(h.tag)
+	n.left, n.right = n, n
+	h.first = h.concat(h.first, n)
+	h.size++
+	return n
+}
+
+// Pop removes and returns the first value of the heap. The boolean result is false if the heap is
+// empty.
+func (h *FibonacciHeap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	z := h.first
+	if z == nil {
+		var zero T
+		return zero, false
+	}
+	if c := z.child; c != nil {
+		for x := c; ; x = x.right {
+			x.parent, x.marked = nil, false
+			if x.right == c {
+				break
+			}
+		}
+		z.child = nil
+		h.concat(z, c)
+	}
+	if z.right == z {
+		h.first = nil
+	} else {
+		h.first = z.right
+		z.left.right = z.right
+		z.right.left = z.left
+		h.consolidate()
+	}
+	h.size--
+	z.left, z.right = nil, nil
+	z.tag.Store(nil)
+	return z.value, true
+}
+
+// Peek returns the first value of the heap without removing it. The boolean result is false if the
+// heap is empty.
+func (h *FibonacciHeap[T]) Peek() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.first == nil {
+		var zero T
+		return zero, false
+	}
+	return h.first.value, true
+}
+
+// DecreaseKey replaces the value of the given node with one that comes no later. If the node then
+// comes before its parent, it is cut out of its tree and added to the roots, and so are those of
+// its ancestors that had already lost a child. It returns false, and changes nothing, if the node
+// does not belong to the heap or the new value would come after the current one.
+func (h *FibonacciHeap[T]) DecreaseKey(node HeapNode[T], value T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n, ok := node.(*fibonacciNode[T])
+	if !ok || !h.tag.owns(n.tag.Load()) || h.cmp(value, n.value) > 0 {
+		return false
+	}
+	n.value = value
+	if p := n.parent; p != nil && h.cmp(n.value, p.value) < 0 {
+		h.cut(n)
+		for p.parent != nil {
+			if !p.marked {
+				p.marked = true
+				break
+			}
+			next := p.parent
+			h.cut(p)
+			p = next
+		}
+	}
+	if h.cmp(n.value, h.first.value) < 0 {
+		h.first = n
+	}
+	return true
+}
+
+// Meld moves all values of other, which must be a *FibonacciHeap, into the heap and leaves other
+// empty, in O(1) time. It returns false if other is not a *FibonacciHeap or is the heap itself.
+//
+// The two heaps are never locked together; see the package documentation.
+func (h *FibonacciHeap[T]) Meld(other Heap[T]) bool {
+	o, ok := other.(*FibonacciHeap[T])
+	if !ok || o == h {
+		return false
+	}
+	o.mu.Lock()
+	first, size, tag := o.first, o.size, o.tag
+	o.first, o.size, o.tag = nil, 0, &heapTag{}
+	o.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tag.link(h.tag)
+	h.first = h.concat(h.first, first)
+	h.size += size
+	return true
+}
+
+// Size returns the number of values in the heap.
+func (h *FibonacciHeap[T]) Size() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.size
+}
+
+// IsEmpty returns true if the heap holds no values, false otherwise.
+func (h *FibonacciHeap[T]) IsEmpty() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.first == nil
+}
+
+// concat joins two circular lists of roots, either of which may be nil, and returns the root of
+// the result that comes first, preferring a.
+func (h *FibonacciHeap[T]) concat(a, b *fibonacciNode[T]) *fibonacciNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	aLast, bLast := a.left, b.left
+	aLast.right, b.left = b, aLast
+	bLast.right, a.left = a, bLast
+	if h.cmp(b.value, a.value) < 0 {
+		return b
+	}
+	return a
+}
+
+// cut removes a node from the children of its parent and adds it to the roots, unmarked.
+func (h *FibonacciHeap[T]) cut(n *fibonacciNode[T]) {
+	p := n.parent
+	if n.right == n {
+		p.child = nil
+	} else {
+		n.left.right = n.right
+		n.right.left = n.left
+		if p.child == n {
+			p.child = n.right
+		}
+	}
+	p.degree--
+	n.parent, n.marked = nil, false
+	n.left, n.right = n, n
+	h.concat(h.first, n)
+}
+
+// consolidate links roots of the same degree, the one that comes later becoming a child of the
+// other, until all roots have distinct degrees, and then rebuilds the list of roots and finds the
+// root that comes first. The table of roots by degree is kept between calls.
+func (h *FibonacciHeap[T]) consolidate() {
+	roots := h.first
+	roots.left.right = nil
+	for w := roots; w != nil; {
+		x := w
+		w = w.right
+		for {
+			for x.degree >= len(h.degrees) {
+				h.degrees = append(h.degrees, nil)
+			}
+			y := h.degrees[x.degree]
+			if y == nil {
+				break
+			}
+			h.degrees[x.degree] = nil
+			if h.cmp(y.value, x.value) < 0 {
+				x, y = y, x
+			}
+			y.parent, y.marked = x, false
+			if x.child == nil {
+				y.left, y.right = y, y
+				x.child = y
+			} else {
+				y.left, y.right = x.child.left, x.child
+				y.left.right, y.right.left = y, y
+			}
+			x.degree++
+		}
+		h.degrees[x.degree] = x
+	}
+
+	h.first = nil
+	for i, x := range h.degrees {
+		if x != nil {
+			h.degrees[i] = nil
+			x.left, x.right = x, x
+			h.first = h.concat(h.first, x)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package structs
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// verifyFibonacciHeap checks that the circular lists of a FibonacciHeap link up in both
+// directions, that no node comes before its parent and no root before the first one, that degrees
+// count the children, that roots are unmarked, that every node belongs to the heap and that the
+// size is right.
+func verifyFibonacciHeap[T any](h *FibonacciHeap[T]) error {
+	count := 0
+	var walk func(first, parent *fibonacciNode[T]) error
+	walk = func(first, parent *fibonacciNode[T]) error {
+		n := first
+		for {
+			count++
+			if count > h.size {
+				return errors.New("the heap has more nodes than its size")
+			}
+			if n.right.left != n || n.parent != parent || !h.tag.owns(n.tag.Load()) {
+				return errors.New("broken links or node belongs to another heap")
+			}
+			if parent == nil && (n.marked || h.cmp(n.value, h.first.value) < 0) {
+				return errors.New("root is marked or comes before the first root")
+			}
+			if parent != nil && h.cmp(n.value, parent.value) < 0 {
+				return errors.New("node comes before its parent")
+			}
+			degree := 0
+			if n.child != nil {
+				for c := n.child; ; c = c.right {
+					degree++
+					if c.right == n.child {
+						break
+					}
+				}
+				if err := walk(n.child, n); err != nil {
+					return err
+				}
+			}
+			if degree != n.degree {
+				return fmt.Errorf("node of degree %d has %d children", n.degree, degree)
+			}
+			if n = n.right; n == first {
+				return nil
+			}
+		}
+	}
+	if h.first != nil {
+		if err := walk(h.first, nil); err != nil {
+			return err
+		}
+	}
+	if count != h.size {
+		return fmt.Errorf("heap holds %d nodes but size %d", count, h.size)
+	}
+	return nil
+}
+
+func TestFibonacciHeapDegreeBound(t *testing.T) {
+	const n = 1000
+	h := NewFibonacciHeap(cmp.Compare[int])
+	nodes := make([]HeapNode[int], n)
+	for i := range nodes {
+		nodes[i] = h.Push(i)
+	}
+	h.Pop()
+	for i := n - 1; i > 0; i -= 3 {
+		h.DecreaseKey(nodes[i], nodes[i].Value()-n/2)
+		if err := verifyFibonacciHeap(h); err != nil {
+			t.Fatalf("Invariant violated after decreasing %d: %v", i, err)
+		}
+	}
+	for h.Size() > 10 {
+		h.Pop()
+		// A tree whose root has degree k has at least F(k+2) >= phi^k nodes.
+		bound := int(math.Log(float64(h.Size()))/math.Log(math.Phi)) + 1
+		for r := h.first; ; r = r.right {
+			if r.degree > bound {
+				t.Fatalf("Root of degree %d exceeds the bound %d for %d values", r.degree, bound, h.Size())
+			}
+			if r.right == h.first {
+				break
+			}
+		}
+	}
+	if err := verifyFibonacciHeap(h); err != nil {
+		t.Fatalf("Invariant violated: %v", err)
+	}
+}
@@ -1,31 +1,57 @@
 package structs
 
-import "sync"
+import (
+	"iter"
+	"sync"
+)
 
-// HashMap is a thread-safe map implementation in Go. It provides methods for adding, getting, removing, and checking the size and emptiness of items in the map.
-// Declaration:
-type HashMap struct {
+// HashMap is a thread-safe map implementation in Go with keys of type K and values of type V.
+// It provides methods for adding, getting, removing, and checking the size and emptiness of items in the map.
+//
+// The map is an open-addressing hash table with Robin Hood hashing; see the table type for how
+// keys are placed. Keys that implement base.Object are hashed with their Hash method and compared
+// with their Equals method, so two distinct objects that are equal by value are the same key; all
+// other keys are hashed by value and compared with ==. The table doubles its capacity when its
+// load factor would exceed the maximum load factor and halves it when it falls below a quarter of
+// that, and Stats reports how well the keys are spread.
+type HashMap[K comparable, V any] struct {
 	mu    sync.Mutex
-	items map[interface{}]interface{}
+	table table[K, V]
+}
+
+// HashMapOptions configures a HashMap.
+// InitialCapacity is the number of keys the map can hold before it first grows; the map never
+// shrinks below that. MaxLoadFactor is the fraction of slots that may be in use before the table
+// grows. Higher values save memory at the price of longer probe sequences. A MaxLoadFactor of
+// zero, or any value outside (0, 1), selects the default of 0.85.
+type HashMapOptions struct {
+	InitialCapacity int
+	MaxLoadFactor   float64
 }
 
-// NewHashMap returns a new instance of HashMap with an empty item map.
-func NewHashMap() *HashMap {
-	return &HashMap{
-		items: make(map[interface{}]interface{}),
+// NewHashMap returns a new instance of HashMap with an empty table and the default options.
+func NewHashMap[K comparable, V any]() *HashMap[K, V] {
+	return NewHashMapWithOptions[K, V](HashMapOptions{})
+}
+
+// NewHashMapWithOptions returns a new instance of HashMap with an empty table configured by opts.
+// The slots are allocated when the first item is added.
+func NewHashMapWithOptions[K comparable, V any](opts HashMapOptions) *HashMap[K, V] {
+	return &HashMap[K, V]{
+		table: newTable[K, V](newHasher[K](), opts.InitialCapacity, opts.MaxLoadFactor),
 	}
 }
 
 // Put adds or updates an item in the HashMap with the specified key and value.
 // It locks the HashMap, adds or updates the item, and then unlocks the HashMap.
-func (hm *HashMap) Put(key, value interface{}) {
+func (hm *HashMap[K, V]) Put(key K, value V) {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
-	hm.items[key] = value
+	hm.table.put(key, hm.table.hasher.hash(key), value)
 }
 
 // Get returns the value associated with the given key in the HashMap.
-// If the key is not found, it returns nil.
+// If the key is not found, it returns the zero value of V.
 // The function acquires a lock on the HashMap before accessing the items.
 // The lock is released after retrieving the value.
 //
@@ -33,11 +59,44 @@ func (hm *HashMap) Put(key, value interface{}) {
 //   - key: The key of the item to retrieve.
 //
 // Returns:
-//   - interface{}: The value associated with the key, or nil if the key is not found.
-func (hm *HashMap) Get(key interface{}) interface{} {
+//   - V: The value associated with the key, or the zero value of V if the key is not found.
+//
+// Get cannot tell a missing key from a key whose value is the zero value of V; use GetOk or
+// Contains for that.
+func (hm *HashMap[K, V]) Get(key K) V {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	value, _ := hm.table.get(key, hm.table.hasher.hash(key))
+	return value
+}
+
+// GetOk returns the value associated with the given key in the HashMap and true, or the zero
+// value of V and false if the key is not found.
+func (hm *HashMap[K, V]) GetOk(key K) (V, bool) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return hm.table.get(key, hm.table.hasher.hash(key))
+}
+
+// Contains reports whether the given key is present in the HashMap, even if its value is the
+// zero value of V.
+func (hm *HashMap[K, V]) Contains(key K) bool {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
-	return hm.items[key]
+	return hm.table.find(key, hm.table.hasher.hash(key)) >= 0
+}
+
+// PutIfAbsent adds an item with the specified key and value if the key is not present, and
+// reports whether it did. An existing value is left unchanged. The check and the addition happen
+// under a single lock, so of several goroutines putting the same absent key exactly one succeeds.
+func (hm *HashMap[K, V]) PutIfAbsent(key K, value V) bool {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hash := hm.table.hasher.hash(key)
+	if hm.table.find(key, hash) >= 0 {
+		return false
+	}
+	return hm.table.put(key, hash, value)
 }
 
 // Remove removes the key-value pair with the specified key from the HashMap.
@@ -45,27 +104,128 @@ func (hm *HashMap) Get(key interface{}) interface{} {
 // This method acquires and releases a lock to ensure thread-safety.
 // To avoid potential race conditions, it is recommended to call this method
 // within a lock or in a thread-safe manner.
-func (hm *HashMap) Remove(key interface{}) {
+func (hm *HashMap[K, V]) Remove(key K) {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
-	delete(hm.items, key)
+	hm.table.remove(key, hm.table.hasher.hash(key))
 }
 
 // Size returns the number of items in the HashMap.
-// It acquires the lock, retrieves the length of the items map,
+// It acquires the lock, retrieves the number of keys in the table,
 // and releases the lock before returning the result.
-func (hm *HashMap) Size() int {
+func (hm *HashMap[K, V]) Size() int {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
-	return len(hm.items)
+	return hm.table.size
 }
 
 // IsEmpty returns a boolean value indicating whether the HashMap is empty or not.
 // It acquires a lock on the HashMap, checks if the length of the items in the
 // HashMap is zero, and releases the lock. It returns true if the items are empty,
 // otherwise, it returns false.
-func (hm *HashMap) IsEmpty() bool {
+func (hm *HashMap[K, V]) IsEmpty() bool {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
-	return len(hm.items) == 0
+	return hm.table.size == 0
+}
+
+// Clear removes all items from the HashMap and releases their memory. The map keeps its options
+// and grows back to its initial capacity when the next item is added.
+func (hm *HashMap[K, V]) Clear() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.table.clear()
+}
+
+// Merge adds all items of other to the HashMap. For a key present in both maps, the new value is
+// the result of resolver, which is called with the key, the value in the HashMap and the value in
+// other; a nil resolver keeps the value from other.
+//
+// The two maps are never locked together, so a map may be merged into itself; see the package
+// documentation. The resolver runs while the HashMap is locked and must not use it.
+func (hm *HashMap[K, V]) Merge(other *HashMap[K, V], resolver func(key K, current, incoming V) V) {
+	entries := other.Entries()
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	for _, e := range entries {
+		hash := hm.table.hasher.hash(e.Key)
+		if i := hm.table.find(e.Key, hash); i >= 0 {
+			if resolver != nil {
+				hm.table.slots[i].value = resolver(e.Key, hm.table.slots[i].value, e.Value)
+			} else {
+				hm.table.slots[i].value = e.Value
+			}
+			continue
+		}
+		hm.table.put(e.Key, hash, e.Value)
+	}
+}
+
+// Clone returns a new HashMap with the same items and options as the HashMap. The copy is
+// independent: changes to either map do not affect the other, although values that are pointers
+// or contain references still share what they point to.
+func (hm *HashMap[K, V]) Clone() *HashMap[K, V] {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return &HashMap[K, V]{table: hm.table.clone()}
+}
+
+// Stats returns the current occupancy and probe-length statistics of the underlying table.
+// It examines every slot, so it takes time proportional to the capacity of the table.
+func (hm *HashMap[K, V]) Stats() HashMapStats {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return hm.table.stats()
+}
+
+// All returns an iterator over the key-value pairs of the HashMap in unspecified order. The
+// HashMap is locked while the loop runs; see the package documentation.
+func (hm *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		hm.mu.Lock()
+		defer hm.mu.Unlock()
+		hm.table.all()(yield)
+	}
+}
+
+// Entry is a key-value pair of a HashMap.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// Entries returns the key-value pairs of the HashMap in unspecified order. Unlike the iterators,
+// it copies the items into a new slice and releases the lock before returning, so the HashMap may
+// be modified while the result is processed.
+func (hm *HashMap[K, V]) Entries() []Entry[K, V] {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	entries := make([]Entry[K, V], 0, hm.table.size)
+	for key, value := range hm.table.all() {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+	}
+	return entries
+}
+
+// Keys returns an iterator over the keys of the HashMap in unspecified order. Collect it with
+// slices.Collect to obtain a slice.
+func (hm *HashMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range hm.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the HashMap in unspecified order.
+func (hm *HashMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range hm.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
 }
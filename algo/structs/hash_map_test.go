@@ -1,23 +1,28 @@
 package structs
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"testing"
+
+	"github.com/ooyeku/algo/algo/base"
 )
 
 func TestHashMap(t *testing.T) {
 	tests := []struct {
 		name     string
-		ops      []func(*HashMap) interface{}
+		ops      []func(*HashMap[string, interface{}]) interface{}
 		expected []interface{}
 	}{
 		{
 			name: "AddItemGetItem",
-			ops: []func(*HashMap) interface{}{
-				func(hm *HashMap) interface{} {
+			ops: []func(*HashMap[string, interface{}]) interface{}{
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Put("key1", "value1")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					return hm.Get("key1")
 				},
 			},
@@ -25,19 +30,19 @@ func TestHashMap(t *testing.T) {
 		},
 		{
 			name: "AddMultipleGetMultiple",
-			ops: []func(*HashMap) interface{}{
-				func(hm *HashMap) interface{} {
+			ops: []func(*HashMap[string, interface{}]) interface{}{
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Put("key1", "value1")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Put("key2", "value2")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					return hm.Get("key1")
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					return hm.Get("key2")
 				},
 			},
@@ -45,16 +50,16 @@ func TestHashMap(t *testing.T) {
 		},
 		{
 			name: "RemoveItem",
-			ops: []func(*HashMap) interface{}{
-				func(hm *HashMap) interface{} {
+			ops: []func(*HashMap[string, interface{}]) interface{}{
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Put("key1", "value1")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Remove("key1")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					return hm.Get("key1")
 				},
 			},
@@ -62,16 +67,16 @@ func TestHashMap(t *testing.T) {
 		},
 		{
 			name: "CheckSize",
-			ops: []func(*HashMap) interface{}{
-				func(hm *HashMap) interface{} {
+			ops: []func(*HashMap[string, interface{}]) interface{}{
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Put("key1", "value1")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Put("key2", "value2")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					return hm.Size()
 				},
 			},
@@ -79,22 +84,22 @@ func TestHashMap(t *testing.T) {
 		},
 		{
 			name: "CheckEmpty",
-			ops: []func(*HashMap) interface{}{
-				func(hm *HashMap) interface{} {
+			ops: []func(*HashMap[string, interface{}]) interface{}{
+				func(hm *HashMap[string, interface{}]) interface{} {
 					return hm.IsEmpty()
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Put("key1", "value1")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					return hm.IsEmpty()
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					hm.Remove("key1")
 					return nil
 				},
-				func(hm *HashMap) interface{} {
+				func(hm *HashMap[string, interface{}]) interface{} {
 					return hm.IsEmpty()
 				},
 			},
@@ -104,7 +109,7 @@ func TestHashMap(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			hm := NewHashMap()
+			hm := NewHashMap[string, interface{}]()
 			for i, op := range tt.ops {
 				result := op(hm)
 				if result != tt.expected[i] {
@@ -114,3 +119,244 @@ func TestHashMap(t *testing.T) {
 		})
 	}
 }
+
+func TestHashMapIterators(t *testing.T) {
+	hm := NewHashMap[string, int]()
+	expected := map[string]int{"one": 1, "two": 2, "three": 3}
+	for k, v := range expected {
+		hm.Put(k, v)
+	}
+	if got := maps.Collect(hm.All()); !maps.Equal(got, expected) {
+		t.Errorf("All: expected %v but got %v", expected, got)
+	}
+	if got := slices.Sorted(hm.Keys()); !slices.Equal(got, []string{"one", "three", "two"}) {
+		t.Errorf("Keys: expected [one three two] but got %v", got)
+	}
+	if got := slices.Sorted(hm.Values()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Values: expected [1 2 3] but got %v", got)
+	}
+	for range hm.Keys() {
+		break
+	}
+	if hm.Size() != 3 {
+		t.Errorf("Expected the map to be usable after an early break")
+	}
+}
+
+func TestHashMapLookups(t *testing.T) {
+	hm := NewHashMap[string, *int]()
+	one := 1
+	hm.Put("nil", nil)
+	hm.Put("one", &one)
+
+	cases := []struct {
+		key   string
+		want  *int
+		found bool
+	}{
+		{"nil", nil, true},
+		{"one", &one, true},
+		{"missing", nil, false},
+	}
+	for _, c := range cases {
+		t.Run(c.key, func(t *testing.T) {
+			if got, found := hm.GetOk(c.key); got != c.want || found != c.found {
+				t.Errorf("GetOk: expected (%v, %v) but got (%v, %v)", c.want, c.found, got, found)
+			}
+			if got := hm.Contains(c.key); got != c.found {
+				t.Errorf("Contains: expected %v but got %v", c.found, got)
+			}
+		})
+	}
+}
+
+func TestHashMapPutIfAbsent(t *testing.T) {
+	hm := NewHashMap[string, int]()
+	if !hm.PutIfAbsent("a", 1) {
+		t.Errorf("Expected PutIfAbsent to add a missing key")
+	}
+	if hm.PutIfAbsent("a", 2) {
+		t.Errorf("Expected PutIfAbsent to leave a present key alone")
+	}
+	if got := hm.Get("a"); got != 1 {
+		t.Errorf("Expected 1 but got %d", got)
+	}
+}
+
+func TestHashMapEntriesClear(t *testing.T) {
+	hm := NewHashMapWithOptions[int, int](HashMapOptions{InitialCapacity: 100})
+	for i := 0; i < 50; i++ {
+		hm.Put(i, i*i)
+	}
+	entries := hm.Entries()
+	if len(entries) != 50 {
+		t.Fatalf("Expected 50 entries but got %d", len(entries))
+	}
+	// The entries are a copy, so the map may be modified while they are processed.
+	for _, e := range entries {
+		if e.Value != e.Key*e.Key {
+			t.Errorf("Entry %d has value %d", e.Key, e.Value)
+		}
+		hm.Remove(e.Key)
+	}
+	if !hm.IsEmpty() {
+		t.Errorf("Expected removing every entry to empty the map")
+	}
+
+	hm.Put(1, 1)
+	hm.Clear()
+	if hm.Size() != 0 || hm.Contains(1) || hm.Stats().Capacity != 0 {
+		t.Errorf("Expected Clear to remove all keys and release the slots, got %+v", hm.Stats())
+	}
+	hm.Put(2, 2)
+	if got := hm.Stats().Capacity; got != 128 {
+		t.Errorf("Expected the map to grow back to its initial capacity of 128 slots but got %d", got)
+	}
+}
+
+func TestHashMapMerge(t *testing.T) {
+	sum := func(_ string, current, incoming int) int {
+		return current + incoming
+	}
+	cases := []struct {
+		name     string
+		resolver func(string, int, int) int
+		expected map[string]int
+	}{
+		{"NilResolver", nil, map[string]int{"a": 1, "b": 20, "c": 30}},
+		{"Sum", sum, map[string]int{"a": 1, "b": 22, "c": 30}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			hm := NewHashMap[string, int]()
+			hm.Put("a", 1)
+			hm.Put("b", 2)
+			other := NewHashMap[string, int]()
+			other.Put("b", 20)
+			other.Put("c", 30)
+			hm.Merge(other, c.resolver)
+			if got := maps.Collect(hm.All()); !maps.Equal(got, c.expected) {
+				t.Errorf("Expected %v but got %v", c.expected, got)
+			}
+			if other.Size() != 2 {
+				t.Errorf("Expected the merged map to be unchanged")
+			}
+		})
+	}
+
+	hm := NewHashMap[string, int]()
+	hm.Put("x", 3)
+	hm.Merge(hm, sum)
+	if got := hm.Get("x"); got != 6 {
+		t.Errorf("Expected merging a map into itself to double x to 6 but got %d", got)
+	}
+}
+
+func TestHashMapClone(t *testing.T) {
+	hm := NewHashMapWithOptions[int, string](HashMapOptions{MaxLoadFactor: 0.5})
+	for i := 0; i < 100; i++ {
+		hm.Put(i, fmt.Sprint(i))
+	}
+	clone := hm.Clone()
+	if !maps.Equal(maps.Collect(clone.All()), maps.Collect(hm.All())) {
+		t.Fatalf("Expected the clone to hold the same items")
+	}
+	if err := verifyTable(&clone.table); err != nil {
+		t.Fatalf("Invariant violated in the clone: %v", err)
+	}
+	if got := clone.Stats().MaxLoadFactor; got != 0.5 {
+		t.Errorf("Expected the clone to keep the load factor 0.5 but got %v", got)
+	}
+	clone.Put(0, "changed")
+	clone.Remove(1)
+	hm.Put(100, "100")
+	if hm.Get(0) != "0" || !hm.Contains(1) || clone.Contains(100) {
+		t.Errorf("Expected the clone and the original to be independent")
+	}
+}
+
+func TestHashMapObjectKeys(t *testing.T) {
+	hm := NewHashMap[*base.Atom, string]()
+	hm.Put(base.NewAtom(1), "int")
+	hm.Put(base.NewAtom("one"), "string")
+
+	// Distinct atoms holding equal values are the same key.
+	if got := hm.Get(base.NewAtom(1)); got != "int" {
+		t.Errorf("Expected int but got %q", got)
+	}
+	hm.Put(base.NewAtom("one"), "replaced")
+	if hm.Size() != 2 {
+		t.Errorf("Expected 2 keys but got %d", hm.Size())
+	}
+	if got := hm.Get(base.NewAtom("one")); got != "replaced" {
+		t.Errorf("Expected replaced but got %q", got)
+	}
+	hm.Remove(base.NewAtom(1))
+	if got := hm.Get(base.NewAtom(1)); got != "" || hm.Size() != 1 {
+		t.Errorf("Expected the atom 1 to be removed")
+	}
+
+	// Keys of an interface type use Hash and Equals of their dynamic values.
+	objects := NewHashMap[base.Object, int]()
+	objects.Put(base.NewAtom(2.5), 1)
+	objects.Put(base.NewAtom(2.5), 2)
+	if objects.Size() != 1 || objects.Get(base.NewAtom(2.5)) != 2 {
+		t.Errorf("Expected a single key with value 2")
+	}
+
+	// A nil pointer is a valid key; its Hash and Equals methods are not called.
+	var nilAtom *base.Atom
+	hm.Put(nilAtom, "nil")
+	if got, ok := hm.GetOk(nil); !ok || got != "nil" {
+		t.Errorf("Expected the nil key to map to nil but got (%q, %v)", got, ok)
+	}
+	if hm.Size() != 2 || !hm.Contains(base.NewAtom("one")) {
+		t.Errorf("Expected the nil key to be distinct from the other keys")
+	}
+	objects.Put(nilAtom, 3)
+	if objects.Get(nilAtom) != 3 || objects.Get(nil) != 0 || objects.Size() != 2 {
+		t.Errorf("Expected a typed nil key distinct from the nil interface")
+	}
+}
+
+func TestHashMapStats(t *testing.T) {
+	hm := NewHashMapWithOptions[int, int](HashMapOptions{InitialCapacity: 1000, MaxLoadFactor: 0.9})
+	if stats := hm.Stats(); stats.Capacity != 0 || stats.MaxLoadFactor != 0.9 {
+		t.Errorf("Expected no slots before the first Put but got %+v", stats)
+	}
+	for i := 0; i < 1000; i++ {
+		hm.Put(i, i)
+	}
+	stats := hm.Stats()
+	if stats.Len != 1000 || stats.Capacity != 2048 {
+		t.Errorf("Expected 1000 keys in 2048 slots but got %+v", stats)
+	}
+	if stats.LoadFactor > stats.MaxLoadFactor || stats.AverageProbeLength < 1 || stats.AverageProbeLength > 3 {
+		t.Errorf("Unexpected statistics %+v", stats)
+	}
+	if stats.MaxProbeLength < 1 || stats.MaxProbeLength > 30 {
+		t.Errorf("Unexpected maximum probe length %d", stats.MaxProbeLength)
+	}
+
+	if got := NewHashMapWithOptions[int, int](HashMapOptions{MaxLoadFactor: 1.5}).Stats().MaxLoadFactor; got != 0.85 {
+		t.Errorf("Expected an invalid load factor to select 0.85 but got %v", got)
+	}
+}
+
+func BenchmarkHashMapPut(b *testing.B) {
+	hm := NewHashMap[int, int]()
+	for i := 0; i < b.N; i++ {
+		hm.Put(i, i)
+	}
+}
+
+func BenchmarkHashMapGet(b *testing.B) {
+	hm := NewHashMap[int, int]()
+	for i := 0; i < 100000; i++ {
+		hm.Put(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hm.Get(i % 100000)
+	}
+}
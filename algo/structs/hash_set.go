@@ -0,0 +1,197 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+)
+
+// HashSet is a thread-safe set of values of type T. It stores its values in the same
+// open-addressing hash table as HashMap, with no value attached to the keys, so adding, removing
+// and looking up a value takes O(1) expected time. Values that implement base.Object are the same
+// value when their Equals method says so; all other values are compared with ==.
+//
+// Union, Intersection, Difference and SymmetricDifference return a new set and leave both
+// operands unchanged. The two sets are never locked together, so a set may be combined with
+// itself; see the package documentation.
+type HashSet[T comparable] struct {
+	mu    sync.Mutex
+	table table[T, struct{}]
+}
+
+// NewHashSet returns a new HashSet holding the given values. Repeated values are added once.
+func NewHashSet[T comparable](values ...T) *HashSet[T] {
+	s := &HashSet[T]{table: newTable[T, struct{}](newHasher[T](), len(values), 0)}
+	for _, v := range values {
+		s.table.put(v, s.table.hasher.hash(v), struct{}{})
+	}
+	return s
+}
+
+// Add adds a value to the set and reports whether it was added, which is false if the value was
+// already present.
+func (s *HashSet[T]) Add(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.table.put(value, s.table.hasher.hash(value), struct{}{})
+}
+
+// Remove removes a value from the set and reports whether it was present.
+func (s *HashSet[T]) Remove(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.table.remove(value, s.table.hasher.hash(value))
+}
+
+// Contains reports whether the value is present in the set.
+func (s *HashSet[T]) Contains(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.table.find(value, s.table.hasher.hash(value)) >= 0
+}
+
+// Size returns the number of values in the set.
+func (s *HashSet[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.table.size
+}
+
+// IsEmpty returns true if the set holds no values, false otherwise.
+func (s *HashSet[T]) IsEmpty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.table.size == 0
+}
+
+// Clear removes all values from the set.
+func (s *HashSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.table.clear()
+}
+
+// Clone returns a new HashSet with the same values as the set. Changes to either set do not
+// affect the other.
+func (s *HashSet[T]) Clone() *HashSet[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &HashSet[T]{table: s.table.clone()}
+}
+
+// Union returns a new set holding the values that are in the set, in other, or in both.
+func (s *HashSet[T]) Union(other *HashSet[T]) *HashSet[T] {
+	values := other.values()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := &HashSet[T]{table: s.table.clone()}
+	for _, v := range values {
+		result.table.put(v, result.table.hasher.hash(v), struct{}{})
+	}
+	return result
+}
+
+// Intersection returns a new set holding the values that are in both the set and other.
+func (s *HashSet[T]) Intersection(other *HashSet[T]) *HashSet[T] {
+	values := other.values()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := &HashSet[T]{table: newTable[T, struct{}](s.table.hasher, 0, s.table.maxLoad)}
+	for _, v := range values {
+		hash := s.table.hasher.hash(v)
+		if s.table.find(v, hash) >= 0 {
+			result.table.put(v, hash, struct{}{})
+		}
+	}
+	return result
+}
+
+// Difference returns a new set holding the values of the set that are not in other.
+func (s *HashSet[T]) Difference(other *HashSet[T]) *HashSet[T] {
+	values := other.values()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := &HashSet[T]{table: s.table.clone()}
+	for _, v := range values {
+		result.table.remove(v, result.table.hasher.hash(v))
+	}
+	return result
+}
+
+// SymmetricDifference returns a new set holding the values that are in exactly one of the set
+// and other.
+func (s *HashSet[T]) SymmetricDifference(other *HashSet[T]) *HashSet[T] {
+	values := other.values()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := &HashSet[T]{table: s.table.clone()}
+	for _, v := range values {
+		hash := result.table.hasher.hash(v)
+		if !result.table.remove(v, hash) {
+			result.table.put(v, hash, struct{}{})
+		}
+	}
+	return result
+}
+
+// IsSubset reports whether every value of the set is also in other. The empty set is a subset of
+// every set, and every set is a subset of itself.
+func (s *HashSet[T]) IsSubset(other *HashSet[T]) bool {
+	values := s.values()
+	other.mu.Lock()
+	defer other.mu.Unlock()
+	if len(values) > other.table.size {
+		return false
+	}
+	for _, v := range values {
+		if other.table.find(v, other.table.hasher.hash(v)) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every value of other is also in the set.
+func (s *HashSet[T]) IsSuperset(other *HashSet[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal reports whether the set and other hold the same values.
+func (s *HashSet[T]) Equal(other *HashSet[T]) bool {
+	values := s.values()
+	other.mu.Lock()
+	defer other.mu.Unlock()
+	if len(values) != other.table.size {
+		return false
+	}
+	for _, v := range values {
+		if other.table.find(v, other.table.hasher.hash(v)) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the values of the set in unspecified order. The set is locked
+// while the loop runs; see the package documentation.
+func (s *HashSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for v := range s.table.all() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// values returns a copy of the values of the set, taken under its lock.
+func (s *HashSet[T]) values() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make([]T, 0, s.table.size)
+	for v := range s.table.all() {
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,95 @@
+package structs
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ooyeku/algo/algo/base"
+)
+
+// verifyHashSet checks the table of a HashSet and that All and Size agree with it.
+func verifyHashSet[T comparable](s *HashSet[T]) error {
+	if err := verifyTable(&s.table); err != nil {
+		return err
+	}
+	if n := len(slices.Collect(s.All())); n != s.Size() {
+		return fmt.Errorf("All() yielded %d values, but Size() = %d", n, s.Size())
+	}
+	return nil
+}
+
+func TestHashSet(t *testing.T) {
+	s := NewHashSet(3, 1, 3)
+	if s.Size() != 2 {
+		t.Errorf("Size() = %d, want 2", s.Size())
+	}
+	if !s.Add(2) || s.Add(2) {
+		t.Errorf("Add should return true for a new value and false for a present one")
+	}
+	if !s.Contains(2) || s.Contains(4) {
+		t.Errorf("Contains does not reflect the added values")
+	}
+	if !s.Remove(1) || s.Remove(1) {
+		t.Errorf("Remove should return true for a present value and false for a missing one")
+	}
+	if got, want := setValues(s), []int{2, 3}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+
+	c := s.Clone()
+	c.Add(5)
+	if s.Contains(5) || !c.Contains(5) {
+		t.Errorf("Clone should be independent of the original set")
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || s.Size() != 0 || s.Contains(2) {
+		t.Errorf("Clear should remove all values")
+	}
+	if !s.Add(2) || s.Size() != 1 {
+		t.Errorf("a cleared set should accept values again")
+	}
+}
+
+func TestHashSetObjectValues(t *testing.T) {
+	s := NewHashSet(base.NewAtom(1), base.NewAtom("one"))
+	// Distinct atoms holding equal values are the same value.
+	if s.Add(base.NewAtom(1)) || !s.Contains(base.NewAtom("one")) {
+		t.Errorf("atoms holding equal values should be the same value")
+	}
+	other := NewHashSet(base.NewAtom("one"), base.NewAtom(2))
+	if got := s.Intersection(other); got.Size() != 1 || !got.Contains(base.NewAtom("one")) {
+		t.Errorf("Intersection should hold only the atom \"one\"")
+	}
+}
+
+func TestHashSetConcurrentAlgebra(t *testing.T) {
+	a, b := NewHashSet[int](), NewHashSet[int]()
+	var wg sync.WaitGroup
+	// Operations between the same two sets in opposite directions must not deadlock.
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				a.Add(j)
+				a.Union(b)
+				a.IsSubset(b)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				b.Add(j)
+				b.Difference(a)
+				b.IsSubset(a)
+			}
+		}()
+	}
+	wg.Wait()
+	if a.Size() != 200 || !a.Equal(b) {
+		t.Errorf("both sets should hold the values 0 to 199")
+	}
+}
@@ -0,0 +1,222 @@
+package structs
+
+import (
+	"iter"
+	"slices"
+)
+
+const (
+	// minTableCapacity is the smallest number of slots of a non-empty table.
+	minTableCapacity = 8
+	// defaultMaxLoadFactor is the load factor above which a table grows by default. Robin Hood
+	// hashing keeps probe sequences short up to high load factors, so the table can be kept
+	// fuller than one that uses plain linear probing.
+	defaultMaxLoadFactor = 0.85
+)
+
+// tableSlot is a slot of a table. dist is one more than the distance of the slot from the home
+// slot of its key, so that the zero value marks an empty slot.
+type tableSlot[K comparable, V any] struct {
+	key   K
+	value V
+	hash  uint64
+	dist  uint32
+}
+
+// table is an open-addressing hash table with Robin Hood hashing and linear probing. It is not
+// safe for concurrent use; HashMap and the shards of ConcurrentHashMap guard it with a lock.
+//
+// A key is stored in the first free slot at or after its home slot, hash & (len(slots)-1). When
+// an inserted key passes a slot whose key is closer to its own home slot than the inserted key is
+// to its home, the two swap places and the displaced key continues the search. Taking slots from
+// the rich and giving them to the poor keeps the variance of the probe lengths low, and since the
+// keys along a probe sequence are ordered by distance, a lookup can stop as soon as it reaches a
+// slot whose key is closer to home than the searched key would be. Deletion shifts the following
+// keys back by one slot instead of leaving tombstones, so lookups never slow down over time.
+type table[K comparable, V any] struct {
+	hasher      hasher[K]
+	slots       []tableSlot[K, V]
+	size        int
+	maxLoad     float64
+	minCapacity int
+}
+
+// newTable returns an empty table that hashes and compares keys with h, grows when its load factor
+// exceeds maxLoad and does not shrink below the slots needed for capacity keys. Values of maxLoad
+// outside (0, 1) select defaultMaxLoadFactor.
+func newTable[K comparable, V any](h hasher[K], capacity int, maxLoad float64) table[K, V] {
+	if maxLoad <= 0 || maxLoad >= 1 {
+		maxLoad = defaultMaxLoadFactor
+	}
+	t := table[K, V]{hasher: h, maxLoad: maxLoad}
+	t.minCapacity = t.capacityFor(capacity)
+	return t
+}
+
+// capacityFor returns the smallest power-of-two number of slots, and at least minTableCapacity,
+// that holds n keys without exceeding the maximum load factor.
+func (t *table[K, V]) capacityFor(n int) int {
+	capacity := minTableCapacity
+	for t.limit(capacity) < n {
+		capacity *= 2
+	}
+	return capacity
+}
+
+// limit returns the number of keys a table with the given number of slots may hold. At least one
+// slot always stays free.
+func (t *table[K, V]) limit(capacity int) int {
+	return min(int(float64(capacity)*t.maxLoad), capacity-1)
+}
+
+// find returns the index of the slot holding key, or -1 if the key is not present.
+func (t *table[K, V]) find(key K, hash uint64) int {
+	if len(t.slots) == 0 {
+		return -1
+	}
+	mask := uint64(len(t.slots) - 1)
+	i := hash & mask
+	for dist := uint32(1); ; dist++ {
+		slot := &t.slots[i]
+		if slot.dist < dist {
+			return -1
+		}
+		if slot.hash == hash && t.hasher.equal(slot.key, key) {
+			return int(i)
+		}
+		i = (i + 1) & mask
+	}
+}
+
+// get returns the value associated with key, whose hash is hash. Callers pass the hash so that
+// ConcurrentHashMap can use the same hash to pick a shard.
+func (t *table[K, V]) get(key K, hash uint64) (V, bool) {
+	if i := t.find(key, hash); i >= 0 {
+		return t.slots[i].value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// put associates value with key, whose hash is hash, and reports whether the key was added.
+func (t *table[K, V]) put(key K, hash uint64, value V) bool {
+	if i := t.find(key, hash); i >= 0 {
+		t.slots[i].value = value
+		return false
+	}
+	if t.size+1 > t.limit(len(t.slots)) {
+		t.resize(max(2*len(t.slots), t.minCapacity))
+	}
+	t.insert(tableSlot[K, V]{key: key, value: value, hash: hash, dist: 1})
+	t.size++
+	return true
+}
+
+// insert stores a key that is not present in the table, which must have a free slot.
+func (t *table[K, V]) insert(s tableSlot[K, V]) {
+	mask := uint64(len(t.slots) - 1)
+	i := s.hash & mask
+	for {
+		slot := &t.slots[i]
+		if slot.dist == 0 {
+			*slot = s
+			return
+		}
+		if slot.dist < s.dist {
+			*slot, s = s, *slot
+		}
+		s.dist++
+		i = (i + 1) & mask
+	}
+}
+
+// remove deletes key, whose hash is hash, and reports whether it was present. The keys following
+// the deleted one in its probe sequence move back by one slot. The table shrinks when it is less
+// than a quarter as full as its maximum load factor allows.
+func (t *table[K, V]) remove(key K, hash uint64) bool {
+	i := t.find(key, hash)
+	if i < 0 {
+		return false
+	}
+	mask := len(t.slots) - 1
+	for j := (i + 1) & mask; t.slots[j].dist > 1; j = (j + 1) & mask {
+		t.slots[i] = t.slots[j]
+		t.slots[i].dist--
+		i = j
+	}
+	t.slots[i] = tableSlot[K, V]{}
+	t.size--
+	if len(t.slots) > t.minCapacity && t.size < t.limit(len(t.slots))/4 {
+		t.resize(len(t.slots) / 2)
+	}
+	return true
+}
+
+// resize moves all keys into a new slot array with the given power-of-two number of slots.
+func (t *table[K, V]) resize(capacity int) {
+	old := t.slots
+	t.slots = make([]tableSlot[K, V], capacity)
+	for _, slot := range old {
+		if slot.dist != 0 {
+			slot.dist = 1
+			t.insert(slot)
+		}
+	}
+}
+
+// clear removes all keys and releases the slots. The table keeps its options, so it grows back
+// to its minimum capacity when the next key is added.
+func (t *table[K, V]) clear() {
+	t.slots = nil
+	t.size = 0
+}
+
+// clone returns a copy of the table that shares no slots with it. The copy uses the same hasher,
+// so every key stays in its slot and nothing needs to be rehashed.
+func (t *table[K, V]) clone() table[K, V] {
+	c := *t
+	c.slots = slices.Clone(t.slots)
+	return c
+}
+
+// all returns an iterator over the keys and values in slot order.
+func (t *table[K, V]) all() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for i := range t.slots {
+			if t.slots[i].dist != 0 && !yield(t.slots[i].key, t.slots[i].value) {
+				return
+			}
+		}
+	}
+}
+
+// HashMapStats describes the occupancy of a hash table and the lengths of its probe sequences.
+// The probe length of a key is the number of slots a lookup of the key examines; a key in its
+// home slot has probe length 1. Robin Hood hashing keeps both the average and the maximum low,
+// so a growing MaxProbeLength points to a poor hash function or too high a load factor.
+type HashMapStats struct {
+	Len                int
+	Capacity           int
+	LoadFactor         float64
+	MaxLoadFactor      float64
+	AverageProbeLength float64
+	MaxProbeLength     int
+}
+
+// stats computes the statistics of the table in O(capacity) time.
+func (t *table[K, V]) stats() HashMapStats {
+	s := HashMapStats{Len: t.size, Capacity: len(t.slots), MaxLoadFactor: t.maxLoad}
+	if len(t.slots) == 0 {
+		return s
+	}
+	total := 0
+	for _, slot := range t.slots {
+		total += int(slot.dist)
+		s.MaxProbeLength = max(s.MaxProbeLength, int(slot.dist))
+	}
+	s.LoadFactor = float64(t.size) / float64(len(t.slots))
+	if t.size > 0 {
+		s.AverageProbeLength = float64(total) / float64(t.size)
+	}
+	return s
+}
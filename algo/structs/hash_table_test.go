@@ -0,0 +1,101 @@
+package structs
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// verifyTable checks the invariants of a Robin Hood table: the capacity is a power of two, every
+// occupied slot records its true distance from the home slot of its key, a slot following an
+// occupied slot is either empty, the home slot of its key, or at most one step further from home,
+// no key is stored twice, and the size is correct.
+func verifyTable[K comparable, V any](t *table[K, V]) error {
+	n := len(t.slots)
+	if n&(n-1) != 0 {
+		return fmt.Errorf("capacity %d is not a power of two", n)
+	}
+	if n > 0 && t.size > t.limit(n) {
+		return fmt.Errorf("%d keys exceed the limit %d of %d slots", t.size, t.limit(n), n)
+	}
+	count := 0
+	for i, slot := range t.slots {
+		if slot.dist == 0 {
+			continue
+		}
+		count++
+		home := int(slot.hash & uint64(n-1))
+		if want := uint32((i-home+n)%n) + 1; slot.dist != want {
+			return fmt.Errorf("slot %d records distance %d but is at distance %d", i, slot.dist, want)
+		}
+		if next := t.slots[(i+1)%n]; next.dist > slot.dist+1 {
+			return fmt.Errorf("slot %d is %d steps from home after a slot %d steps from home", (i+1)%n, next.dist, slot.dist)
+		}
+		if j := t.find(slot.key, slot.hash); j != i {
+			return fmt.Errorf("key %v in slot %d is found in slot %d", slot.key, i, j)
+		}
+	}
+	if count != t.size {
+		return fmt.Errorf("table holds %d keys but size %d", count, t.size)
+	}
+	return nil
+}
+
+func TestTableRandomOperations(t *testing.T) {
+	for _, maxLoad := range []float64{0.5, 0.85, 0.95} {
+		t.Run(fmt.Sprint(maxLoad), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(19))
+			tbl := newTable[int, int](newHasher[int](), 0, maxLoad)
+			model := map[int]int{}
+			for i := 0; i < 20000; i++ {
+				key := rng.Intn(3000)
+				switch rng.Intn(3) {
+				case 0:
+					_, found := model[key]
+					if removed := tbl.remove(key, tbl.hasher.hash(key)); removed != found {
+						t.Fatalf("remove(%d): expected %v but got %v", key, found, removed)
+					}
+					delete(model, key)
+				default:
+					_, found := model[key]
+					if added := tbl.put(key, tbl.hasher.hash(key), i); added == found {
+						t.Fatalf("put(%d): expected added=%v", key, !found)
+					}
+					model[key] = i
+				}
+				if i%1000 == 0 {
+					if err := verifyTable(&tbl); err != nil {
+						t.Fatalf("After %d operations: %v", i, err)
+					}
+				}
+			}
+			for key, value := range model {
+				if got, ok := tbl.get(key, tbl.hasher.hash(key)); got != value || !ok {
+					t.Fatalf("get(%d): expected (%d, true) but got (%d, %v)", key, value, got, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestTableResize(t *testing.T) {
+	tbl := newTable[int, struct{}](newHasher[int](), 100, 0.5)
+	if len(tbl.slots) != 0 || tbl.minCapacity != 256 {
+		t.Fatalf("Expected no slots and a minimum capacity of 256 but got %d and %d", len(tbl.slots), tbl.minCapacity)
+	}
+	for i := 0; i < 1000; i++ {
+		tbl.put(i, tbl.hasher.hash(i), struct{}{})
+	}
+	if len(tbl.slots) != 2048 {
+		t.Errorf("Expected 2048 slots for 1000 keys at load factor 0.5 but got %d", len(tbl.slots))
+	}
+	for i := 0; i < 1000; i++ {
+		tbl.remove(i, tbl.hasher.hash(i))
+	}
+	if len(tbl.slots) != 256 || tbl.size != 0 {
+		t.Errorf("Expected the table to shrink to its minimum capacity of 256 but got %d", len(tbl.slots))
+	}
+	if err := verifyTable(&tbl); err != nil {
+		t.Error(err)
+	}
+}
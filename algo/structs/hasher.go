@@ -0,0 +1,60 @@
+package structs
+
+import (
+	"hash/maphash"
+	"reflect"
+
+	"github.com/ooyeku/algo/algo/base"
+)
+
+// hasher hashes and compares keys of type K for the hash-based containers.
+//
+// If K implements base.Object, keys are hashed with their Hash method and compared with their
+// Equals method, so that distinct objects that are equal by value, such as two *base.Atom holding
+// the same value, are the same key. Otherwise keys are hashed with maphash.Comparable and compared
+// with ==. In both cases the hash is mixed with a random seed chosen when the hasher is created,
+// so hash values differ between processes, and even weak Hash methods spread keys evenly over
+// the buckets of a table.
+type hasher[K comparable] struct {
+	seed   maphash.Seed
+	object bool
+}
+
+// newHasher returns a hasher with a new random seed.
+func newHasher[K comparable]() hasher[K] {
+	return hasher[K]{
+		seed:   maphash.MakeSeed(),
+		object: reflect.TypeFor[K]().Implements(reflect.TypeFor[base.Object]()),
+	}
+}
+
+// hash returns the 64-bit hash of key.
+func (h hasher[K]) hash(key K) uint64 {
+	if h.object {
+		// A nil interface key has no Hash method and falls through to maphash, and so does a nil
+		// pointer key, whose Hash method would most likely dereference it.
+		if o, ok := any(key).(base.Object); ok && !isNilPointer(o) {
+			return maphash.Comparable(h.seed, o.Hash())
+		}
+	}
+	return maphash.Comparable(h.seed, key)
+}
+
+// equal reports whether a and b are the same key. Nil pointer keys are compared with ==, so a
+// nil pointer is only ever equal to a nil pointer of the same type.
+func (h hasher[K]) equal(a, b K) bool {
+	if h.object {
+		oa, okA := any(a).(base.Object)
+		ob, okB := any(b).(base.Object)
+		if okA && okB && !isNilPointer(oa) && !isNilPointer(ob) {
+			return oa.Equals(ob)
+		}
+	}
+	return a == b
+}
+
+// isNilPointer reports whether o holds a nil pointer.
+func isNilPointer(o base.Object) bool {
+	v := reflect.ValueOf(o)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
@@ -0,0 +1,97 @@
+package structs
+
+import "sync/atomic"
+
+// Heap is the interface shared by the mergeable heaps in this package: DaryHeap, PairingHeap,
+// BinomialHeap and FibonacciHeap. They hold values of type T ordered by a cmp function, which
+// returns a negative number if a comes before b, so like PriorityQueue they are min-heaps for
+// cmp.Compare and max-heaps for a cmp function that reverses it. They differ in the time their
+// operations take, so they can be swapped for one another to find the heap that suits an
+// algorithm best:
+//
+//	Operation     DaryHeap         PairingHeap      BinomialHeap   FibonacciHeap
+//	Push          O(log_d N)       O(1)             O(1)*          O(1)
+//	Peek          O(1)             O(1)             O(log N)       O(1)
+//	Pop           O(d log_d N)     O(log N)*        O(log N)       O(log N)*
+//	DecreaseKey   O(log_d N)       O(log N)*        O(log N)       O(1)*
+//	Meld          O(N + M)         O(1)             O(log N)       O(1)
+//
+// The times marked with an asterisk are amortized. The Fibonacci heap has the best bounds, but
+// its constant factors are high; in practice a d-ary heap, whose values sit in one slice, or a
+// pairing heap is often faster, which CompareHeapAlgorithms in package algo measures.
+//
+// Push adds a value and returns a HeapNode, a handle for DecreaseKey. Pop removes and returns the
+// first value and Peek returns it without removing it. DecreaseKey replaces the value of a node
+// with one that comes no later and reports whether it did; it fails if the node does not belong to
+// the heap, for example because it has been popped, or if the new value would come later. Meld
+// moves all values of another heap of the same type into the heap and leaves the other heap
+// empty; the nodes of the other heap stay valid and now belong to the heap. It reports false if
+// the other heap has a different type or is the heap itself. Both heaps should use the same order.
+// Size returns the number of values and IsEmpty reports whether there are none.
+type Heap[T any] interface {
+	Push(value T) HeapNode[T]
+	Pop() (T, bool)
+	Peek() (T, bool)
+	DecreaseKey(node HeapNode[T], value T) bool
+	Meld(other Heap[T]) bool
+	Size() int
+	IsEmpty() bool
+}
+
+var (
+	_ Heap[int] = (*DaryHeap[int])(nil)
+	_ Heap[int] = (*PairingHeap[int])(nil)
+	_ Heap[int] = (*BinomialHeap[int])(nil)
+	_ Heap[int] = (*FibonacciHeap[int])(nil)
+)
+
+// HeapNode is a handle to a value in a Heap, returned by Push. It stays valid while the value
+// moves through the heap and when the heap is melded into another one. Value does not lock the
+// heap, so it must not be called while other goroutines decrease the key of the node.
+type HeapNode[T any] interface {
+	Value() T
+}
+
+// heapTag identifies the heap a node belongs to. Every heap owns a tag and every node points to
+// the tag of the heap it was pushed to. Melding a heap into another links the tag of the melded
+// heap to the tag of the other heap, instead of updating all the nodes, so that pairing and
+// Fibonacci heaps can meld in O(1) time; the melded heap gets a new tag. A node belongs to a heap
+// if following the links from its tag leads to the tag of the heap, and a node that has left its
+// heap points to no tag. Like the find of a union-find structure, following the links shortens
+// them by path halving, so after M melds checking a node takes amortized O(log M) time rather
+// than O(M).
+//
+// Once the links are shared by several heaps, the heaps follow them under different locks, so the
+// links and the tags of the nodes are atomic pointers. A link is set when a melded tag is attached
+// to its new heap and afterwards only replaced, by compare-and-swap, with a tag further along the
+// same chain, so it always leads to the same heap and concurrent checks cannot form a cycle.
+type heapTag struct {
+	next atomic.Pointer[heapTag]
+}
+
+// link attaches the tag t of a melded heap to the tag h of the heap it was melded into.
+func (t *heapTag) link(h *heapTag) {
+	t.next.Store(h)
+}
+
+// find returns the tag at the end of the links from t. On the way it links every other tag to
+// the tag two steps further along, which halves the path for the next check.
+func (t *heapTag) find() *heapTag {
+	for {
+		next := t.next.Load()
+		if next == nil {
+			return t
+		}
+		after := next.next.Load()
+		if after == nil {
+			return next
+		}
+		t.next.CompareAndSwap(next, after)
+		t = after
+	}
+}
+
+// owns reports whether a node pointing to the tag t belongs to the heap that owns the tag h.
+func (h *heapTag) owns(t *heapTag) bool {
+	return t != nil && t.find() == h
+}
@@ -0,0 +1,339 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// heaps lists the Heap implementations with a constructor and a function that checks the
+// structure of a heap built by it, so that every test below runs against all of them.
+var heaps = []struct {
+	name   string
+	new    func(cmp func(a, b int) int) Heap[int]
+	verify func(h Heap[int]) error
+}{
+	{
+		name:   "BinaryHeap",
+		new:    func(cmp func(a, b int) int) Heap[int] { return NewDaryHeap(2, cmp) },
+		verify: func(h Heap[int]) error { return verifyDaryHeap(h.(*DaryHeap[int])) },
+	},
+	{
+		name:   "4aryHeap",
+		new:    func(cmp func(a, b int) int) Heap[int] { return NewDaryHeap(4, cmp) },
+		verify: func(h Heap[int]) error { return verifyDaryHeap(h.(*DaryHeap[int])) },
+	},
+	{
+		name:   "PairingHeap",
+		new:    func(cmp func(a, b int) int) Heap[int] { return NewPairingHeap(cmp) },
+		verify: func(h Heap[int]) error { return verifyPairingHeap(h.(*PairingHeap[int])) },
+	},
+	{
+		name:   "BinomialHeap",
+		new:    func(cmp func(a, b int) int) Heap[int] { return NewBinomialHeap(cmp) },
+		verify: func(h Heap[int]) error { return verifyBinomialHeap(h.(*BinomialHeap[int])) },
+	},
+	{
+		name:   "FibonacciHeap",
+		new:    func(cmp func(a, b int) int) Heap[int] { return NewFibonacciHeap(cmp) },
+		verify: func(h Heap[int]) error { return verifyFibonacciHeap(h.(*FibonacciHeap[int])) },
+	},
+}
+
+// drainHeap pops all values of a heap.
+func drainHeap[T any](h Heap[T]) []T {
+	var values []T
+	for v, ok := h.Pop(); ok; v, ok = h.Pop() {
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestHeap(t *testing.T) {
+	values := []int{5, 3, 8, 1, 9, 2, 7, 3, 6, 4, 0}
+	ascending := slices.Sorted(slices.Values(values))
+	descending := slices.Clone(ascending)
+	slices.Reverse(descending)
+	for _, hp := range heaps {
+		t.Run(hp.name, func(t *testing.T) {
+			for _, order := range []struct {
+				name     string
+				cmp      func(a, b int) int
+				expected []int
+			}{
+				{"Min", cmp.Compare[int], ascending},
+				{"Max", func(a, b int) int { return cmp.Compare(b, a) }, descending},
+			} {
+				h := hp.new(order.cmp)
+				if _, ok := h.Pop(); ok || !h.IsEmpty() {
+					t.Errorf("%s: Expected a new heap to be empty", order.name)
+				}
+				for _, v := range values {
+					h.Push(v)
+				}
+				if err := hp.verify(h); err != nil {
+					t.Fatalf("%s: Invariant violated: %v", order.name, err)
+				}
+				if v, ok := h.Peek(); !ok || v != order.expected[0] || h.Size() != len(values) {
+					t.Errorf("%s: Expected Peek to return (%d, true) but got (%d, %v)", order.name, order.expected[0], v, ok)
+				}
+				if got := drainHeap(h); !slices.Equal(got, order.expected) {
+					t.Errorf("%s: Expected %v but got %v", order.name, order.expected, got)
+				}
+				if _, ok := h.Peek(); ok || h.Size() != 0 {
+					t.Errorf("%s: Expected a drained heap to be empty", order.name)
+				}
+			}
+		})
+	}
+}
+
+func TestHeapDecreaseKey(t *testing.T) {
+	for _, hp := range heaps {
+		t.Run(hp.name, func(t *testing.T) {
+			h := hp.new(cmp.Compare[int])
+			nodes := make([]HeapNode[int], 20)
+			for i := range nodes {
+				nodes[i] = h.Push(100 + i)
+			}
+			h.Pop()
+			h.Pop()
+			if h.DecreaseKey(nodes[0], 0) {
+				t.Errorf("Expected DecreaseKey of a popped node to fail")
+			}
+			if h.DecreaseKey(nodes[10], 200) || nodes[10].Value() != 110 {
+				t.Errorf("Expected DecreaseKey to refuse a value that comes later")
+			}
+			for i, v := range []int{50, 60, 40, 110} {
+				if !h.DecreaseKey(nodes[19-i*3], v) {
+					t.Errorf("DecreaseKey to %d failed", v)
+				}
+				if err := hp.verify(h); err != nil {
+					t.Fatalf("Invariant violated after DecreaseKey to %d: %v", v, err)
+				}
+			}
+			if nodes[13].Value() != 40 {
+				t.Errorf("Expected the node to hold its new value, got %d", nodes[13].Value())
+			}
+			got := drainHeap(h)
+			if got[0] != 40 || got[1] != 50 || got[2] != 60 || !slices.IsSorted(got) || len(got) != 18 {
+				t.Errorf("Unexpected order %v", got)
+			}
+
+			other := hp.new(cmp.Compare[int])
+			foreign := other.Push(1)
+			h.Push(5)
+			if h.DecreaseKey(foreign, 0) || h.DecreaseKey(&PriorityQueueItem[int]{}, 0) {
+				t.Errorf("Expected DecreaseKey of a foreign node to fail")
+			}
+		})
+	}
+}
+
+func TestHeapMeld(t *testing.T) {
+	for _, hp := range heaps {
+		t.Run(hp.name, func(t *testing.T) {
+			h := hp.new(cmp.Compare[int])
+			other := hp.new(cmp.Compare[int])
+			var nodes []HeapNode[int]
+			for i := 0; i < 10; i++ {
+				h.Push(2 * i)
+				nodes = append(nodes, other.Push(2*i+1))
+			}
+			if h.Meld(h) {
+				t.Errorf("Expected melding a heap into itself to fail")
+			}
+			for _, different := range heaps {
+				d := different.new(cmp.Compare[int])
+				if fmt.Sprintf("%T", d) != fmt.Sprintf("%T", h) && h.Meld(d) {
+					t.Errorf("Expected melding a %s to fail", different.name)
+				}
+			}
+			if !h.Meld(other) || !other.IsEmpty() || other.Size() != 0 || h.Size() != 20 {
+				t.Fatalf("Expected Meld to move all values out of the other heap")
+			}
+			if err := hp.verify(h); err != nil {
+				t.Fatalf("Invariant violated after Meld: %v", err)
+			}
+			if other.DecreaseKey(nodes[9], -1) || !h.DecreaseKey(nodes[9], -1) {
+				t.Errorf("Expected a melded node to belong to the heap it was melded into")
+			}
+			other.Push(100)
+			third := hp.new(cmp.Compare[int])
+			third.Meld(h)
+			if !third.DecreaseKey(nodes[5], -2) || h.DecreaseKey(nodes[4], -3) {
+				t.Errorf("Expected nodes to follow their values through repeated melds")
+			}
+			if v, _ := third.Peek(); v != -2 {
+				t.Errorf("Expected -2 first but got %d", v)
+			}
+			if got := drainHeap(third); len(got) != 20 || !slices.IsSorted(got) {
+				t.Errorf("Expected 20 sorted values but got %v", got)
+			}
+			if got := drainHeap(other); !slices.Equal(got, []int{100}) {
+				t.Errorf("Expected the melded heap to be usable, got %v", got)
+			}
+		})
+	}
+}
+
+func TestHeapConcurrentMeldedNodes(t *testing.T) {
+	// A node that has passed through two melds is checked by the heap that owns it and by the
+	// heap it was melded out of at the same time, each under its own lock. Run with -race.
+	for _, hp := range heaps {
+		t.Run(hp.name, func(t *testing.T) {
+			o, h, g := hp.new(cmp.Compare[int]), hp.new(cmp.Compare[int]), hp.new(cmp.Compare[int])
+			var nodes []HeapNode[int]
+			for i := 0; i < 200; i++ {
+				nodes = append(nodes, o.Push(i))
+			}
+			h.Meld(o)
+			g.Meld(h)
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i, n := range nodes {
+					if !g.DecreaseKey(n, -i) {
+						t.Errorf("Expected DecreaseKey on the owning heap to succeed")
+						return
+					}
+				}
+				drainHeap(g)
+			}()
+			go func() {
+				defer wg.Done()
+				for i, n := range nodes {
+					if h.DecreaseKey(n, -i) || o.DecreaseKey(n, -i) {
+						t.Errorf("Expected DecreaseKey on a heap the node was melded out of to fail")
+						return
+					}
+				}
+			}()
+			wg.Wait()
+			if !g.IsEmpty() || !h.IsEmpty() || !o.IsEmpty() {
+				t.Errorf("Expected all heaps to be empty")
+			}
+		})
+	}
+}
+
+func TestHeapTagPathHalving(t *testing.T) {
+	// A chain of 64 melded tags is halved by every check, so a few checks bring the tag of the
+	// first heap next to the tag of the last one.
+	tags := make([]*heapTag, 64)
+	for i := range tags {
+		tags[i] = &heapTag{}
+		if i > 0 {
+			tags[i-1].link(tags[i])
+		}
+	}
+	last := tags[len(tags)-1]
+	steps := func() int {
+		n := 0
+		for tag := tags[0]; tag != last; tag = tag.next.Load() {
+			n++
+		}
+		return n
+	}
+	for want := 63; want > 1; want = (want + 1) / 2 {
+		if got := steps(); got != want {
+			t.Fatalf("Expected the path to take %d steps but it takes %d", want, got)
+		}
+		if !last.owns(tags[0]) || tags[0].owns(last) {
+			t.Fatalf("Expected only the last tag to own the first")
+		}
+	}
+	if got := steps(); got != 1 {
+		t.Errorf("Expected the first tag to be linked to the last but it takes %d steps", got)
+	}
+}
+
+func TestHeapRandomOperations(t *testing.T) {
+	for _, hp := range heaps {
+		t.Run(hp.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(9))
+			h := hp.new(cmp.Compare[int])
+			model := map[HeapNode[int]]int{}
+			for i := 0; i < 4000; i++ {
+				switch op := r.Intn(10); {
+				case op < 4:
+					v := r.Intn(10000)
+					model[h.Push(v)] = v
+				case op < 7:
+					for n, v := range model {
+						nv := v - r.Intn(500)
+						if !h.DecreaseKey(n, nv) {
+							t.Fatalf("DecreaseKey from %d to %d failed", v, nv)
+						}
+						model[n] = nv
+						break
+					}
+				case op < 9:
+					want, ok := 0, false
+					for _, v := range model {
+						if !ok || v < want {
+							want, ok = v, true
+						}
+					}
+					v, popped := h.Pop()
+					if popped != ok || popped && v != want {
+						t.Fatalf("Pop = (%d, %v), want (%d, %v)", v, popped, want, ok)
+					}
+					// The popped node is the one with the popped value that no longer
+					// belongs to the heap, which DecreaseKey to the same value detects.
+					for n, mv := range model {
+						if mv == v {
+							if !h.DecreaseKey(n, mv) {
+								delete(model, n)
+								break
+							}
+						}
+					}
+				default:
+					other := hp.new(cmp.Compare[int])
+					for j := r.Intn(20); j > 0; j-- {
+						v := r.Intn(10000)
+						model[other.Push(v)] = v
+					}
+					h.Meld(other)
+				}
+				if err := hp.verify(h); err != nil {
+					t.Fatalf("Invariant violated after operation %d: %v", i, err)
+				}
+				if h.Size() != len(model) {
+					t.Fatalf("Expected size %d but got %d", len(model), h.Size())
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkHeap runs a workload like that of Dijkstra's algorithm on every heap: it pushes n
+// values, decreases the keys of half of them and pops them all.
+func BenchmarkHeap(b *testing.B) {
+	const n = 1 << 12
+	values := rand.New(rand.NewSource(1)).Perm(n)
+	for _, hp := range heaps {
+		b.Run(hp.name, func(b *testing.B) {
+			b.ReportAllocs()
+			nodes := make([]HeapNode[int], n)
+			for i := 0; i < b.N; i++ {
+				h := hp.new(cmp.Compare[int])
+				for j, v := range values {
+					nodes[j] = h.Push(v)
+				}
+				for j := 0; j < n; j += 2 {
+					h.DecreaseKey(nodes[j], values[j]-n)
+				}
+				for !h.IsEmpty() {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package structs
+
+// lfuEntry is an entry of an LFUCache together with the bucket of its use count.
+type lfuEntry[K comparable, V any] struct {
+	cacheEntry[K, V]
+	bucket *Element[*lfuBucket[K, V]]
+}
+
+// lfuBucket holds the entries of an LFUCache that have been used freq times, most recently used
+// first.
+type lfuBucket[K comparable, V any] struct {
+	freq    int
+	entries *DoublyLinkedList[lfuEntry[K, V]]
+}
+
+// newLFUBucket returns an empty bucket for the given use count.
+func newLFUBucket[K comparable, V any](freq int) *lfuBucket[K, V] {
+	return &lfuBucket[K, V]{freq: freq, entries: NewDoublyLinkedList[lfuEntry[K, V]]()}
+}
+
+// LFUCache is a thread-safe cache with a fixed capacity that evicts the least frequently used
+// entry when a new key does not fit. Among the entries used equally often, the least recently
+// used one is evicted.
+//
+// The cache counts how often every entry has been used: a Put of a new key counts as the first
+// use, and every hit and every Put of a present key adds one. Entries with the same count share a
+// bucket, a DoublyLinkedList ordered by recency, and the buckets form a list ordered by count.
+// A use moves an entry to the bucket of the next count, creating that bucket if needed, and the
+// victim of an eviction is at the back of the first bucket, so every operation takes O(1) time.
+//
+// Counting uses makes the cache resistant to scans, which touch each key only once, and keeps
+// keys that are used steadily over long periods. The counts never decrease, however, so keys
+// that were popular once can stay in the cache after they are no longer used, and a new key has
+// to be used repeatedly to keep its place.
+type LFUCache[K comparable, V any] struct {
+	cacheCore[K, V]
+	index   *HashMap[K, *Element[lfuEntry[K, V]]]
+	buckets *DoublyLinkedList[*lfuBucket[K, V]]
+	len     int
+}
+
+// NewLFUCache returns an empty LFUCache that holds up to capacity entries, which never expire. It
+// panics if capacity is less than 1.
+func NewLFUCache[K comparable, V any](capacity int) *LFUCache[K, V] {
+	return NewLFUCacheWithOptions(capacity, CacheOptions[K, V]{})
+}
+
+// NewLFUCacheWithOptions returns an empty LFUCache that holds up to capacity entries and is
+// configured by opts. It panics if capacity is less than 1.
+func NewLFUCacheWithOptions[K comparable, V any](capacity int, opts CacheOptions[K, V]) *LFUCache[K, V] {
+	return &LFUCache[K, V]{
+		cacheCore: newCacheCore("LFU", capacity, opts),
+		index:     NewHashMapWithOptions[K, *Element[lfuEntry[K, V]]](HashMapOptions{InitialCapacity: capacity}),
+		buckets:   NewDoublyLinkedList[*lfuBucket[K, V]](),
+	}
+}
+
+// Get returns the value associated with the given key and counts a use of the key. The boolean
+// result is false if the key is absent or has expired.
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.unlock()
+	e := c.lookup(key)
+	if e == nil {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.touch(e)
+	return e.Value.value, true
+}
+
+// Peek returns the value associated with the given key without counting a use or the lookup.
+// The boolean result is false if the key is absent or has expired.
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.unlock()
+	if e := c.lookup(key); e != nil {
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put associates the given value with the given key and counts a use of the key. If the key is
+// new and the cache is full, the least frequently used entry is evicted.
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.unlock()
+	if e := c.index.Get(key); e != nil {
+		e.Value.cacheEntry = c.newEntry(key, value)
+		c.touch(e)
+		return
+	}
+	if c.len >= c.capacity {
+		c.evict(c.buckets.Front().Value.entries.Back(), EvictedCapacity)
+	}
+	first := c.buckets.Front()
+	if first == nil || first.Value.freq != 1 {
+		first = c.buckets.PushFront(newLFUBucket[K, V](1))
+	}
+	e := first.Value.entries.PushFront(lfuEntry[K, V]{cacheEntry: c.newEntry(key, value), bucket: first})
+	c.index.Put(key, e)
+	c.len++
+}
+
+// Remove deletes the given key and reports whether it was present. The eviction callback is not
+// called.
+func (c *LFUCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.unlock()
+	e := c.index.Get(key)
+	if e == nil {
+		return false
+	}
+	c.unlink(e)
+	return true
+}
+
+// Len returns the number of entries in the cache, including expired entries that have not been
+// removed yet.
+func (c *LFUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.unlock()
+	return c.len
+}
+
+// Purge removes all entries from the cache without calling the eviction callback. The statistics
+// are kept.
+func (c *LFUCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.unlock()
+	c.buckets.Clear()
+	c.index.Clear()
+	c.len = 0
+}
+
+// PurgeExpired removes all expired entries, calls the eviction callback for them, and returns how
+// many there were. It examines every entry, so it takes O(N) time.
+func (c *LFUCache[K, V]) PurgeExpired() int {
+	c.mu.Lock()
+	defer c.unlock()
+	removed := 0
+	for b := c.buckets.Front(); b != nil; {
+		nextBucket := b.Next()
+		for e := b.Value.entries.Front(); e != nil; {
+			next := e.Next()
+			if c.expired(&e.Value.cacheEntry) {
+				c.evict(e, EvictedExpired)
+				removed++
+			}
+			e = next
+		}
+		b = nextBucket
+	}
+	return removed
+}
+
+// Frequency returns the number of uses counted for the given key, or zero if the key is absent.
+// It does not count a use itself.
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.unlock()
+	if e := c.index.Get(key); e != nil {
+		return e.Value.bucket.Value.freq
+	}
+	return 0
+}
+
+// lookup returns the list element of the given key, or nil if the key is absent. An expired
+// entry is evicted and reported as absent.
+func (c *LFUCache[K, V]) lookup(key K) *Element[lfuEntry[K, V]] {
+	e := c.index.Get(key)
+	if e != nil && c.expired(&e.Value.cacheEntry) {
+		c.evict(e, EvictedExpired)
+		return nil
+	}
+	return e
+}
+
+// touch counts a use of the entry of the given list element by moving it to the front of the
+// bucket for the next count. The entry gets a new element in that bucket, which replaces the old
+// one in the index.
+func (c *LFUCache[K, V]) touch(e *Element[lfuEntry[K, V]]) {
+	b := e.Value.bucket
+	next := b.Next()
+	if next == nil || next.Value.freq != b.Value.freq+1 {
+		next = c.buckets.InsertAfter(newLFUBucket[K, V](b.Value.freq+1), b)
+	}
+	b.Value.entries.Remove(e)
+	e.Value.bucket = next
+	c.index.Put(e.Value.key, next.Value.entries.PushFront(e.Value))
+	if b.Value.entries.IsEmpty() {
+		c.buckets.Remove(b)
+	}
+}
+
+// unlink removes the entry of the given list element, and its bucket if that becomes empty.
+func (c *LFUCache[K, V]) unlink(e *Element[lfuEntry[K, V]]) {
+	b := e.Value.bucket
+	b.Value.entries.Remove(e)
+	if b.Value.entries.IsEmpty() {
+		c.buckets.Remove(b)
+	}
+	c.index.Remove(e.Value.key)
+	c.len--
+}
+
+// evict removes the entry of the given list element for the given reason.
+func (c *LFUCache[K, V]) evict(e *Element[lfuEntry[K, V]], reason EvictionReason) {
+	c.unlink(e)
+	c.evicted(&e.Value.cacheEntry, reason)
+}
@@ -0,0 +1,82 @@
+package structs
+
+import (
+	"fmt"
+	"testing"
+)
+
+// verifyLFUCache checks the buckets of an LFUCache: they are non-empty and ordered by strictly
+// increasing use count, every entry points to its bucket and is indexed by its element, and the
+// length and the capacity are respected.
+func verifyLFUCache[K comparable, V any](c *LFUCache[K, V]) error {
+	count, freq := 0, 0
+	for b := c.buckets.Front(); b != nil; b = b.Next() {
+		if b.Value.freq <= freq {
+			return fmt.Errorf("bucket %d follows bucket %d", b.Value.freq, freq)
+		}
+		freq = b.Value.freq
+		if b.Value.entries.IsEmpty() {
+			return fmt.Errorf("bucket %d is empty", freq)
+		}
+		for e := b.Value.entries.Front(); e != nil; e = e.Next() {
+			if e.Value.bucket != b {
+				return fmt.Errorf("key %v does not point to bucket %d", e.Value.key, freq)
+			}
+			if c.index.Get(e.Value.key) != e {
+				return fmt.Errorf("key %v is not indexed by its element", e.Value.key)
+			}
+			count++
+		}
+	}
+	if count != c.len || c.index.Size() != c.len {
+		return fmt.Errorf("length %d but %d entries and %d indexed keys", c.len, count, c.index.Size())
+	}
+	if c.len > c.capacity {
+		return fmt.Errorf("%d entries exceed the capacity %d", c.len, c.capacity)
+	}
+	return nil
+}
+
+func TestLFUCacheEviction(t *testing.T) {
+	cases := []struct {
+		name    string
+		gets    []int
+		evicted int
+	}{
+		{"LeastFrequent", []int{1, 1, 2, 3, 3}, 2},
+		{"TieBrokenByRecency", []int{3, 1, 2}, 3},
+		{"NoUses", nil, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewLFUCache[int, int](3)
+			for k := 1; k <= 3; k++ {
+				c.Put(k, k)
+			}
+			for _, k := range tc.gets {
+				c.Get(k)
+			}
+			c.Put(4, 4)
+			if _, ok := c.Peek(tc.evicted); ok {
+				t.Errorf("Expected key %d to be evicted", tc.evicted)
+			}
+			if c.Frequency(4) != 1 || c.Len() != 3 {
+				t.Errorf("Expected key 4 to enter with a single use")
+			}
+		})
+	}
+}
+
+func TestLFUCacheFrequency(t *testing.T) {
+	c := NewLFUCache[string, int](2)
+	c.Put("a", 1)
+	c.Get("a")
+	c.Put("a", 2)
+	c.Peek("a")
+	if got := c.Frequency("a"); got != 3 {
+		t.Errorf("Expected 3 uses of a but got %d", got)
+	}
+	if got := c.Frequency("b"); got != 0 {
+		t.Errorf("Expected 0 uses of an absent key but got %d", got)
+	}
+}
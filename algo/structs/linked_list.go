@@ -1,21 +1,24 @@
 package structs
 
-import "sync"
+import (
+	"iter"
+	"sync"
+)
 
 // Node represents a node in a linked list.
-// Each node contains a value of type interface{} and a reference to the next node.
-type Node struct {
-	Value interface{}
-	Next  *Node
+// Each node contains a value of type T and a reference to the next node.
+type Node[T comparable] struct {
+	Value T
+	Next  *Node[T]
 }
 
-// LinkedList represents a thread-safe singly linked list.
+// LinkedList represents a thread-safe singly linked list of values of type T.
 //
 // It provides methods to append, prepend, and remove values from the list.
 // The list can also be queried for its size and whether it is empty.
-type LinkedList struct {
+type LinkedList[T comparable] struct {
 	mu   sync.Mutex
-	head *Node
+	head *Node[T]
 	size int
 }
 
@@ -24,24 +27,24 @@ type LinkedList struct {
 //
 // Example usage:
 //
-//	ll := NewLinkedList()
+//	ll := NewLinkedList[int]()
 //	ll.Append(1)
 //	ll.Prepend(2)
 //	ll.Remove(1)
 //	size := ll.Size()
 //	isEmpty := ll.IsEmpty()
-func NewLinkedList() *LinkedList {
-	return &LinkedList{}
+func NewLinkedList[T comparable]() *LinkedList[T] {
+	return &LinkedList[T]{}
 }
 
 // Append adds a new node with the given value to the end of the linked list. If the
 // linked list is empty, the new node becomes the head. This method is thread-safe and
 // uses a mutex to ensure concurrent access is properly synchronized. The size of the
 // linked list is increased by 1 after appending the node.
-func (ll *LinkedList) Append(value interface{}) {
+func (ll *LinkedList[T]) Append(value T) {
 	ll.mu.Lock()
 	defer ll.mu.Unlock()
-	newNode := &Node{Value: value}
+	newNode := &Node[T]{Value: value}
 	if ll.head == nil {
 		ll.head = newNode
 	} else {
@@ -60,10 +63,10 @@ func (ll *LinkedList) Append(value interface{}) {
 // It then updates the head pointer to point to the new node, effectively making it the new head.
 // Finally, it increments the size of the linked list by one.
 // After the function completes, it releases the lock on the linked list.
-func (ll *LinkedList) Prepend(value interface{}) {
+func (ll *LinkedList[T]) Prepend(value T) {
 	ll.mu.Lock()
 	defer ll.mu.Unlock()
-	newNode := &Node{Value: value, Next: ll.head}
+	newNode := &Node[T]{Value: value, Next: ll.head}
 	ll.head = newNode
 	ll.size++
 }
@@ -75,7 +78,7 @@ func (ll *LinkedList) Prepend(value interface{}) {
 // If such a node is found, it is removed by updating the previous node's Next pointer to skip over it.
 // The size of the linked list is decremented by 1 if a node is removed.
 // This method is safe to use concurrently by multiple goroutines.
-func (ll *LinkedList) Remove(value interface{}) {
+func (ll *LinkedList[T]) Remove(value T) {
 	ll.mu.Lock()
 	defer ll.mu.Unlock()
 	if ll.head == nil {
@@ -98,7 +101,7 @@ func (ll *LinkedList) Remove(value interface{}) {
 
 // Size returns the current size of the linked list.
 // It acquires a lock to ensure thread safety and releases it before returning the size.
-func (ll *LinkedList) Size() int {
+func (ll *LinkedList[T]) Size() int {
 	ll.mu.Lock()
 	defer ll.mu.Unlock()
 	return ll.size
@@ -107,8 +110,41 @@ func (ll *LinkedList) Size() int {
 // IsEmpty returns a boolean value indicating whether the linked list is empty or not.
 // It acquires a lock on the linked list, checks the size of the linked list, and returns true if the size is 0.
 // It releases the lock before returning.
-func (ll *LinkedList) IsEmpty() bool {
+func (ll *LinkedList[T]) IsEmpty() bool {
 	ll.mu.Lock()
 	defer ll.mu.Unlock()
 	return ll.size == 0
 }
+
+// All returns an iterator over the values of the linked list from the head to the tail. The list
+// is locked while the loop runs; see the package documentation.
+func (ll *LinkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ll.mu.Lock()
+		defer ll.mu.Unlock()
+		for current := ll.head; current != nil; current = current.Next {
+			if !yield(current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of the linked list from the tail to the head.
+// Since the nodes only link forward, the loop first collects the nodes, which takes O(N) time
+// and memory before the first value is yielded.
+func (ll *LinkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ll.mu.Lock()
+		defer ll.mu.Unlock()
+		nodes := make([]*Node[T], 0, ll.size)
+		for current := ll.head; current != nil; current = current.Next {
+			nodes = append(nodes, current)
+		}
+		for i := len(nodes) - 1; i >= 0; i-- {
+			if !yield(nodes[i].Value) {
+				return
+			}
+		}
+	}
+}
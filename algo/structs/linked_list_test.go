@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -8,19 +9,19 @@ func TestLinkedList(t *testing.T) {
 	t.Run("LinkedList operations", func(t *testing.T) {
 		tests := []struct {
 			name       string
-			operations func(ll *LinkedList)
+			operations func(ll *LinkedList[int])
 			isEmpty    bool
 			size       int
 		}{
 			{
 				name:       "Initialization",
-				operations: func(ll *LinkedList) {},
+				operations: func(ll *LinkedList[int]) {},
 				isEmpty:    true,
 				size:       0,
 			},
 			{
 				name: "Single append operation",
-				operations: func(ll *LinkedList) {
+				operations: func(ll *LinkedList[int]) {
 					ll.Append(1)
 				},
 				isEmpty: false,
@@ -28,7 +29,7 @@ func TestLinkedList(t *testing.T) {
 			},
 			{
 				name: "Multiple append operations",
-				operations: func(ll *LinkedList) {
+				operations: func(ll *LinkedList[int]) {
 					ll.Append(1)
 					ll.Append(2)
 					ll.Append(3)
@@ -38,7 +39,7 @@ func TestLinkedList(t *testing.T) {
 			},
 			{
 				name: "Single prepend operation",
-				operations: func(ll *LinkedList) {
+				operations: func(ll *LinkedList[int]) {
 					ll.Prepend(1)
 				},
 				isEmpty: false,
@@ -46,7 +47,7 @@ func TestLinkedList(t *testing.T) {
 			},
 			{
 				name: "Multiple prepend operations",
-				operations: func(ll *LinkedList) {
+				operations: func(ll *LinkedList[int]) {
 					ll.Prepend(1)
 					ll.Prepend(2)
 					ll.Prepend(3)
@@ -56,7 +57,7 @@ func TestLinkedList(t *testing.T) {
 			},
 			{
 				name: "Append and prepend operations",
-				operations: func(ll *LinkedList) {
+				operations: func(ll *LinkedList[int]) {
 					ll.Prepend(1)
 					ll.Append(2)
 					ll.Prepend(3)
@@ -66,7 +67,7 @@ func TestLinkedList(t *testing.T) {
 			},
 			{
 				name: "Single remove operation",
-				operations: func(ll *LinkedList) {
+				operations: func(ll *LinkedList[int]) {
 					ll.Append(1)
 					ll.Remove(1)
 				},
@@ -75,7 +76,7 @@ func TestLinkedList(t *testing.T) {
 			},
 			{
 				name: "Multiple remove operations",
-				operations: func(ll *LinkedList) {
+				operations: func(ll *LinkedList[int]) {
 					ll.Append(1)
 					ll.Append(2)
 					ll.Append(3)
@@ -90,7 +91,7 @@ func TestLinkedList(t *testing.T) {
 
 		for _, tc := range tests {
 			t.Run(tc.name, func(t *testing.T) {
-				ll := NewLinkedList()
+				ll := NewLinkedList[int]()
 				tc.operations(ll)
 				if ll.IsEmpty() != tc.isEmpty {
 					t.Errorf("Expected isEmpty to be %v, but got %v", tc.isEmpty, ll.IsEmpty())
@@ -103,3 +104,29 @@ func TestLinkedList(t *testing.T) {
 		}
 	})
 }
+
+func TestLinkedListIterators(t *testing.T) {
+	ll := NewLinkedList[int]()
+	if got := slices.Collect(ll.All()); len(got) != 0 {
+		t.Errorf("Expected no values from an empty list but got %v", got)
+	}
+	ll.Append(2)
+	ll.Append(3)
+	ll.Prepend(1)
+	if got := slices.Collect(ll.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All: expected [1 2 3] but got %v", got)
+	}
+	if got := slices.Collect(ll.Backward()); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Backward: expected [3 2 1] but got %v", got)
+	}
+	var first []int
+	for v := range ll.Backward() {
+		first = append(first, v)
+		if len(first) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(first, []int{3, 2}) || ll.Size() != 3 {
+		t.Errorf("Expected an early break after [3 2] but got %v", first)
+	}
+}
@@ -0,0 +1,120 @@
+package structs
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// msNode is a node of a LockFreeQueue. Its value is set before the node is published and never
+// changes afterwards; next is set once, when the following node is linked.
+type msNode[T any] struct {
+	value T
+	next  atomic.Pointer[msNode[T]]
+}
+
+// LockFreeQueue is a thread-safe first-in-first-out queue holding items of type T that uses no
+// locks. It implements the queue of Michael and Scott: the items form a singly linked list that
+// starts with a sentinel node, head points to the sentinel and tail to the last node or, briefly,
+// to the one before it. Enqueue links a new node after the last one and then swings tail to it;
+// Dequeue swings head to the node after the sentinel, which becomes the new sentinel, and returns
+// its value. Each step is a single atomic compare-and-swap, and a goroutine that finds tail
+// lagging behind advances it before it continues, so no goroutine ever has to wait for another.
+//
+// As with LockFreeStack, there is no lock for a descheduled goroutine to hold, but every Enqueue
+// allocates a node, so whether the queue is faster than Queue depends on the machine and the
+// workload; BenchmarkQueueContention compares the two. The sentinel keeps the last dequeued item
+// reachable until the next Dequeue, since clearing it would race with goroutines that are still
+// reading it.
+type LockFreeQueue[T any] struct {
+	head atomic.Pointer[msNode[T]]
+	tail atomic.Pointer[msNode[T]]
+	size atomic.Int64
+}
+
+// NewLockFreeQueue creates a new empty LockFreeQueue.
+func NewLockFreeQueue[T any]() *LockFreeQueue[T] {
+	q := &LockFreeQueue[T]{}
+	sentinel := &msNode[T]{}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// Enqueue adds an item to the back of the queue.
+func (q *LockFreeQueue[T]) Enqueue(item T) {
+	n := &msNode[T]{value: item}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if next != nil {
+			// Another Enqueue linked a node but has not swung tail yet; help it along.
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.size.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue removes and returns the item at the front of the queue. The boolean result is false if
+// the queue is empty.
+func (q *LockFreeQueue[T]) Dequeue() (T, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+		if head == tail {
+			// The queue is not empty, but tail still points to the sentinel.
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if q.head.CompareAndSwap(head, next) {
+			q.size.Add(-1)
+			return next.value, true
+		}
+	}
+}
+
+// Peek returns the item at the front of the queue without removing it. The boolean result is
+// false if the queue is empty.
+func (q *LockFreeQueue[T]) Peek() (T, bool) {
+	if next := q.head.Load().next.Load(); next != nil {
+		return next.value, true
+	}
+	var zero T
+	return zero, false
+}
+
+// IsEmpty returns true if the queue is empty, false otherwise.
+func (q *LockFreeQueue[T]) IsEmpty() bool {
+	return q.head.Load().next.Load() == nil
+}
+
+// Size returns the number of items in the queue. The count is updated right after an item is
+// enqueued or dequeued, so while other goroutines use the queue it may briefly lag behind, but it
+// is exact whenever the queue is not being modified. An item may be removed before the count
+// reflects its addition, so the count can briefly drop below zero; Size reports zero then.
+func (q *LockFreeQueue[T]) Size() int {
+	return int(max(q.size.Load(), 0))
+}
+
+// All returns an iterator over the items of the queue from the front to the back, which is the
+// order in which Dequeue would return them. The queue is not locked: the loop starts at the items
+// in the queue when it starts, and also yields the items enqueued while it runs, until it reaches
+// the back of the queue. A loop body that enqueues an item for every item it sees never ends.
+func (q *LockFreeQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := q.head.Load().next.Load(); n != nil; n = n.next.Load() {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
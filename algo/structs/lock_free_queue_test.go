@@ -0,0 +1,142 @@
+package structs
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestLockFreeQueue(t *testing.T) {
+	q := NewLockFreeQueue[int]()
+	if _, ok := q.Dequeue(); ok {
+		t.Errorf("Expected Dequeue of an empty queue to report false")
+	}
+	if _, ok := q.Peek(); ok || !q.IsEmpty() || q.Size() != 0 {
+		t.Errorf("Expected a new queue to be empty")
+	}
+	for i := 1; i <= 3; i++ {
+		q.Enqueue(i)
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3] but got %v", got)
+	}
+	if v, ok := q.Peek(); !ok || v != 1 || q.Size() != 3 {
+		t.Errorf("Expected Peek to return (1, true) and keep 3 items, got (%d, %v)", v, ok)
+	}
+	for want := 1; want <= 3; want++ {
+		if v, ok := q.Dequeue(); !ok || v != want {
+			t.Errorf("Dequeue: expected (%d, true) but got (%d, %v)", want, v, ok)
+		}
+	}
+	q.Enqueue(4)
+	if v, ok := q.Dequeue(); !ok || v != 4 || !q.IsEmpty() {
+		t.Errorf("Expected the queue to be reusable after it was emptied, got (%d, %v)", v, ok)
+	}
+}
+
+func TestLockFreeQueueConcurrent(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 5000
+	q := NewLockFreeQueue[int]()
+
+	var produced sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		produced.Add(1)
+		go func(p int) {
+			defer produced.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Enqueue(p*perProducer + i)
+			}
+		}(p)
+	}
+
+	var done sync.WaitGroup
+	done.Add(1)
+	stop := make(chan struct{})
+	go func() {
+		defer done.Done()
+		produced.Wait()
+		close(stop)
+	}()
+
+	results := make([][]int, consumers)
+	var consumed sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consumed.Add(1)
+		go func(c int) {
+			defer consumed.Done()
+			for {
+				v, ok := q.Dequeue()
+				if ok {
+					results[c] = append(results[c], v)
+					continue
+				}
+				select {
+				case <-stop:
+					if q.IsEmpty() {
+						return
+					}
+				default:
+					runtime.Gosched()
+				}
+			}
+		}(c)
+	}
+	consumed.Wait()
+	done.Wait()
+
+	seen := make([]bool, producers*perProducer)
+	for _, got := range results {
+		last := []int{-1, -1, -1, -1}
+		for _, v := range got {
+			if seen[v] {
+				t.Fatalf("Item %d was dequeued twice", v)
+			}
+			seen[v] = true
+			p, i := v/perProducer, v%perProducer
+			if i <= last[p] {
+				t.Fatalf("Items of producer %d were dequeued out of order", p)
+			}
+			last[p] = i
+		}
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("Item %d was never dequeued", v)
+		}
+	}
+	if q.Size() != 0 {
+		t.Errorf("Expected size 0 but got %d", q.Size())
+	}
+}
+
+// BenchmarkQueueContention enqueues and dequeues from many goroutines at once, from one to 64 per
+// processor, on a Queue and a LockFreeQueue.
+func BenchmarkQueueContention(b *testing.B) {
+	for _, perProc := range []int{1, 8, 64} {
+		name := fmt.Sprintf("Goroutines=%d", perProc*runtime.GOMAXPROCS(0))
+		b.Run("Queue/"+name, func(b *testing.B) {
+			q := NewQueue[int]()
+			b.ReportAllocs()
+			b.SetParallelism(perProc)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					q.Enqueue(1)
+					q.Dequeue()
+				}
+			})
+		})
+		b.Run("LockFreeQueue/"+name, func(b *testing.B) {
+			q := NewLockFreeQueue[int]()
+			b.ReportAllocs()
+			b.SetParallelism(perProc)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					q.Enqueue(1)
+					q.Dequeue()
+				}
+			})
+		})
+	}
+}
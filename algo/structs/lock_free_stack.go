@@ -0,0 +1,102 @@
+package structs
+
+import (
+	"iter"
+	"sync/atomic"
+)
+
+// treiberNode is a node of a LockFreeStack. Its fields are set before the node is published and
+// never change afterwards.
+type treiberNode[T any] struct {
+	value T
+	next  *treiberNode[T]
+}
+
+// LockFreeStack is a thread-safe last-in-first-out stack holding items of type T that uses no
+// locks. It implements the stack of Treiber: the items form a singly linked list, and Push and Pop
+// replace the top of the list with a single atomic compare-and-swap, retrying if another goroutine
+// changed the top in between.
+//
+// Since no goroutine ever waits for a lock, a goroutine that is descheduled in the middle of an
+// operation cannot hold up the others, as it can with Stack, which serializes every operation on
+// a mutex. Whether that makes the stack faster depends on the machine and the workload: every
+// Push allocates a node, and all goroutines still compete for the same top pointer, so measure
+// with BenchmarkStackContention before switching. The ABA problem of compare-and-swap based stacks
+// cannot occur, because the garbage collector never reuses a node that a goroutine still refers
+// to.
+//
+// The zero value is an empty LockFreeStack ready to use.
+type LockFreeStack[T any] struct {
+	top  atomic.Pointer[treiberNode[T]]
+	size atomic.Int64
+}
+
+// NewLockFreeStack creates a new empty LockFreeStack.
+func NewLockFreeStack[T any]() *LockFreeStack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Push adds an item to the top of the stack.
+func (s *LockFreeStack[T]) Push(item T) {
+	n := &treiberNode[T]{value: item}
+	for {
+		n.next = s.top.Load()
+		if s.top.CompareAndSwap(n.next, n) {
+			s.size.Add(1)
+			return
+		}
+	}
+}
+
+// Pop removes and returns the top item of the stack. The boolean result is false if the stack is
+// empty.
+func (s *LockFreeStack[T]) Pop() (T, bool) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			var zero T
+			return zero, false
+		}
+		if s.top.CompareAndSwap(top, top.next) {
+			s.size.Add(-1)
+			return top.value, true
+		}
+	}
+}
+
+// Peek returns the top item of the stack without removing it. The boolean result is false if the
+// stack is empty.
+func (s *LockFreeStack[T]) Peek() (T, bool) {
+	if top := s.top.Load(); top != nil {
+		return top.value, true
+	}
+	var zero T
+	return zero, false
+}
+
+// IsEmpty returns true if the stack is empty, false otherwise.
+func (s *LockFreeStack[T]) IsEmpty() bool {
+	return s.top.Load() == nil
+}
+
+// Size returns the number of items in the stack. The count is updated right after an item is
+// pushed or popped, so while other goroutines use the stack it may briefly lag behind, but it is
+// exact whenever the stack is not being modified. An item may be removed before the count
+// reflects its addition, so the count can briefly drop below zero; Size reports zero then.
+func (s *LockFreeStack[T]) Size() int {
+	return int(max(s.size.Load(), 0))
+}
+
+// All returns an iterator over the items of the stack from the top to the bottom, which is the
+// order in which Pop would return them. The stack is not locked: the loop yields the items that
+// were in the stack when it started, whatever other goroutines or the loop body do to the stack
+// meanwhile.
+func (s *LockFreeStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.top.Load(); n != nil; n = n.next {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package structs
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestLockFreeStack(t *testing.T) {
+	var s LockFreeStack[int]
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Expected Pop of an empty stack to report false")
+	}
+	if _, ok := s.Peek(); ok || !s.IsEmpty() || s.Size() != 0 {
+		t.Errorf("Expected the zero value to be an empty stack")
+	}
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Expected [3 2 1] but got %v", got)
+	}
+	if v, ok := s.Peek(); !ok || v != 3 || s.Size() != 3 {
+		t.Errorf("Expected Peek to return (3, true) and keep 3 items, got (%d, %v)", v, ok)
+	}
+	for want := 3; want >= 1; want-- {
+		if v, ok := s.Pop(); !ok || v != want {
+			t.Errorf("Pop: expected (%d, true) but got (%d, %v)", want, v, ok)
+		}
+	}
+	if !NewLockFreeStack[int]().IsEmpty() || !s.IsEmpty() {
+		t.Errorf("Expected the stack to be empty")
+	}
+}
+
+func TestLockFreeStackSnapshot(t *testing.T) {
+	s := NewLockFreeStack[int]()
+	for i := 0; i < 5; i++ {
+		s.Push(i)
+	}
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+		s.Pop()
+		s.Push(v + 10)
+	}
+	if !slices.Equal(got, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("Expected the loop to see the items when it started, got %v", got)
+	}
+}
+
+func TestLockFreeStackConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 5000
+	s := NewLockFreeStack[int]()
+	popped := make([][]int, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				s.Push(g*perGoroutine + i)
+				if i%2 == 1 {
+					for j := 0; j < 2; j++ {
+						if v, ok := s.Pop(); ok {
+							popped[g] = append(popped[g], v)
+						}
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	rest := slices.Collect(s.All())
+	if s.Size() != len(rest) {
+		t.Errorf("Expected size %d but got %d", len(rest), s.Size())
+	}
+	all := slices.Concat(append(popped, rest)...)
+	slices.Sort(all)
+	for i, v := range all {
+		if v != i {
+			t.Fatalf("Expected every pushed item exactly once, first difference at %d: %d", i, v)
+		}
+	}
+	if len(all) != goroutines*perGoroutine {
+		t.Errorf("Expected %d items but got %d", goroutines*perGoroutine, len(all))
+	}
+}
+
+// BenchmarkStackContention pushes and pops from many goroutines at once, from one to 64 per
+// processor, on a Stack and a LockFreeStack.
+func BenchmarkStackContention(b *testing.B) {
+	for _, perProc := range []int{1, 8, 64} {
+		name := fmt.Sprintf("Goroutines=%d", perProc*runtime.GOMAXPROCS(0))
+		b.Run("Stack/"+name, func(b *testing.B) {
+			s := NewStack[int]()
+			b.ReportAllocs()
+			b.SetParallelism(perProc)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					s.Push(1)
+					s.Pop()
+				}
+			})
+		})
+		b.Run("LockFreeStack/"+name, func(b *testing.B) {
+			s := NewLockFreeStack[int]()
+			b.ReportAllocs()
+			b.SetParallelism(perProc)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					s.Push(1)
+					s.Pop()
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package structs
+
+// LRUCache is a thread-safe cache with a fixed capacity that evicts the least recently used entry
+// when a new key does not fit.
+//
+// The entries are kept in a DoublyLinkedList ordered by the time of their last use, most recent
+// first, and a HashMap maps every key to its element in the list. A hit moves the element to the
+// front and an eviction removes the element at the back, so Get, Put and Remove take O(1) time.
+// Recency is a good predictor of future use for most workloads, but a single scan over more keys
+// than the cache holds flushes all of its entries; LFUCache and ARCCache resist such scans.
+type LRUCache[K comparable, V any] struct {
+	cacheCore[K, V]
+	index *HashMap[K, *Element[cacheEntry[K, V]]]
+	list  *DoublyLinkedList[cacheEntry[K, V]]
+}
+
+// NewLRUCache returns an empty LRUCache that holds up to capacity entries, which never expire. It
+// panics if capacity is less than 1.
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	return NewLRUCacheWithOptions(capacity, CacheOptions[K, V]{})
+}
+
+// NewLRUCacheWithOptions returns an empty LRUCache that holds up to capacity entries and is
+// configured by opts. It panics if capacity is less than 1.
+func NewLRUCacheWithOptions[K comparable, V any](capacity int, opts CacheOptions[K, V]) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		cacheCore: newCacheCore("LRU", capacity, opts),
+		index:     NewHashMapWithOptions[K, *Element[cacheEntry[K, V]]](HashMapOptions{InitialCapacity: capacity}),
+		list:      NewDoublyLinkedList[cacheEntry[K, V]](),
+	}
+}
+
+// Get returns the value associated with the given key and marks the key as the most recently
+// used one. The boolean result is false if the key is absent or has expired.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.unlock()
+	e := c.lookup(key)
+	if e == nil {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.list.MoveToFront(e)
+	return e.Value.value, true
+}
+
+// Peek returns the value associated with the given key without marking it as used or counting
+// the lookup. The boolean result is false if the key is absent or has expired.
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.unlock()
+	if e := c.lookup(key); e != nil {
+		return e.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Put associates the given value with the given key and marks the key as the most recently used
+// one. If the key is new and the cache is full, the least recently used entry is evicted.
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.unlock()
+	if e := c.index.Get(key); e != nil {
+		e.Value = c.newEntry(key, value)
+		c.list.MoveToFront(e)
+		return
+	}
+	if c.list.Size() >= c.capacity {
+		c.evict(c.list.Back(), EvictedCapacity)
+	}
+	c.index.Put(key, c.list.PushFront(c.newEntry(key, value)))
+}
+
+// Remove deletes the given key and reports whether it was present. The eviction callback is not
+// called.
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.unlock()
+	e := c.index.Get(key)
+	if e == nil {
+		return false
+	}
+	c.list.Remove(e)
+	c.index.Remove(key)
+	return true
+}
+
+// Len returns the number of entries in the cache, including expired entries that have not been
+// removed yet.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.unlock()
+	return c.list.Size()
+}
+
+// Purge removes all entries from the cache without calling the eviction callback. The statistics
+// are kept.
+func (c *LRUCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.unlock()
+	c.list.Clear()
+	c.index.Clear()
+}
+
+// PurgeExpired removes all expired entries, calls the eviction callback for them, and returns how
+// many there were. It examines every entry, so it takes O(N) time.
+func (c *LRUCache[K, V]) PurgeExpired() int {
+	c.mu.Lock()
+	defer c.unlock()
+	removed := 0
+	for e := c.list.Front(); e != nil; {
+		next := e.Next()
+		if c.expired(&e.Value) {
+			c.evict(e, EvictedExpired)
+			removed++
+		}
+		e = next
+	}
+	return removed
+}
+
+// lookup returns the list element of the given key, or nil if the key is absent. An expired
+// entry is evicted and reported as absent.
+func (c *LRUCache[K, V]) lookup(key K) *Element[cacheEntry[K, V]] {
+	e := c.index.Get(key)
+	if e != nil && c.expired(&e.Value) {
+		c.evict(e, EvictedExpired)
+		return nil
+	}
+	return e
+}
+
+// evict removes the entry of the given list element for the given reason.
+func (c *LRUCache[K, V]) evict(e *Element[cacheEntry[K, V]], reason EvictionReason) {
+	c.list.Remove(e)
+	c.index.Remove(e.Value.key)
+	c.evicted(&e.Value, reason)
+}
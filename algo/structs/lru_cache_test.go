@@ -0,0 +1,141 @@
+package structs
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// lruKeys returns the keys of an LRUCache from the most to the least recently used.
+func lruKeys[K comparable, V any](c *LRUCache[K, V]) []K {
+	keys := make([]K, 0, c.list.Size())
+	for e := range c.list.All() {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// verifyLRUCache checks that the list and the index of an LRUCache hold the same keys and that
+// the cache does not exceed its capacity.
+func verifyLRUCache[K comparable, V any](c *LRUCache[K, V]) error {
+	if err := verifyDoublyLinkedList(c.list); err != nil {
+		return err
+	}
+	if c.list.Size() > c.capacity {
+		return fmt.Errorf("%d entries exceed the capacity %d", c.list.Size(), c.capacity)
+	}
+	if c.index.Size() != c.list.Size() {
+		return fmt.Errorf("index holds %d keys but the list %d", c.index.Size(), c.list.Size())
+	}
+	for e := c.list.Front(); e != nil; e = e.Next() {
+		if c.index.Get(e.Value.key) != e {
+			return fmt.Errorf("key %v is not indexed by its element", e.Value.key)
+		}
+	}
+	return nil
+}
+
+func TestLRUCacheOrder(t *testing.T) {
+	cases := []struct {
+		name     string
+		ops      func(c *LRUCache[int, int])
+		expected []int
+	}{
+		{
+			name: "InsertionOrder",
+			ops: func(c *LRUCache[int, int]) {
+				c.Put(1, 1)
+				c.Put(2, 2)
+				c.Put(3, 3)
+			},
+			expected: []int{3, 2, 1},
+		},
+		{
+			name: "GetMovesToFront",
+			ops: func(c *LRUCache[int, int]) {
+				c.Put(1, 1)
+				c.Put(2, 2)
+				c.Put(3, 3)
+				c.Get(1)
+				c.Put(4, 4)
+			},
+			expected: []int{4, 1, 3},
+		},
+		{
+			name: "PutMovesToFront",
+			ops: func(c *LRUCache[int, int]) {
+				c.Put(1, 1)
+				c.Put(2, 2)
+				c.Put(3, 3)
+				c.Put(1, 10)
+				c.Put(4, 4)
+			},
+			expected: []int{4, 1, 3},
+		},
+		{
+			name: "PeekDoesNotMove",
+			ops: func(c *LRUCache[int, int]) {
+				c.Put(1, 1)
+				c.Put(2, 2)
+				c.Put(3, 3)
+				c.Peek(1)
+				c.Put(4, 4)
+			},
+			expected: []int{4, 3, 2},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewLRUCache[int, int](3)
+			tc.ops(c)
+			if got := lruKeys(c); !slices.Equal(got, tc.expected) {
+				t.Errorf("Expected keys %v but got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestLRUCacheRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	c := NewLRUCache[int, int](16)
+	// model holds the keys from the most to the least recently used.
+	var model []int
+	touch := func(k int) {
+		if i := slices.Index(model, k); i >= 0 {
+			model = slices.Delete(model, i, i+1)
+		}
+		model = slices.Insert(model, 0, k)
+	}
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(40)
+		switch r.Intn(3) {
+		case 0:
+			c.Put(k, k)
+			touch(k)
+			if len(model) > 16 {
+				model = model[:16]
+			}
+		case 1:
+			if c.Remove(k) != slices.Contains(model, k) {
+				t.Fatalf("Remove(%d) disagrees with the model", k)
+			}
+			if i := slices.Index(model, k); i >= 0 {
+				model = slices.Delete(model, i, i+1)
+			}
+		default:
+			if _, ok := c.Get(k); ok != slices.Contains(model, k) {
+				t.Fatalf("Get(%d) disagrees with the model", k)
+			}
+			if slices.Contains(model, k) {
+				touch(k)
+			}
+		}
+		if err := verifyLRUCache(c); err != nil {
+			t.Fatalf("Invariant violated after operation %d: %v", i, err)
+		}
+		if got := lruKeys(c); !slices.Equal(got, model) {
+			t.Fatalf("Expected keys %v but got %v", model, got)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package structs
+
+// OrderedSet is the interface shared by the ordered collections in this package: RedBlackTree,
+// AVLTree, Treap, SplayTree and SkipList. Values are kept sorted according to the cmp function
+// the collection was created with, and two values are equal when cmp returns zero for them.
+// Insert adds a value even if an equal value is already present, so every implementation
+// behaves like a sorted multiset and the implementations can be swapped for one another.
+//
+// Insert adds a value. Delete removes one value equal to the given value and reports whether
+// one was found. Search reports whether an equal value is present. Len returns the number of
+// values. Min and Max return the smallest and largest value. Floor returns the largest value
+// less than or equal to the given value and Ceiling the smallest value greater than or equal
+// to it. The boolean results of Min, Max, Floor and Ceiling are false if there is no such
+// value. InOrder calls a function for every value in ascending order.
+type OrderedSet[T any] interface {
+	Insert(value T)
+	Delete(value T) bool
+	Search(value T) bool
+	Len() int
+	Min() (T, bool)
+	Max() (T, bool)
+	Floor(value T) (T, bool)
+	Ceiling(value T) (T, bool)
+	InOrder(f func(value T))
+}
+
+var _ OrderedSet[int] = (*RedBlackTree[int])(nil)
@@ -0,0 +1,266 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// orderedSets lists a constructor for every OrderedSet implementation, so that the tests and
+// benchmarks below run against all of them.
+var orderedSets = []struct {
+	name string
+	new  func(cmp func(a, b int) int) OrderedSet[int]
+}{
+	{"RedBlackTree", func(cmp func(a, b int) int) OrderedSet[int] { return NewRedBlackTree(cmp) }},
+	{"AVLTree", func(cmp func(a, b int) int) OrderedSet[int] { return NewAVLTree(cmp) }},
+	{"Treap", func(cmp func(a, b int) int) OrderedSet[int] { return NewTreap(cmp) }},
+	{"SplayTree", func(cmp func(a, b int) int) OrderedSet[int] { return NewSplayTree(cmp) }},
+	{"SkipList", func(cmp func(a, b int) int) OrderedSet[int] { return NewSkipList(cmp) }},
+	{"BTree2", func(cmp func(a, b int) int) OrderedSet[int] { return NewBTree[int, struct{}](2, cmp) }},
+	{"BTree32", func(cmp func(a, b int) int) OrderedSet[int] { return NewBTree[int, struct{}](32, cmp) }},
+}
+
+// collect returns the values of set in the order InOrder visits them.
+func collect[T any](set OrderedSet[T]) []T {
+	values := []T{}
+	set.InOrder(func(value T) {
+		values = append(values, value)
+	})
+	return values
+}
+
+func TestOrderedSetEmpty(t *testing.T) {
+	for _, impl := range orderedSets {
+		t.Run(impl.name, func(t *testing.T) {
+			set := impl.new(cmp.Compare[int])
+			if set.Len() != 0 || set.Search(1) || set.Delete(1) {
+				t.Errorf("Expected empty set")
+			}
+			if _, ok := set.Min(); ok {
+				t.Errorf("Expected Min of empty set to fail")
+			}
+			if _, ok := set.Max(); ok {
+				t.Errorf("Expected Max of empty set to fail")
+			}
+			if _, ok := set.Floor(1); ok {
+				t.Errorf("Expected Floor of empty set to fail")
+			}
+			if _, ok := set.Ceiling(1); ok {
+				t.Errorf("Expected Ceiling of empty set to fail")
+			}
+			if values := collect(set); len(values) != 0 {
+				t.Errorf("Expected no values but got %v", values)
+			}
+		})
+	}
+}
+
+func TestOrderedSetNavigation(t *testing.T) {
+	tt := []struct {
+		value   int
+		floor   int
+		floorOK bool
+		ceil    int
+		ceilOK  bool
+	}{
+		{5, 0, false, 10, true},
+		{10, 10, true, 10, true},
+		{15, 10, true, 20, true},
+		{20, 20, true, 20, true},
+		{45, 40, true, 0, false},
+	}
+
+	for _, impl := range orderedSets {
+		t.Run(impl.name, func(t *testing.T) {
+			set := impl.new(cmp.Compare[int])
+			for _, v := range []int{30, 10, 40, 20, 20} {
+				set.Insert(v)
+			}
+			if v, ok := set.Min(); v != 10 || !ok {
+				t.Errorf("Min: expected (10, true) but got (%d, %v)", v, ok)
+			}
+			if v, ok := set.Max(); v != 40 || !ok {
+				t.Errorf("Max: expected (40, true) but got (%d, %v)", v, ok)
+			}
+			for _, tc := range tt {
+				if v, ok := set.Floor(tc.value); v != tc.floor || ok != tc.floorOK {
+					t.Errorf("Floor(%d): expected (%d, %v) but got (%d, %v)", tc.value, tc.floor, tc.floorOK, v, ok)
+				}
+				if v, ok := set.Ceiling(tc.value); v != tc.ceil || ok != tc.ceilOK {
+					t.Errorf("Ceiling(%d): expected (%d, %v) but got (%d, %v)", tc.value, tc.ceil, tc.ceilOK, v, ok)
+				}
+			}
+		})
+	}
+}
+
+// TestOrderedSetRandomOperations runs the same random mix of operations, with many duplicates,
+// against every implementation and a sorted slice, and compares the results after every step.
+func TestOrderedSetRandomOperations(t *testing.T) {
+	for _, impl := range orderedSets {
+		t.Run(impl.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(42))
+			set := impl.new(cmp.Compare[int])
+			var model []int
+			for i := 0; i < 3000; i++ {
+				value := rng.Intn(200)
+				pos := sort.SearchInts(model, value)
+				found := pos < len(model) && model[pos] == value
+				switch rng.Intn(4) {
+				case 0, 1:
+					set.Insert(value)
+					model = append(model[:pos], append([]int{value}, model[pos:]...)...)
+				case 2:
+					if deleted := set.Delete(value); deleted != found {
+						t.Fatalf("Delete(%d): expected %v but got %v", value, found, deleted)
+					}
+					if found {
+						model = append(model[:pos], model[pos+1:]...)
+					}
+				case 3:
+					if got := set.Search(value); got != found {
+						t.Fatalf("Search(%d): expected %v but got %v", value, found, got)
+					}
+				}
+				if set.Len() != len(model) {
+					t.Fatalf("Expected length %d but got %d", len(model), set.Len())
+				}
+			}
+			if got := collect(set); fmt.Sprint(got) != fmt.Sprint(model) {
+				t.Errorf("Expected values %v but got %v", model, got)
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSetInsert(b *testing.B) {
+	for _, impl := range orderedSets {
+		b.Run(impl.name, func(b *testing.B) {
+			values := rand.New(rand.NewSource(1)).Perm(b.N)
+			set := impl.new(cmp.Compare[int])
+			b.ResetTimer()
+			for _, v := range values {
+				set.Insert(v)
+			}
+		})
+	}
+}
+
+// benchmarkOrderedSetSearch measures searches in a set of 100000 random values, with the
+// queries drawn by next.
+func benchmarkOrderedSetSearch(b *testing.B, next func(rng *rand.Rand) int) {
+	const n = 100000
+	for _, impl := range orderedSets {
+		b.Run(impl.name, func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			set := impl.new(cmp.Compare[int])
+			for _, v := range rng.Perm(n) {
+				set.Insert(v)
+			}
+			queries := make([]int, 1024)
+			for i := range queries {
+				queries[i] = next(rng)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				set.Search(queries[i%len(queries)])
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedSetSearchUniform(b *testing.B) {
+	benchmarkOrderedSetSearch(b, func(rng *rand.Rand) int {
+		return rng.Intn(100000)
+	})
+}
+
+// BenchmarkOrderedSetSearchSkewed queries a few hot values most of the time, which is the
+// workload splay trees are designed for.
+func BenchmarkOrderedSetSearchSkewed(b *testing.B) {
+	benchmarkOrderedSetSearch(b, func(rng *rand.Rand) int {
+		if rng.Intn(10) < 9 {
+			return rng.Intn(16)
+		}
+		return rng.Intn(100000)
+	})
+}
+
+func BenchmarkOrderedSetDelete(b *testing.B) {
+	for _, impl := range orderedSets {
+		b.Run(impl.name, func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			set := impl.new(cmp.Compare[int])
+			for _, v := range rng.Perm(b.N) {
+				set.Insert(v)
+			}
+			values := rng.Perm(b.N)
+			b.ResetTimer()
+			for _, v := range values {
+				set.Delete(v)
+			}
+		})
+	}
+}
+
+// iterableSet is implemented by the OrderedSet implementations that iterate over plain values.
+type iterableSet interface {
+	OrderedSet[int]
+	All() iter.Seq[int]
+	Backward() iter.Seq[int]
+	Range(lo, hi int) iter.Seq[int]
+}
+
+func TestOrderedSetIterators(t *testing.T) {
+	for _, impl := range orderedSets {
+		set, ok := impl.new(cmp.Compare[int]).(iterableSet)
+		if !ok {
+			continue
+		}
+		t.Run(impl.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(9))
+			var model []int
+			for i := 0; i < 500; i++ {
+				v := rng.Intn(100)
+				set.Insert(v)
+				model = append(model, v)
+			}
+			slices.Sort(model)
+
+			if got := slices.Collect(set.All()); !slices.Equal(got, model) {
+				t.Errorf("All: expected %v but got %v", model, got)
+			}
+			reversed := slices.Clone(model)
+			slices.Reverse(reversed)
+			if got := slices.Collect(set.Backward()); !slices.Equal(got, reversed) {
+				t.Errorf("Backward: expected %v but got %v", reversed, got)
+			}
+			for _, r := range [][2]int{{0, 100}, {10, 20}, {-5, 3}, {95, 200}, {50, 50}, {60, 40}} {
+				var expected []int
+				for _, v := range model {
+					if v >= r[0] && v < r[1] {
+						expected = append(expected, v)
+					}
+				}
+				if got := slices.Collect(set.Range(r[0], r[1])); !slices.Equal(got, expected) {
+					t.Errorf("Range(%d, %d): expected %v but got %v", r[0], r[1], expected, got)
+				}
+			}
+
+			// Breaking out of a loop releases the lock, so the set can be used afterwards.
+			for v := range set.All() {
+				if v > 50 {
+					break
+				}
+			}
+			if set.Len() != len(model) {
+				t.Errorf("Expected length %d but got %d", len(model), set.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package structs
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// pairingNode is a node of a PairingHeap. The children of a node form a doubly linked list that
+// starts at child; prev points to the previous sibling, or to the parent for the first child.
+type pairingNode[T any] struct {
+	value             T
+	child, next, prev *pairingNode[T]
+	tag               atomic.Pointer[heapTag]
+}
+
+// Value returns the value of the node.
+func (n *pairingNode[T]) Value() T {
+	return n.value
+}
+
+// PairingHeap is a thread-safe pairing heap of values of type T ordered by a cmp function, as
+// described by Fredman, Sedgewick, Sleator and Tarjan. It is a single tree in which every node
+// comes no later than its children, and it is restructured lazily: Push and Meld link the new
+// tree under the root, or the root under it, in O(1) time, DecreaseKey cuts the node out of the
+// tree and links it with the root, and only Pop does real work, pairing up the children of the
+// root from left to right and then linking the pairs from right to left into a new tree.
+//
+// The pairing heap is simple and has small constant factors, so it often beats the Fibonacci
+// heap, whose bounds it nearly matches, and binary heaps in algorithms that decrease keys often.
+// See Heap for the operations and their running times.
+type PairingHeap[T any] struct {
+	mu   sync.Mutex
+	root *pairingNode[T]
+	size int
+	cmp  func(a, b T) int
+	tag  *heapTag
+}
+
+// NewPairingHeap creates a new empty PairingHeap ordered by the given cmp function.
+func NewPairingHeap[T any](cmp func(a, b T) int) *PairingHeap[T] {
+	return &PairingHeap[T]{cmp: cmp, tag: &heapTag{}}
+}
+
+// Push adds a value to the heap and returns its node.
+func (h *PairingHeap[T]) Push(value T) HeapNode[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := &pairingNode[T]{value: value}
+	n.tag.Store(h.tag)
+	h.root = h.link(h.root, n)
+	h.size++
+	return n
+}
+
+// Pop removes and returns the first value of the heap. The boolean result is false if the heap is
+// empty.
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	r := h.root
+	h.root = h.mergePairs(r.child)
+	h.size--
+	r.child = nil
+	r.tag.Store(nil)
+	return r.value, true
+}
+
+// Peek returns the first value of the heap without removing it. The boolean result is false if the
+// heap is empty.
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// DecreaseKey replaces the value of the given node with one that comes no later. Unless the node
+// is the root, it is cut out of the tree together with its subtree and linked with the root. It
+// returns false, and changes nothing, if the node does not belong to the heap or the new value
+// would come after the current one.
+func (h *PairingHeap[T]) DecreaseKey(node HeapNode[T], value T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n, ok := node.(*pairingNode[T])
+	if !ok || !h.tag.owns(n.tag.Load()) || h.cmp(value, n.value) > 0 {
+		return false
+	}
+	n.value = value
+	if n == h.root {
+		return true
+	}
+	if n.prev.child == n {
+		n.prev.child = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+	n.next, n.prev = nil, nil
+	h.root = h.link(h.root, n)
+	return true
+}
+
+// Meld moves all values of other, which must be a *PairingHeap, into the heap and leaves other
+// empty, in O(1) time. It returns false if other is not a *PairingHeap or is the heap itself.
+//
+// The two heaps are never locked together; see the package documentation.
+func (h *PairingHeap[T]) Meld(other Heap[T]) bool {
+	o, ok := other.(*PairingHeap[T])
+	if !ok || o == h {
+		return false
+	}
+	o.mu.Lock()
+	root, size, tag := o.root, o.size, o.tag
+	o.root, o.size, o.tag = nil, 0, &heapTag{}
+	o.mu.Unlock()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tag.link(h.tag)
+	h.root = h.link(h.root, root)
+	h.size += size
+	return true
+}
+
+// Size returns the number of values in the heap.
+func (h *PairingHeap[T]) Size() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.size
+}
+
+// IsEmpty returns true if the heap holds no values, false otherwise.
+func (h *PairingHeap[T]) IsEmpty() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.root == nil
+}
+
+// link links two trees, either of which may be nil, and returns the root of the result: the root
+// that comes later becomes the first child of the other. Both roots must have no siblings.
+func (h *PairingHeap[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+	b.prev = a
+	b.next = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs links the list of siblings starting at first into one tree and returns its root. The
+// first pass links the siblings in pairs from left to right and pushes the results on a stack,
+// threaded through their next fields; the second pass pops them, from right to left, and links
+// each with the tree built so far.
+func (h *PairingHeap[T]) mergePairs(first *pairingNode[T]) *pairingNode[T] {
+	var stack *pairingNode[T]
+	for first != nil {
+		a, b := first, first.next
+		first = nil
+		if b != nil {
+			first = b.next
+			b.next, b.prev = nil, nil
+		}
+		a.next, a.prev = nil, nil
+		pair := h.link(a, b)
+		pair.next = stack
+		stack = pair
+	}
+	var root *pairingNode[T]
+	for stack != nil {
+		next := stack.next
+		stack.next = nil
+		root = h.link(root, stack)
+		stack = next
+	}
+	return root
+}
@@ -0,0 +1,75 @@
+package structs
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// verifyPairingHeap checks that no node of a PairingHeap comes before its parent, that the sibling
+// links agree in both directions, that every node belongs to the heap and that the size is right.
+func verifyPairingHeap[T any](h *PairingHeap[T]) error {
+	if h.root == nil {
+		if h.size != 0 {
+			return fmt.Errorf("empty heap has size %d", h.size)
+		}
+		return nil
+	}
+	if h.root.prev != nil || h.root.next != nil {
+		return errors.New("the root has a parent or siblings")
+	}
+	count := 0
+	var walk func(n *pairingNode[T]) error
+	walk = func(n *pairingNode[T]) error {
+		count++
+		if !h.tag.owns(n.tag.Load()) {
+			return errors.New("node belongs to another heap")
+		}
+		prev := n
+		for c := n.child; c != nil; prev, c = c, c.next {
+			if c.prev != prev {
+				return errors.New("broken backward link between siblings")
+			}
+			if h.cmp(c.value, n.value) < 0 {
+				return errors.New("node comes before its parent")
+			}
+			if err := walk(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(h.root); err != nil {
+		return err
+	}
+	if count != h.size {
+		return fmt.Errorf("heap holds %d nodes but size %d", count, h.size)
+	}
+	return nil
+}
+
+func TestPairingHeapDecreaseKeyCuts(t *testing.T) {
+	h := NewPairingHeap(cmp.Compare[int])
+	nodes := make([]HeapNode[int], 16)
+	for i := range nodes {
+		nodes[i] = h.Push(i)
+	}
+	h.Pop()
+	// The root now has children with subtrees; cutting the first child, a later sibling and a
+	// node deeper down exercises every case of unlinking a node.
+	for _, i := range []int{1, 15, 8, 3} {
+		if !h.DecreaseKey(nodes[i], -i) {
+			t.Fatalf("DecreaseKey of %d failed", i)
+		}
+		if err := verifyPairingHeap(h); err != nil {
+			t.Fatalf("Invariant violated after decreasing %d: %v", i, err)
+		}
+		if v, _ := h.Peek(); v != -i && v > -i {
+			t.Errorf("Expected %d or less first but got %d", -i, v)
+		}
+	}
+	if v, _ := h.Pop(); v != -15 {
+		t.Errorf("Expected -15 first but got %d", v)
+	}
+}
@@ -0,0 +1,279 @@
+package structs
+
+import (
+	"iter"
+	"math/bits"
+)
+
+const (
+	// hamtBits is the number of hash bits consumed by each level of a PersistentMap.
+	hamtBits = 5
+	// hamtMask selects the hash bits of one level.
+	hamtMask = 1<<hamtBits - 1
+)
+
+// hamtEntry is a slot of a hamtNode. It holds either a key-value pair together with the hash of
+// the key, or, if child is not nil, a subtree for all keys whose hashes share the bits consumed
+// so far.
+type hamtEntry[K comparable, V any] struct {
+	key   K
+	value V
+	hash  uint64
+	child *hamtNode[K, V]
+}
+
+// hamtNode is a node of a hash array mapped trie. Bit i of bitmap is set if the node has an entry
+// for the hash bits i at its level, and the entries are stored densely in the order of their
+// bits, so entry i is at position popcount(bitmap & (1<<i - 1)). Below the last level, where all
+// hash bits are consumed, a node is a collision node that holds all of its entries in a plain
+// list and does not use the bitmap. Nodes are never modified once they are shared.
+type hamtNode[K comparable, V any] struct {
+	bitmap  uint32
+	entries []hamtEntry[K, V]
+}
+
+// PersistentMap is an immutable hash map from keys of type K to values of type V, implemented as
+// a hash array mapped trie (HAMT).
+//
+// The trie consumes the 64-bit hash of a key five bits at a time, so every node has up to 32
+// children and a lookup visits at most 13 nodes, in practice about log32 N. Each node stores a
+// bitmap of its occupied slots and only allocates the slots in use, which keeps the trie compact.
+// Keys that implement base.Object are hashed with their Hash method and compared with their Equals
+// method; all other keys are hashed by value and compared with ==.
+//
+// Put and Delete do not modify the map they are called on but return a new version. Only the
+// nodes on the path to the changed key are copied, and all other nodes are shared with the old
+// version, so every version stays valid and unchanged for as long as it is referenced. Since no
+// version is ever modified, a PersistentMap needs no lock and can be read from any number of
+// goroutines at once.
+type PersistentMap[K comparable, V any] struct {
+	root   *hamtNode[K, V]
+	hasher hasher[K]
+	size   int
+}
+
+// NewPersistentMap returns an empty PersistentMap. All versions derived from it share its hash
+// seed.
+func NewPersistentMap[K comparable, V any]() *PersistentMap[K, V] {
+	return &PersistentMap[K, V]{
+		root:   &hamtNode[K, V]{},
+		hasher: newHasher[K](),
+	}
+}
+
+// slot returns the bit of the given hash at the level that starts at shift, and the position its
+// entry has or would have in node.
+func (n *hamtNode[K, V]) slot(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// Get returns the value associated with the given key. The boolean result is false if the key is
+// not present, in which case the zero value of V is returned.
+func (m *PersistentMap[K, V]) Get(key K) (V, bool) {
+	hash := m.hasher.hash(key)
+	node := m.root
+	for shift := uint(0); ; shift += hamtBits {
+		if shift >= 64 {
+			for _, e := range node.entries {
+				if m.hasher.equal(e.key, key) {
+					return e.value, true
+				}
+			}
+			break
+		}
+		bit, pos := node.slot(hash, shift)
+		if node.bitmap&bit == 0 {
+			break
+		}
+		e := &node.entries[pos]
+		if e.child == nil {
+			if e.hash == hash && m.hasher.equal(e.key, key) {
+				return e.value, true
+			}
+			break
+		}
+		node = e.child
+	}
+	var zero V
+	return zero, false
+}
+
+// ContainsKey reports whether the given key is present in the map.
+func (m *PersistentMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Put returns a new version of the map in which the given key is associated with the given value.
+func (m *PersistentMap[K, V]) Put(key K, value V) *PersistentMap[K, V] {
+	root, added := putHAMT(m.hasher, m.root, 0, hamtEntry[K, V]{key: key, value: value, hash: m.hasher.hash(key)})
+	size := m.size
+	if added {
+		size++
+	}
+	return &PersistentMap[K, V]{root: root, hasher: m.hasher, size: size}
+}
+
+// putHAMT returns a copy of node with the entry e added or, if its key is already present,
+// replaced. Keys are compared with h. The boolean result reports whether the key was added.
+func putHAMT[K comparable, V any](h hasher[K], node *hamtNode[K, V], shift uint, e hamtEntry[K, V]) (*hamtNode[K, V], bool) {
+	if shift >= 64 {
+		for i, old := range node.entries {
+			if h.equal(old.key, e.key) {
+				entries := append([]hamtEntry[K, V](nil), node.entries...)
+				entries[i] = e
+				return &hamtNode[K, V]{entries: entries}, false
+			}
+		}
+		return &hamtNode[K, V]{entries: append(append([]hamtEntry[K, V](nil), node.entries...), e)}, true
+	}
+
+	bit, pos := node.slot(e.hash, shift)
+	if node.bitmap&bit == 0 {
+		entries := make([]hamtEntry[K, V], 0, len(node.entries)+1)
+		entries = append(append(append(entries, node.entries[:pos]...), e), node.entries[pos:]...)
+		return &hamtNode[K, V]{bitmap: node.bitmap | bit, entries: entries}, true
+	}
+
+	old := node.entries[pos]
+	added := true
+	switch {
+	case old.child != nil:
+		old.child, added = putHAMT(h, old.child, shift+hamtBits, e)
+		e = hamtEntry[K, V]{child: old.child}
+	case old.hash == e.hash && h.equal(old.key, e.key):
+		added = false
+	default:
+		e = hamtEntry[K, V]{child: mergeHAMT(shift+hamtBits, old, e)}
+	}
+	entries := append([]hamtEntry[K, V](nil), node.entries...)
+	entries[pos] = e
+	return &hamtNode[K, V]{bitmap: node.bitmap, entries: entries}, added
+}
+
+// mergeHAMT returns a new subtree at the level that starts at shift holding the two entries a
+// and b, whose keys differ but whose hashes agree on all bits consumed above that level.
+func mergeHAMT[K comparable, V any](shift uint, a, b hamtEntry[K, V]) *hamtNode[K, V] {
+	if shift >= 64 {
+		return &hamtNode[K, V]{entries: []hamtEntry[K, V]{a, b}}
+	}
+	ia, ib := (a.hash>>shift)&hamtMask, (b.hash>>shift)&hamtMask
+	if ia == ib {
+		return &hamtNode[K, V]{
+			bitmap:  1 << ia,
+			entries: []hamtEntry[K, V]{{child: mergeHAMT(shift+hamtBits, a, b)}},
+		}
+	}
+	if ia > ib {
+		a, b = b, a
+		ia, ib = ib, ia
+	}
+	return &hamtNode[K, V]{bitmap: 1<<ia | 1<<ib, entries: []hamtEntry[K, V]{a, b}}
+}
+
+// Delete returns a new version of the map without the given key, and reports whether the key was
+// present. If it was not, the map itself is returned.
+func (m *PersistentMap[K, V]) Delete(key K) (*PersistentMap[K, V], bool) {
+	root, found := deleteHAMT(m.hasher, m.root, 0, m.hasher.hash(key), key)
+	if !found {
+		return m, false
+	}
+	return &PersistentMap[K, V]{root: root, hasher: m.hasher, size: m.size - 1}, true
+}
+
+// deleteHAMT returns a copy of node without the given key, and reports whether the key was found.
+// Keys are compared with h. A subtree that is left with a single key-value pair is replaced by
+// that pair in its parent, so the trie never holds chains of nodes with only one key.
+func deleteHAMT[K comparable, V any](h hasher[K], node *hamtNode[K, V], shift uint, hash uint64, key K) (*hamtNode[K, V], bool) {
+	if shift >= 64 {
+		for i, e := range node.entries {
+			if h.equal(e.key, key) {
+				entries := append(append([]hamtEntry[K, V](nil), node.entries[:i]...), node.entries[i+1:]...)
+				return &hamtNode[K, V]{entries: entries}, true
+			}
+		}
+		return node, false
+	}
+
+	bit, pos := node.slot(hash, shift)
+	if node.bitmap&bit == 0 {
+		return node, false
+	}
+	e := node.entries[pos]
+	if e.child == nil {
+		if e.hash != hash || !h.equal(e.key, key) {
+			return node, false
+		}
+		entries := append(append([]hamtEntry[K, V](nil), node.entries[:pos]...), node.entries[pos+1:]...)
+		return &hamtNode[K, V]{bitmap: node.bitmap &^ bit, entries: entries}, true
+	}
+
+	child, found := deleteHAMT(h, e.child, shift+hamtBits, hash, key)
+	if !found {
+		return node, false
+	}
+	if len(child.entries) == 1 && child.entries[0].child == nil {
+		e = child.entries[0]
+	} else {
+		e = hamtEntry[K, V]{child: child}
+	}
+	entries := append([]hamtEntry[K, V](nil), node.entries...)
+	entries[pos] = e
+	return &hamtNode[K, V]{bitmap: node.bitmap, entries: entries}, true
+}
+
+// Len returns the number of entries in the map.
+func (m *PersistentMap[K, V]) Len() int {
+	return m.size
+}
+
+// IsEmpty returns true if the map is empty, false otherwise.
+func (m *PersistentMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// All returns an iterator over the entries of the map in unspecified order. The order is the same
+// for every loop over the same version. The map is immutable, so the loop body may freely use the
+// map and create new versions of it.
+func (m *PersistentMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		allHAMT(m.root, yield)
+	}
+}
+
+// allHAMT yields the entries of the subtree rooted at node and returns false if yield did.
+func allHAMT[K comparable, V any](node *hamtNode[K, V], yield func(K, V) bool) bool {
+	for _, e := range node.entries {
+		if e.child != nil {
+			if !allHAMT(e.child, yield) {
+				return false
+			}
+		} else if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys returns an iterator over the keys of the map in unspecified order.
+func (m *PersistentMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the map in unspecified order.
+func (m *PersistentMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
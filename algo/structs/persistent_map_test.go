@@ -0,0 +1,149 @@
+package structs
+
+import (
+	"maps"
+	"math/rand"
+	"testing"
+)
+
+func TestPersistentMap(t *testing.T) {
+	m0 := NewPersistentMap[string, int]()
+	m1 := m0.Put("one", 1)
+	m2 := m1.Put("two", 2)
+	m3 := m2.Put("one", 100)
+	m4, deleted := m3.Delete("two")
+
+	tt := []struct {
+		name     string
+		m        *PersistentMap[string, int]
+		expected map[string]int
+	}{
+		{"Empty", m0, map[string]int{}},
+		{"One", m1, map[string]int{"one": 1}},
+		{"Two", m2, map[string]int{"one": 1, "two": 2}},
+		{"Replaced", m3, map[string]int{"one": 100, "two": 2}},
+		{"Deleted", m4, map[string]int{"one": 100}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := maps.Collect(tc.m.All()); !maps.Equal(got, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, got)
+			}
+			if tc.m.Len() != len(tc.expected) || tc.m.IsEmpty() != (len(tc.expected) == 0) {
+				t.Errorf("Expected length %d but got %d", len(tc.expected), tc.m.Len())
+			}
+			for k, v := range tc.expected {
+				if got, ok := tc.m.Get(k); got != v || !ok {
+					t.Errorf("Get(%q): expected (%d, true) but got (%d, %v)", k, v, got, ok)
+				}
+			}
+		})
+	}
+	if !deleted {
+		t.Errorf("Expected two to be deleted")
+	}
+	if same, deleted := m4.Delete("three"); same != m4 || deleted {
+		t.Errorf("Deleting a missing key should return the map itself")
+	}
+	if m4.ContainsKey("two") || !m3.ContainsKey("two") {
+		t.Errorf("Delete must not change the original version")
+	}
+}
+
+func TestPersistentMapRandomOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(17))
+	m := NewPersistentMap[int, int]()
+	model := map[int]int{}
+	var snapshots []*PersistentMap[int, int]
+	var snapshotModels []map[int]int
+	for i := 0; i < 20000; i++ {
+		key := rng.Intn(5000)
+		if rng.Intn(3) == 0 {
+			var deleted bool
+			m, deleted = m.Delete(key)
+			if _, found := model[key]; deleted != found {
+				t.Fatalf("Delete(%d): expected %v but got %v", key, found, deleted)
+			}
+			delete(model, key)
+		} else {
+			m = m.Put(key, i)
+			model[key] = i
+		}
+		if i%2000 == 0 {
+			snapshots = append(snapshots, m)
+			snapshotModels = append(snapshotModels, maps.Clone(model))
+		}
+	}
+	snapshots = append(snapshots, m)
+	snapshotModels = append(snapshotModels, model)
+	for i, snapshot := range snapshots {
+		if got := maps.Collect(snapshot.All()); !maps.Equal(got, snapshotModels[i]) || snapshot.Len() != len(got) {
+			t.Fatalf("Snapshot %d does not match its model", i)
+		}
+	}
+}
+
+// TestHAMTCollisions builds tries from entries with chosen hashes, so that keys share hash
+// prefixes or whole hashes, and checks that deletions collapse the trie again.
+func TestHAMTCollisions(t *testing.T) {
+	entries := []hamtEntry[string, int]{
+		{key: "a", value: 1, hash: 0x1},
+		{key: "b", value: 2, hash: 0x1 | 1<<40},
+		{key: "c", value: 3, hash: 0x1 | 1<<40},
+		{key: "d", value: 4, hash: 0x1 | 1<<40},
+		{key: "e", value: 5, hash: 0x2},
+	}
+	h := newHasher[string]()
+	root := &hamtNode[string, int]{}
+	for _, e := range entries {
+		var added bool
+		root, added = putHAMT(h, root, 0, e)
+		if !added {
+			t.Fatalf("Expected %q to be added", e.key)
+		}
+	}
+	root, added := putHAMT(h, root, 0, hamtEntry[string, int]{key: "c", value: 30, hash: 0x1 | 1<<40})
+	if added {
+		t.Errorf("Expected c to be replaced")
+	}
+
+	get := func(node *hamtNode[string, int]) map[string]int {
+		return maps.Collect((&PersistentMap[string, int]{root: node}).All())
+	}
+	if got := get(root); !maps.Equal(got, map[string]int{"a": 1, "b": 2, "c": 30, "d": 4, "e": 5}) {
+		t.Errorf("Unexpected entries %v", got)
+	}
+
+	for _, e := range entries[1:4] {
+		var found bool
+		root, found = deleteHAMT(h, root, 0, e.hash, e.key)
+		if !found {
+			t.Fatalf("Expected %q to be deleted", e.key)
+		}
+	}
+	if _, found := deleteHAMT(h, root, 0, 0x1|1<<40, "b"); found {
+		t.Errorf("Expected b to be gone")
+	}
+	// Only a and e are left, and both must be stored directly in the root again.
+	if len(root.entries) != 2 || root.entries[0].child != nil || root.entries[1].child != nil {
+		t.Errorf("Expected the trie to collapse into the root")
+	}
+}
+
+func BenchmarkPersistentMapPut(b *testing.B) {
+	m := NewPersistentMap[int, int]()
+	for i := 0; i < b.N; i++ {
+		m = m.Put(i, i)
+	}
+}
+
+func BenchmarkPersistentMapGet(b *testing.B) {
+	m := NewPersistentMap[int, int]()
+	for i := 0; i < 100000; i++ {
+		m = m.Put(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i % 100000)
+	}
+}
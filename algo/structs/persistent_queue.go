@@ -0,0 +1,173 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+)
+
+// stream is a lazily evaluated, memoized immutable list. Its first cell is computed by thunk the
+// first time it is forced, and the result is cached, so later forces of the same stream, including
+// forces through other versions of a PersistentQueue, take O(1) time. A sync.Once makes forcing
+// safe for concurrent readers.
+type stream[T any] struct {
+	once  sync.Once
+	thunk func() *streamCell[T]
+	cell  *streamCell[T]
+}
+
+// streamCell is an evaluated cell of a stream. A nil cell is the end of the stream.
+type streamCell[T any] struct {
+	value T
+	next  *stream[T]
+}
+
+// lazyStream returns a stream whose first cell is computed by thunk when it is first forced.
+func lazyStream[T any](thunk func() *streamCell[T]) *stream[T] {
+	return &stream[T]{thunk: thunk}
+}
+
+// forcedStream returns a stream whose first cell is already evaluated.
+func forcedStream[T any](cell *streamCell[T]) *stream[T] {
+	s := &stream[T]{cell: cell}
+	s.once.Do(func() {})
+	return s
+}
+
+// force evaluates the first cell of the stream, or returns the cached cell.
+func (s *stream[T]) force() *streamCell[T] {
+	if s == nil {
+		return nil
+	}
+	s.once.Do(func() {
+		s.cell = s.thunk()
+		s.thunk = nil
+	})
+	return s.cell
+}
+
+// appendReversed returns the lazy stream front ++ reverse(rear). The concatenation advances one
+// cell each time a cell is forced, and the reversal of rear is performed in one go when the end of
+// front is reached.
+func appendReversed[T any](front *stream[T], rear *pstackNode[T]) *stream[T] {
+	return lazyStream(func() *streamCell[T] {
+		if cell := front.force(); cell != nil {
+			return &streamCell[T]{value: cell.value, next: appendReversed(cell.next, rear)}
+		}
+		var reversed *streamCell[T]
+		for node := rear; node != nil; node = node.next {
+			reversed = &streamCell[T]{value: node.value, next: forcedStream(reversed)}
+		}
+		return reversed
+	})
+}
+
+// PersistentQueue is an immutable First-In-First-Out queue of items of type T, implemented as
+// Okasaki's banker's queue.
+//
+// The queue keeps its items in a front stream, from which they are dequeued, and a rear list, to
+// which they are enqueued in reverse order. Whenever the rear list becomes longer than the front
+// stream, the reversed rear list is lazily appended to the front. Because the append is lazy and
+// its results are memoized, the cost of each reversal is shared by all versions of the queue that
+// reach it, so Enqueue and Dequeue take O(1) amortized time even when old versions are reused,
+// which is what breaks the amortized bounds of a plain two-list queue.
+//
+// Enqueue and Dequeue return a new version of the queue and leave the old one unchanged. A
+// PersistentQueue needs no lock and can be read from any number of goroutines at once.
+type PersistentQueue[T any] struct {
+	front     *stream[T]
+	frontSize int
+	rear      *pstackNode[T]
+	rearSize  int
+}
+
+// NewPersistentQueue returns an empty PersistentQueue for items of type T.
+func NewPersistentQueue[T any]() *PersistentQueue[T] {
+	return &PersistentQueue[T]{}
+}
+
+// balance returns a queue with the given parts that satisfies the invariant that the rear list is
+// not longer than the front stream.
+func balance[T any](front *stream[T], frontSize int, rear *pstackNode[T], rearSize int) *PersistentQueue[T] {
+	if rearSize <= frontSize {
+		return &PersistentQueue[T]{front: front, frontSize: frontSize, rear: rear, rearSize: rearSize}
+	}
+	return &PersistentQueue[T]{front: appendReversed(front, rear), frontSize: frontSize + rearSize}
+}
+
+// Enqueue returns a new version of the queue with item added to the back.
+func (q *PersistentQueue[T]) Enqueue(item T) *PersistentQueue[T] {
+	return balance(q.front, q.frontSize, &pstackNode[T]{value: item, next: q.rear}, q.rearSize+1)
+}
+
+// Dequeue returns a new version of the queue without its front item, together with that item. If
+// the queue is empty, it returns the queue itself, the zero value of T and false.
+func (q *PersistentQueue[T]) Dequeue() (*PersistentQueue[T], T, bool) {
+	cell := q.front.force()
+	if cell == nil {
+		var zero T
+		return q, zero, false
+	}
+	return balance(cell.next, q.frontSize-1, q.rear, q.rearSize), cell.value, true
+}
+
+// Peek returns the front item of the queue. The boolean result is false if the queue is empty.
+func (q *PersistentQueue[T]) Peek() (T, bool) {
+	cell := q.front.force()
+	if cell == nil {
+		var zero T
+		return zero, false
+	}
+	return cell.value, true
+}
+
+// IsEmpty returns true if the queue is empty, false otherwise.
+func (q *PersistentQueue[T]) IsEmpty() bool {
+	return q.frontSize == 0
+}
+
+// Size returns the number of items in the queue.
+func (q *PersistentQueue[T]) Size() int {
+	return q.frontSize + q.rearSize
+}
+
+// All returns an iterator over the items of the queue from the front to the back. Iterating forces
+// the front stream, which does the same work that dequeuing the items would do.
+func (q *PersistentQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for cell := q.front.force(); cell != nil; cell = cell.next.force() {
+			if !yield(cell.value) {
+				return
+			}
+		}
+		rear := make([]T, 0, q.rearSize)
+		for node := q.rear; node != nil; node = node.next {
+			rear = append(rear, node.value)
+		}
+		for i := len(rear) - 1; i >= 0; i-- {
+			if !yield(rear[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the queue from the back to the front. The front
+// stream only links forward, so its items are collected before they are yielded.
+func (q *PersistentQueue[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := q.rear; node != nil; node = node.next {
+			if !yield(node.value) {
+				return
+			}
+		}
+		front := make([]T, 0, q.frontSize)
+		for cell := q.front.force(); cell != nil; cell = cell.next.force() {
+			front = append(front, cell.value)
+		}
+		for i := len(front) - 1; i >= 0; i-- {
+			if !yield(front[i]) {
+				return
+			}
+		}
+	}
+}
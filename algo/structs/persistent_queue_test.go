@@ -0,0 +1,103 @@
+package structs
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestPersistentQueue(t *testing.T) {
+	q := NewPersistentQueue[int]()
+	if !q.IsEmpty() || q.Size() != 0 {
+		t.Errorf("New queue should be empty")
+	}
+	if same, v, ok := q.Dequeue(); same != q || v != 0 || ok {
+		t.Errorf("Dequeue on an empty queue should fail")
+	}
+
+	versions := []*PersistentQueue[int]{q}
+	for i := 1; i <= 10; i++ {
+		q = q.Enqueue(i)
+		versions = append(versions, q)
+	}
+	for i, version := range versions {
+		expected := make([]int, i)
+		for j := range expected {
+			expected[j] = j + 1
+		}
+		if got := slices.Collect(version.All()); !slices.Equal(got, expected) {
+			t.Errorf("Version %d: expected %v but got %v", i, expected, got)
+		}
+		slices.Reverse(expected)
+		if got := slices.Collect(version.Backward()); !slices.Equal(got, expected) {
+			t.Errorf("Version %d backward: expected %v but got %v", i, expected, got)
+		}
+	}
+
+	// Dequeuing twice from the same version yields the same item both times.
+	for round := 0; round < 2; round++ {
+		rest, v, ok := versions[5].Dequeue()
+		if v != 1 || !ok || rest.Size() != 4 {
+			t.Errorf("Round %d: expected to dequeue 1 from version 5 but got %d, %v", round, v, ok)
+		}
+		if front, _ := rest.Peek(); front != 2 {
+			t.Errorf("Round %d: expected 2 at the front but got %d", round, front)
+		}
+	}
+}
+
+// TestPersistentQueueRandomOperations applies random operations to random old versions and
+// compares every version with a slice.
+func TestPersistentQueueRandomOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	queues := []*PersistentQueue[int]{NewPersistentQueue[int]()}
+	models := [][]int{{}}
+	for i := 0; i < 3000; i++ {
+		k := rng.Intn(len(queues))
+		q, model := queues[k], models[k]
+		if rng.Intn(3) == 0 {
+			next, v, ok := q.Dequeue()
+			if ok != (len(model) > 0) || ok && v != model[0] {
+				t.Fatalf("Dequeue from version %d: expected %v but got %d, %v", k, model, v, ok)
+			}
+			if ok {
+				queues, models = append(queues, next), append(models, model[1:])
+			}
+		} else {
+			queues = append(queues, q.Enqueue(i))
+			models = append(models, append(slices.Clip(model), i))
+		}
+	}
+	for k, q := range queues {
+		if got := slices.Collect(q.All()); !slices.Equal(got, models[k]) || q.Size() != len(models[k]) {
+			t.Fatalf("Version %d: expected %v but got %v", k, models[k], got)
+		}
+	}
+}
+
+func TestPersistentQueueConcurrentReaders(t *testing.T) {
+	q := NewPersistentQueue[int]()
+	for i := 0; i < 1000; i++ {
+		q = q.Enqueue(i)
+	}
+	// Readers force the same lazy streams at the same time; the race detector checks that the
+	// memoization is safe.
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			version := q
+			for i := 0; i < 1000; i++ {
+				var v int
+				version, v, _ = version.Dequeue()
+				if v != i {
+					t.Errorf("Expected %d but got %d", i, v)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,335 @@
+package structs
+
+import "iter"
+
+// prbNode is a node of a PersistentRedBlackTree. A node is only modified while it is being built
+// by the update that created it; once the update returns, the node is shared and never changes.
+type prbNode[T any] struct {
+	value T
+	left  *prbNode[T]
+	right *prbNode[T]
+	red   bool
+}
+
+// clone returns a copy of the node that the current update may modify.
+func (n *prbNode[T]) clone() *prbNode[T] {
+	c := *n
+	return &c
+}
+
+// PersistentRedBlackTree is an immutable red-black tree holding values of type T.
+//
+// Insert and Delete do not modify the tree they are called on but return a new version. Only the
+// O(log N) nodes on the path from the root to the changed position, and the few nodes touched by
+// rotations and color flips, are copied; every other node is shared between the old and the new
+// version. Every version stays valid and unchanged for as long as it is referenced, so taking a
+// snapshot is free, and since no version is ever modified, a PersistentRedBlackTree needs no lock
+// and can be read from any number of goroutines at once.
+//
+// The tree is a left-leaning red-black tree as described by Sedgewick, in which red links always
+// lean left. This removes most of the cases of the classic algorithms and allows insertion and
+// deletion to be written as simple recursive functions, which lend themselves to path copying.
+// Like RedBlackTree, the tree may hold several values that are equal according to cmp.
+type PersistentRedBlackTree[T any] struct {
+	root *prbNode[T]
+	cmp  func(a, b T) int
+	size int
+}
+
+// NewPersistentRedBlackTree creates a new empty persistent red-black tree with the specified
+// comparison function, which returns a negative number if a is less than b, zero if they are
+// equal, and a positive number if a is greater than b, like cmp.Compare.
+func NewPersistentRedBlackTree[T any](cmp func(a, b T) int) *PersistentRedBlackTree[T] {
+	return &PersistentRedBlackTree[T]{cmp: cmp}
+}
+
+// prbIsRed reports whether node is red. Nil links are black.
+func prbIsRed[T any](node *prbNode[T]) bool {
+	return node != nil && node.red
+}
+
+// The following helpers implement the local transformations of a left-leaning red-black tree.
+// The node passed to them must already be a copy owned by the current update; any other node
+// they modify is copied first.
+
+// prbRotateLeft turns a right-leaning red link below h into a left-leaning one.
+func prbRotateLeft[T any](h *prbNode[T]) *prbNode[T] {
+	x := h.right.clone()
+	h.right = x.left
+	x.left = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+// prbRotateRight turns a left-leaning red link below h into a right-leaning one.
+func prbRotateRight[T any](h *prbNode[T]) *prbNode[T] {
+	x := h.left.clone()
+	h.left = x.right
+	x.right = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+// prbFlipColors flips the colors of h and its two children, which splits or merges a 4-node.
+func prbFlipColors[T any](h *prbNode[T]) {
+	h.red = !h.red
+	h.left = h.left.clone()
+	h.left.red = !h.left.red
+	h.right = h.right.clone()
+	h.right.red = !h.right.red
+}
+
+// prbFixUp restores the left-leaning invariants at h on the way back up from an update.
+func prbFixUp[T any](h *prbNode[T]) *prbNode[T] {
+	if prbIsRed(h.right) && !prbIsRed(h.left) {
+		h = prbRotateLeft(h)
+	}
+	if prbIsRed(h.left) && prbIsRed(h.left.left) {
+		h = prbRotateRight(h)
+	}
+	if prbIsRed(h.left) && prbIsRed(h.right) {
+		prbFlipColors(h)
+	}
+	return h
+}
+
+// prbMoveRedLeft makes h.left or one of its children red, assuming that h is red and both
+// h.left and h.left.left are black, so that a deletion can continue into the left subtree.
+func prbMoveRedLeft[T any](h *prbNode[T]) *prbNode[T] {
+	prbFlipColors(h)
+	if prbIsRed(h.right.left) {
+		h.right = prbRotateRight(h.right)
+		h = prbRotateLeft(h)
+		prbFlipColors(h)
+	}
+	return h
+}
+
+// prbMoveRedRight makes h.right or one of its children red, assuming that h is red and both
+// h.right and h.right.left are black, so that a deletion can continue into the right subtree.
+func prbMoveRedRight[T any](h *prbNode[T]) *prbNode[T] {
+	prbFlipColors(h)
+	if prbIsRed(h.left.left) {
+		h = prbRotateRight(h)
+		prbFlipColors(h)
+	}
+	return h
+}
+
+// Insert returns a new version of the tree that also contains the given value. Values equal to
+// values already in the tree are inserted after them.
+func (t *PersistentRedBlackTree[T]) Insert(value T) *PersistentRedBlackTree[T] {
+	root := t.insertNode(t.root, value)
+	root.red = false
+	return &PersistentRedBlackTree[T]{root: root, cmp: t.cmp, size: t.size + 1}
+}
+
+// insertNode returns a copy of the subtree rooted at h with the value inserted.
+func (t *PersistentRedBlackTree[T]) insertNode(h *prbNode[T], value T) *prbNode[T] {
+	if h == nil {
+		return &prbNode[T]{value: value, red: true}
+	}
+	h = h.clone()
+	if t.cmp(value, h.value) < 0 {
+		h.left = t.insertNode(h.left, value)
+	} else {
+		h.right = t.insertNode(h.right, value)
+	}
+	return prbFixUp(h)
+}
+
+// Delete returns a new version of the tree without one value equal to the given value, and
+// reports whether such a value was found. If it was not, the tree itself is returned.
+func (t *PersistentRedBlackTree[T]) Delete(value T) (*PersistentRedBlackTree[T], bool) {
+	if !t.Search(value) {
+		return t, false
+	}
+	root := t.root.clone()
+	if !prbIsRed(root.left) && !prbIsRed(root.right) {
+		root.red = true
+	}
+	root = t.deleteNode(root, value)
+	if root != nil {
+		root.red = false
+	}
+	return &PersistentRedBlackTree[T]{root: root, cmp: t.cmp, size: t.size - 1}, true
+}
+
+// deleteNode removes one value equal to the given value from the subtree rooted at h, which must
+// contain such a value and must be a copy owned by the current update. On the way down it keeps
+// the current node or its left child red, so that the value is finally removed from a 3-node or
+// 4-node and no black link disappears.
+func (t *PersistentRedBlackTree[T]) deleteNode(h *prbNode[T], value T) *prbNode[T] {
+	if t.cmp(value, h.value) < 0 {
+		if !prbIsRed(h.left) && !prbIsRed(h.left.left) {
+			h = prbMoveRedLeft(h)
+		}
+		h.left = t.deleteNode(h.left.clone(), value)
+	} else {
+		if prbIsRed(h.left) {
+			h = prbRotateRight(h)
+		}
+		if t.cmp(value, h.value) == 0 && h.right == nil {
+			return nil
+		}
+		if !prbIsRed(h.right) && !prbIsRed(h.right.left) {
+			h = prbMoveRedRight(h)
+		}
+		if t.cmp(value, h.value) == 0 {
+			successor := h.right
+			for successor.left != nil {
+				successor = successor.left
+			}
+			h.value = successor.value
+			h.right = prbDeleteMin(h.right.clone())
+		} else {
+			h.right = t.deleteNode(h.right.clone(), value)
+		}
+	}
+	return prbFixUp(h)
+}
+
+// prbDeleteMin removes the smallest value from the subtree rooted at h, which must be a copy owned
+// by the current update.
+func prbDeleteMin[T any](h *prbNode[T]) *prbNode[T] {
+	if h.left == nil {
+		return nil
+	}
+	if !prbIsRed(h.left) && !prbIsRed(h.left.left) {
+		h = prbMoveRedLeft(h)
+	}
+	h.left = prbDeleteMin(h.left.clone())
+	return prbFixUp(h)
+}
+
+// Search reports whether a value equal to the given value is present in the tree.
+func (t *PersistentRedBlackTree[T]) Search(value T) bool {
+	node := t.root
+	for node != nil {
+		c := t.cmp(value, node.value)
+		if c == 0 {
+			return true
+		}
+		if c < 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return false
+}
+
+// Len returns the number of values in the tree.
+func (t *PersistentRedBlackTree[T]) Len() int {
+	return t.size
+}
+
+// Min returns the smallest value in the tree. The boolean result is false if the tree is empty.
+func (t *PersistentRedBlackTree[T]) Min() (T, bool) {
+	node := t.root
+	for node != nil && node.left != nil {
+		node = node.left
+	}
+	return prbValue(node)
+}
+
+// Max returns the largest value in the tree. The boolean result is false if the tree is empty.
+func (t *PersistentRedBlackTree[T]) Max() (T, bool) {
+	node := t.root
+	for node != nil && node.right != nil {
+		node = node.right
+	}
+	return prbValue(node)
+}
+
+// Floor returns the largest value in the tree that is less than or equal to the given value. The
+// boolean result is false if there is no such value.
+func (t *PersistentRedBlackTree[T]) Floor(value T) (T, bool) {
+	var best *prbNode[T]
+	for node := t.root; node != nil; {
+		if t.cmp(node.value, value) <= 0 {
+			best = node
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return prbValue(best)
+}
+
+// Ceiling returns the smallest value in the tree that is greater than or equal to the given value.
+// The boolean result is false if there is no such value.
+func (t *PersistentRedBlackTree[T]) Ceiling(value T) (T, bool) {
+	var best *prbNode[T]
+	for node := t.root; node != nil; {
+		if t.cmp(node.value, value) >= 0 {
+			best = node
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	return prbValue(best)
+}
+
+// prbValue returns the value of node and true, or the zero value of T and false if node is nil.
+func prbValue[T any](node *prbNode[T]) (T, bool) {
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.value, true
+}
+
+// All returns an iterator over the values of the tree in ascending order. The tree is immutable,
+// so the loop body may freely use the tree and create new versions of it.
+func (t *PersistentRedBlackTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.ascend(t.root, nil, nil, yield)
+	}
+}
+
+// Backward returns an iterator over the values of the tree in descending order.
+func (t *PersistentRedBlackTree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.descend(t.root, yield)
+	}
+}
+
+// Range returns an iterator over the values v with lo <= v < hi in ascending order.
+func (t *PersistentRedBlackTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.ascend(t.root, &lo, &hi, yield)
+	}
+}
+
+// ascend yields the values of the subtree rooted at node that are not less than *lo and less than
+// *hi in ascending order; a nil bound is unbounded. It returns false once iteration must stop.
+func (t *PersistentRedBlackTree[T]) ascend(node *prbNode[T], lo, hi *T, yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	if lo == nil || t.cmp(node.value, *lo) >= 0 {
+		if !t.ascend(node.left, lo, hi, yield) {
+			return false
+		}
+		if hi != nil && t.cmp(node.value, *hi) >= 0 {
+			return false
+		}
+		if !yield(node.value) {
+			return false
+		}
+	}
+	return t.ascend(node.right, lo, hi, yield)
+}
+
+// descend yields the values of the subtree rooted at node in descending order and returns false
+// if yield did.
+func (t *PersistentRedBlackTree[T]) descend(node *prbNode[T], yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	return t.descend(node.right, yield) && yield(node.value) && t.descend(node.left, yield)
+}
@@ -0,0 +1,167 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// verifyPersistentRedBlackTree checks the invariants of a left-leaning red-black tree: values are
+// in search tree order, the root is black, no right link is red, no red node has a red left child,
+// every path from the root to a leaf has the same number of black nodes, and the size is correct.
+func verifyPersistentRedBlackTree[T any](t *PersistentRedBlackTree[T]) error {
+	if prbIsRed(t.root) {
+		return fmt.Errorf("root is red")
+	}
+	count := 0
+	var walk func(node *prbNode[T]) (int, error)
+	walk = func(node *prbNode[T]) (int, error) {
+		if node == nil {
+			return 1, nil
+		}
+		count++
+		if prbIsRed(node.right) {
+			return 0, fmt.Errorf("node %v has a red right link", node.value)
+		}
+		if prbIsRed(node) && prbIsRed(node.left) {
+			return 0, fmt.Errorf("red node %v has a red child", node.value)
+		}
+		if node.left != nil && t.cmp(node.left.value, node.value) > 0 {
+			return 0, fmt.Errorf("left child of %v is greater", node.value)
+		}
+		if node.right != nil && t.cmp(node.right.value, node.value) < 0 {
+			return 0, fmt.Errorf("right child of %v is smaller", node.value)
+		}
+		left, err := walk(node.left)
+		if err != nil {
+			return 0, err
+		}
+		right, err := walk(node.right)
+		if err != nil {
+			return 0, err
+		}
+		if left != right {
+			return 0, fmt.Errorf("black heights %d and %d differ below %v", left, right, node.value)
+		}
+		if !node.red {
+			left++
+		}
+		return left, nil
+	}
+	if _, err := walk(t.root); err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("tree has %d nodes but size %d", count, t.size)
+	}
+	return nil
+}
+
+// TestPersistentRedBlackTreeVersions applies random insertions and deletions to random old
+// versions and checks that every version keeps its contents and invariants.
+func TestPersistentRedBlackTreeVersions(t *testing.T) {
+	rng := rand.New(rand.NewSource(13))
+	trees := []*PersistentRedBlackTree[int]{NewPersistentRedBlackTree(cmp.Compare[int])}
+	models := [][]int{{}}
+	for i := 0; i < 2000; i++ {
+		k := rng.Intn(len(trees))
+		tree, model := trees[k], models[k]
+		value := rng.Intn(100)
+		pos, found := slices.BinarySearch(model, value)
+		if rng.Intn(3) == 0 {
+			next, deleted := tree.Delete(value)
+			if deleted != found {
+				t.Fatalf("Delete(%d) on version %d: expected %v but got %v", value, k, found, deleted)
+			}
+			if !deleted {
+				continue
+			}
+			trees, models = append(trees, next), append(models, slices.Delete(slices.Clone(model), pos, pos+1))
+		} else {
+			trees = append(trees, tree.Insert(value))
+			models = append(models, slices.Insert(slices.Clone(model), pos, value))
+		}
+	}
+	for k, tree := range trees {
+		if err := verifyPersistentRedBlackTree(tree); err != nil {
+			t.Fatalf("Version %d: %v", k, err)
+		}
+		if got := slices.Collect(tree.All()); !slices.Equal(got, models[k]) {
+			t.Fatalf("Version %d: expected %v but got %v", k, models[k], got)
+		}
+	}
+}
+
+func TestPersistentRedBlackTreeNavigation(t *testing.T) {
+	tree := NewPersistentRedBlackTree(cmp.Compare[int])
+	if _, ok := tree.Min(); ok {
+		t.Errorf("Min of an empty tree should fail")
+	}
+	for _, v := range []int{40, 10, 30, 20, 20} {
+		tree = tree.Insert(v)
+	}
+	checks := []struct {
+		name     string
+		got      func() (int, bool)
+		expected int
+		ok       bool
+	}{
+		{"Min", tree.Min, 10, true},
+		{"Max", tree.Max, 40, true},
+		{"Floor", func() (int, bool) { return tree.Floor(25) }, 20, true},
+		{"FloorBelow", func() (int, bool) { return tree.Floor(5) }, 0, false},
+		{"Ceiling", func() (int, bool) { return tree.Ceiling(25) }, 30, true},
+		{"CeilingAbove", func() (int, bool) { return tree.Ceiling(45) }, 0, false},
+	}
+	for _, c := range checks {
+		if v, ok := c.got(); v != c.expected || ok != c.ok {
+			t.Errorf("%s: expected (%d, %v) but got (%d, %v)", c.name, c.expected, c.ok, v, ok)
+		}
+	}
+	if got := slices.Collect(tree.Backward()); !slices.Equal(got, []int{40, 30, 20, 20, 10}) {
+		t.Errorf("Backward: unexpected values %v", got)
+	}
+	if got := slices.Collect(tree.Range(15, 40)); !slices.Equal(got, []int{20, 20, 30}) {
+		t.Errorf("Range: unexpected values %v", got)
+	}
+	if same, deleted := tree.Delete(99); same != tree || deleted {
+		t.Errorf("Deleting a missing value should return the tree itself")
+	}
+}
+
+func TestPersistentRedBlackTreeSharing(t *testing.T) {
+	tree := NewPersistentRedBlackTree(cmp.Compare[int])
+	for i := 0; i < 1023; i++ {
+		tree = tree.Insert(i)
+	}
+	next := tree.Insert(2000)
+
+	// An insertion copies a single path, so almost all nodes are shared between the versions.
+	shared := map[*prbNode[int]]bool{}
+	var mark func(node *prbNode[int])
+	mark = func(node *prbNode[int]) {
+		if node != nil {
+			shared[node] = true
+			mark(node.left)
+			mark(node.right)
+		}
+	}
+	mark(tree.root)
+	copied := 0
+	var count func(node *prbNode[int])
+	count = func(node *prbNode[int]) {
+		if node != nil {
+			if !shared[node] {
+				copied++
+			}
+			count(node.left)
+			count(node.right)
+		}
+	}
+	count(next.root)
+	if copied > 40 {
+		t.Errorf("Expected O(log n) copied nodes but got %d", copied)
+	}
+}
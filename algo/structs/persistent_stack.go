@@ -0,0 +1,92 @@
+package structs
+
+import "iter"
+
+// pstackNode is a node of the immutable singly linked list behind PersistentStack and
+// PersistentQueue. Nodes are never modified after they are created, so any number of
+// lists may share a common tail.
+type pstackNode[T any] struct {
+	value T
+	next  *pstackNode[T]
+}
+
+// PersistentStack is an immutable Last-In-First-Out stack of items of type T.
+//
+// Push and Pop do not modify the stack they are called on but return a new version. The new
+// version shares all of its items with the old one, so both operations take O(1) time and
+// memory, and every version stays valid and unchanged for as long as it is referenced. Since
+// no version is ever modified, a PersistentStack needs no lock and can be read from any number
+// of goroutines at once.
+type PersistentStack[T any] struct {
+	head *pstackNode[T]
+	size int
+}
+
+// NewPersistentStack returns an empty PersistentStack for items of type T.
+func NewPersistentStack[T any]() *PersistentStack[T] {
+	return &PersistentStack[T]{}
+}
+
+// Push returns a new version of the stack with item on top.
+func (s *PersistentStack[T]) Push(item T) *PersistentStack[T] {
+	return &PersistentStack[T]{
+		head: &pstackNode[T]{value: item, next: s.head},
+		size: s.size + 1,
+	}
+}
+
+// Pop returns a new version of the stack without its top item, together with that item. If the
+// stack is empty, it returns the stack itself, the zero value of T and false.
+func (s *PersistentStack[T]) Pop() (*PersistentStack[T], T, bool) {
+	if s.head == nil {
+		var zero T
+		return s, zero, false
+	}
+	return &PersistentStack[T]{head: s.head.next, size: s.size - 1}, s.head.value, true
+}
+
+// Peek returns the top item of the stack. The boolean result is false if the stack is empty.
+func (s *PersistentStack[T]) Peek() (T, bool) {
+	if s.head == nil {
+		var zero T
+		return zero, false
+	}
+	return s.head.value, true
+}
+
+// IsEmpty returns true if the stack is empty, false otherwise.
+func (s *PersistentStack[T]) IsEmpty() bool {
+	return s.head == nil
+}
+
+// Size returns the number of items in the stack.
+func (s *PersistentStack[T]) Size() int {
+	return s.size
+}
+
+// All returns an iterator over the items of the stack from the top to the bottom.
+func (s *PersistentStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := s.head; node != nil; node = node.next {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the stack from the bottom to the top. Since the
+// nodes only link downwards, the loop first collects the items, which takes O(N) time and memory.
+func (s *PersistentStack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		items := make([]T, 0, s.size)
+		for node := s.head; node != nil; node = node.next {
+			items = append(items, node.value)
+		}
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(items[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package structs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPersistentStack(t *testing.T) {
+	empty := NewPersistentStack[int]()
+	if !empty.IsEmpty() || empty.Size() != 0 {
+		t.Errorf("New stack should be empty")
+	}
+	if s, v, ok := empty.Pop(); s != empty || v != 0 || ok {
+		t.Errorf("Pop on an empty stack: expected (empty, 0, false) but got (%v, %d, %v)", s, v, ok)
+	}
+	if _, ok := empty.Peek(); ok {
+		t.Errorf("Peek on an empty stack should fail")
+	}
+
+	s1 := empty.Push(1)
+	s2 := s1.Push(2)
+	s3 := s2.Push(3)
+	s2b := s2.Push(30)
+
+	tt := []struct {
+		name     string
+		stack    *PersistentStack[int]
+		expected []int
+	}{
+		{"Empty", empty, []int{}},
+		{"One", s1, []int{1}},
+		{"Two", s2, []int{2, 1}},
+		{"Three", s3, []int{3, 2, 1}},
+		{"Branch", s2b, []int{30, 2, 1}},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := slices.Collect(tc.stack.All()); !slices.Equal(got, tc.expected) {
+				t.Errorf("All: expected %v but got %v", tc.expected, got)
+			}
+			if tc.stack.Size() != len(tc.expected) {
+				t.Errorf("Expected size %d but got %d", len(tc.expected), tc.stack.Size())
+			}
+			reversed := slices.Clone(tc.expected)
+			slices.Reverse(reversed)
+			if got := slices.Collect(tc.stack.Backward()); !slices.Equal(got, reversed) {
+				t.Errorf("Backward: expected %v but got %v", reversed, got)
+			}
+		})
+	}
+
+	popped, v, ok := s3.Pop()
+	if v != 3 || !ok || popped.Size() != 2 {
+		t.Errorf("Pop: expected 3 but got %d, %v", v, ok)
+	}
+	if top, _ := s3.Peek(); top != 3 {
+		t.Errorf("Pop must not change the original version, top is %d", top)
+	}
+	if popped.head != s2.head {
+		t.Errorf("Expected the popped version to share its nodes with the older version")
+	}
+}
@@ -0,0 +1,299 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// PriorityQueueItem is a handle to a value in a PriorityQueue. The handle stays valid while the
+// value moves through the heap, so a scheduler or a graph algorithm can keep it, for example in a
+// map from tasks or vertices to their items, and later change the priority of the value or remove
+// it in O(log N) time.
+//
+// The queue an item belongs to is recorded in an atomic pointer, so an item may be passed to
+// another queue while its own queue is changed concurrently. Value does not lock the queue, so it
+// must not be called while other goroutines update the item.
+type PriorityQueueItem[T any] struct {
+	value T
+	index int
+	queue atomic.Pointer[PriorityQueue[T]]
+}
+
+// Value returns the value of the item.
+func (it *PriorityQueueItem[T]) Value() T {
+	return it.value
+}
+
+// PriorityQueue represents a thread-safe priority queue of values of type T, kept in a binary heap
+// ordered by a cmp function. The cmp function returns a negative number if a comes before b, zero
+// if they are equal and a positive number if a comes after b, so the queue is a min-queue for
+// cmp.Compare and a max-queue for a cmp function that reverses it:
+//
+//	tasks := NewPriorityQueue(func(a, b Task) int { return cmp.Compare(b.Priority, a.Priority) })
+//
+// Push, Pop, Update, DecreaseKey and Remove take O(log N) time and Peek takes O(1) time. Push
+// returns an item that serves as a handle for Update, DecreaseKey and Remove. Heapify adds many
+// values at once and Merge moves the values of another queue into the queue; both rebuild the
+// heap bottom-up in O(N + M) time, which is faster than pushing the values one by one.
+//
+// Operations that take an item do nothing, and report failure, if the item does not belong to the
+// queue, for example because it has been popped or removed. Values that compare equal are popped
+// in no particular order.
+type PriorityQueue[T any] struct {
+	mu    sync.Mutex
+	items []*PriorityQueueItem[T]
+	cmp   func(a, b T) int
+}
+
+// NewPriorityQueue creates a new empty PriorityQueue ordered by the given cmp function.
+//
+// Example usage:
+//
+//	pq := NewPriorityQueue(cmp.Compare[int])
+//	item := pq.Push(5)
+//	pq.Push(3)
+//	pq.DecreaseKey(item, 1)
+//	value, ok := pq.Pop() // 1, true
+func NewPriorityQueue[T any](cmp func(a, b T) int) *PriorityQueue[T] {
+	return &PriorityQueue[T]{cmp: cmp}
+}
+
+// Push adds a value to the queue and returns its item.
+func (pq *PriorityQueue[T]) Push(value T) *PriorityQueueItem[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	it := &PriorityQueueItem[T]{value: value, index: len(pq.items)}
+	it.queue.Store(pq)
+	pq.items = append(pq.items, it)
+	pq.up(it.index)
+	return it
+}
+
+// Heapify adds the given values to the queue and returns their items, in the order of the values.
+// Unlike pushing the values one by one, which takes O(M log(N + M)) time, Heapify rebuilds the
+// heap bottom-up in O(N + M) time, so it is the fastest way to fill a queue from a slice.
+func (pq *PriorityQueue[T]) Heapify(values []T) []*PriorityQueueItem[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	added := make([]*PriorityQueueItem[T], len(values))
+	for i, v := range values {
+		added[i] = &PriorityQueueItem[T]{value: v, index: len(pq.items)}
+		added[i].queue.Store(pq)
+		pq.items = append(pq.items, added[i])
+	}
+	pq.heapify()
+	return added
+}
+
+// Pop removes and returns the first value of the queue, the smallest according to the cmp
+// function. The boolean result is false if the queue is empty.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	it := pq.items[0]
+	pq.remove(0)
+	return it.value, true
+}
+
+// Peek returns the first value of the queue without removing it. The boolean result is false if
+// the queue is empty.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return pq.items[0].value, true
+}
+
+// Update replaces the value of the given item, moving it forward or back in the queue as the new
+// value requires. It returns false, and changes nothing, if the item does not belong to the queue.
+func (pq *PriorityQueue[T]) Update(it *PriorityQueueItem[T], value T) bool {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if it.queue.Load() != pq {
+		return false
+	}
+	it.value = value
+	if !pq.up(it.index) {
+		pq.down(it.index)
+	}
+	return true
+}
+
+// DecreaseKey replaces the value of the given item with one that comes no later according to the
+// cmp function, moving the item forward in the queue. It is the operation Dijkstra's and Prim's
+// algorithms perform when they find a shorter edge to a vertex; in a max-queue it raises the
+// priority of the item. It returns false, and changes nothing, if the item does not belong to the
+// queue or the new value would come after the current one.
+func (pq *PriorityQueue[T]) DecreaseKey(it *PriorityQueueItem[T], value T) bool {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if it.queue.Load() != pq || pq.cmp(value, it.value) > 0 {
+		return false
+	}
+	it.value = value
+	pq.up(it.index)
+	return true
+}
+
+// Remove removes the given item from the queue. It returns false if the item does not belong to
+// the queue.
+func (pq *PriorityQueue[T]) Remove(it *PriorityQueueItem[T]) bool {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if it.queue.Load() != pq {
+		return false
+	}
+	pq.remove(it.index)
+	return true
+}
+
+// Contains returns true if the given item belongs to the queue, false otherwise.
+func (pq *PriorityQueue[T]) Contains(it *PriorityQueueItem[T]) bool {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return it.queue.Load() == pq
+}
+
+// Merge moves all values of other into the queue and leaves other empty. The items of other stay
+// valid and now belong to the queue. Merge rebuilds the heap in O(N + M) time. It returns false,
+// and changes nothing, if other is the queue itself. Both queues should use the same order.
+//
+// The two queues are never locked together; see the package documentation.
+func (pq *PriorityQueue[T]) Merge(other *PriorityQueue[T]) bool {
+	if other == pq {
+		return false
+	}
+	other.mu.Lock()
+	moved := other.items
+	other.items = nil
+	for _, it := range moved {
+		it.queue.Store(nil)
+	}
+	other.mu.Unlock()
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	for _, it := range moved {
+		it.index = len(pq.items)
+		it.queue.Store(pq)
+		pq.items = append(pq.items, it)
+	}
+	pq.heapify()
+	return true
+}
+
+// Size returns the number of values in the queue.
+func (pq *PriorityQueue[T]) Size() int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return len(pq.items)
+}
+
+// IsEmpty returns true if the queue holds no values, false otherwise.
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return len(pq.items) == 0
+}
+
+// Clear removes all values from the queue. The removed items no longer belong to the queue.
+func (pq *PriorityQueue[T]) Clear() {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	for _, it := range pq.items {
+		it.queue.Store(nil)
+	}
+	pq.items = nil
+}
+
+// All returns an iterator over the values of the queue in heap order, which starts with the first
+// value but is otherwise unspecified. To visit the values in order, pop them. The queue is locked
+// while the loop runs; see the package documentation.
+func (pq *PriorityQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		pq.mu.Lock()
+		defer pq.mu.Unlock()
+		for _, it := range pq.items {
+			if !yield(it.value) {
+				return
+			}
+		}
+	}
+}
+
+// less reports whether the item at index i comes before the item at index j.
+func (pq *PriorityQueue[T]) less(i, j int) bool {
+	return pq.cmp(pq.items[i].value, pq.items[j].value) < 0
+}
+
+// swap exchanges the items at indexes i and j and keeps their indexes up to date.
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+// up moves the item at index i towards the root while it comes before its parent, and reports
+// whether it moved.
+func (pq *PriorityQueue[T]) up(i int) bool {
+	start := i
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(i, parent) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+	return i != start
+}
+
+// down moves the item at index i towards the leaves while one of its children comes before it.
+func (pq *PriorityQueue[T]) down(i int) {
+	n := len(pq.items)
+	for {
+		first := i
+		if left := 2*i + 1; left < n && pq.less(left, first) {
+			first = left
+		}
+		if right := 2*i + 2; right < n && pq.less(right, first) {
+			first = right
+		}
+		if first == i {
+			return
+		}
+		pq.swap(i, first)
+		i = first
+	}
+}
+
+// heapify restores the heap property for all items bottom-up, in O(N) time.
+func (pq *PriorityQueue[T]) heapify() {
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+}
+
+// remove removes the item at index i and detaches it from the queue.
+func (pq *PriorityQueue[T]) remove(i int) {
+	last := len(pq.items) - 1
+	it := pq.items[i]
+	if i != last {
+		pq.swap(i, last)
+	}
+	pq.items[last] = nil
+	pq.items = pq.items[:last]
+	if i != last && !pq.up(i) {
+		pq.down(i)
+	}
+	it.queue.Store(nil)
+	it.index = -1
+}
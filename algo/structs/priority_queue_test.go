@@ -0,0 +1,284 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// verifyPriorityQueue checks that no item of a PriorityQueue comes before its parent and that
+// every item knows its index and belongs to the queue.
+func verifyPriorityQueue[T any](pq *PriorityQueue[T]) error {
+	for i, it := range pq.items {
+		if it.index != i || it.queue.Load() != pq {
+			return fmt.Errorf("item at index %d has index %d or belongs to another queue", i, it.index)
+		}
+		if i > 0 && pq.less(i, (i-1)/2) {
+			return fmt.Errorf("item at index %d comes before its parent", i)
+		}
+	}
+	return nil
+}
+
+// drainPriorityQueue pops all values of a PriorityQueue.
+func drainPriorityQueue[T any](pq *PriorityQueue[T]) []T {
+	var values []T
+	for v, ok := pq.Pop(); ok; v, ok = pq.Pop() {
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestPriorityQueue(t *testing.T) {
+	cases := []struct {
+		name     string
+		cmp      func(a, b int) int
+		ops      func(pq *PriorityQueue[int])
+		expected []int
+	}{
+		{
+			name:     "Empty",
+			cmp:      cmp.Compare[int],
+			ops:      func(pq *PriorityQueue[int]) {},
+			expected: nil,
+		},
+		{
+			name: "Min",
+			cmp:  cmp.Compare[int],
+			ops: func(pq *PriorityQueue[int]) {
+				for _, v := range []int{5, 1, 4, 1, 3} {
+					pq.Push(v)
+				}
+			},
+			expected: []int{1, 1, 3, 4, 5},
+		},
+		{
+			name: "Max",
+			cmp:  func(a, b int) int { return cmp.Compare(b, a) },
+			ops: func(pq *PriorityQueue[int]) {
+				for _, v := range []int{5, 1, 4, 1, 3} {
+					pq.Push(v)
+				}
+			},
+			expected: []int{5, 4, 3, 1, 1},
+		},
+		{
+			name: "Update",
+			cmp:  cmp.Compare[int],
+			ops: func(pq *PriorityQueue[int]) {
+				items := pq.Heapify([]int{1, 2, 3, 4, 5})
+				pq.Update(items[0], 10)
+				pq.Update(items[4], 0)
+			},
+			expected: []int{0, 2, 3, 4, 10},
+		},
+		{
+			name: "DecreaseKey",
+			cmp:  cmp.Compare[int],
+			ops: func(pq *PriorityQueue[int]) {
+				items := pq.Heapify([]int{10, 20, 30})
+				pq.DecreaseKey(items[2], 5)
+				pq.DecreaseKey(items[0], 50)
+			},
+			expected: []int{5, 10, 20},
+		},
+		{
+			name: "Remove",
+			cmp:  cmp.Compare[int],
+			ops: func(pq *PriorityQueue[int]) {
+				items := pq.Heapify([]int{7, 3, 9, 1, 5})
+				pq.Remove(items[3])
+				pq.Remove(items[2])
+			},
+			expected: []int{3, 5, 7},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pq := NewPriorityQueue(tc.cmp)
+			tc.ops(pq)
+			if err := verifyPriorityQueue(pq); err != nil {
+				t.Fatalf("Invariant violated: %v", err)
+			}
+			if pq.Size() != len(tc.expected) || pq.IsEmpty() != (len(tc.expected) == 0) {
+				t.Errorf("Expected size %d but got %d", len(tc.expected), pq.Size())
+			}
+			if v, ok := pq.Peek(); ok != (len(tc.expected) > 0) || ok && v != tc.expected[0] {
+				t.Errorf("Peek returned (%d, %v)", v, ok)
+			}
+			if got := drainPriorityQueue(pq); !slices.Equal(got, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	pq := NewPriorityQueue(cmp.Compare[string])
+	b := pq.Push("b")
+	a := pq.Push("a")
+	if a.Value() != "a" || !pq.Contains(a) {
+		t.Errorf("Expected the item of a to hold a and belong to the queue")
+	}
+	if pq.DecreaseKey(a, "c") {
+		t.Errorf("Expected DecreaseKey to refuse a value that comes later")
+	}
+	if v, _ := pq.Pop(); v != "a" {
+		t.Errorf("Expected a to be popped first but got %q", v)
+	}
+	if pq.Contains(a) || pq.Update(a, "z") || pq.DecreaseKey(a, "0") || pq.Remove(a) {
+		t.Errorf("Expected a popped item to no longer belong to the queue")
+	}
+
+	other := NewPriorityQueue(cmp.Compare[string])
+	if other.Remove(b) || other.Update(b, "x") {
+		t.Errorf("Expected operations on a foreign item to fail")
+	}
+	if !pq.Remove(b) || pq.Remove(b) || !pq.IsEmpty() {
+		t.Errorf("Expected an item to be removed exactly once")
+	}
+
+	c := pq.Push("c")
+	pq.Clear()
+	if pq.Contains(c) || !pq.IsEmpty() {
+		t.Errorf("Expected Clear to detach the items")
+	}
+}
+
+func TestPriorityQueueHeapifyAndMerge(t *testing.T) {
+	pq := NewPriorityQueue(cmp.Compare[int])
+	pq.Push(4)
+	items := pq.Heapify([]int{9, 2, 7})
+	for i, want := range []int{9, 2, 7} {
+		if items[i].Value() != want {
+			t.Errorf("Expected Heapify to return the items in the order of the values")
+		}
+	}
+	if err := verifyPriorityQueue(pq); err != nil {
+		t.Fatalf("Invariant violated after Heapify: %v", err)
+	}
+
+	other := NewPriorityQueue(cmp.Compare[int])
+	other.Heapify([]int{8, 1, 6})
+	five := other.Push(5)
+	if !pq.Merge(other) || !other.IsEmpty() {
+		t.Fatalf("Expected Merge to move all values out of the other queue")
+	}
+	if pq.Merge(pq) {
+		t.Errorf("Expected merging a queue into itself to fail")
+	}
+	if err := verifyPriorityQueue(pq); err != nil {
+		t.Fatalf("Invariant violated after Merge: %v", err)
+	}
+	if other.Contains(five) || !pq.DecreaseKey(five, 0) {
+		t.Errorf("Expected a merged item to belong to the queue it was merged into")
+	}
+	if got := slices.Collect(pq.All()); len(got) != 8 || got[0] != 0 {
+		t.Errorf("Expected All to yield 8 values starting with 0, got %v", got)
+	}
+	if got := drainPriorityQueue(pq); !slices.Equal(got, []int{0, 1, 2, 4, 6, 7, 8, 9}) {
+		t.Errorf("Expected [0 1 2 4 6 7 8 9] but got %v", got)
+	}
+}
+
+func TestPriorityQueueConcurrentItems(t *testing.T) {
+	// The items of one queue are handed to another queue while they are merged and popped, each
+	// queue under its own lock. Run with -race.
+	a, b := NewPriorityQueue(cmp.Compare[int]), NewPriorityQueue(cmp.Compare[int])
+	c := NewPriorityQueue(cmp.Compare[int])
+	items := a.Heapify(make([]int, 200))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		c.Merge(a)
+		for !c.IsEmpty() {
+			c.Pop()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for _, it := range items {
+			if b.Contains(it) || b.Update(it, 1) || b.DecreaseKey(it, -1) || b.Remove(it) {
+				t.Errorf("Expected operations on a foreign item to fail")
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	if !a.IsEmpty() || !b.IsEmpty() || !c.IsEmpty() {
+		t.Errorf("Expected all queues to be empty")
+	}
+}
+
+func TestPriorityQueueRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	pq := NewPriorityQueue(cmp.Compare[int])
+	var live []*PriorityQueueItem[int]
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(6); {
+		case op <= 1:
+			live = append(live, pq.Push(r.Intn(1000)))
+		case op == 2 && len(live) > 0:
+			at := r.Intn(len(live))
+			pq.Update(live[at], r.Intn(1000))
+		case op == 3 && len(live) > 0:
+			at := r.Intn(len(live))
+			pq.DecreaseKey(live[at], live[at].Value()-r.Intn(100))
+		case op == 4 && len(live) > 0:
+			at := r.Intn(len(live))
+			if !pq.Remove(live[at]) {
+				t.Fatalf("Remove of a live item failed")
+			}
+			live = slices.Delete(live, at, at+1)
+		case op == 5:
+			want := 0
+			for j, it := range live {
+				if j == 0 || it.Value() < want {
+					want = it.Value()
+				}
+			}
+			v, ok := pq.Pop()
+			if ok != (len(live) > 0) || ok && v != want {
+				t.Fatalf("Pop = (%d, %v), want %d", v, ok, want)
+			}
+			live = slices.DeleteFunc(live, func(it *PriorityQueueItem[int]) bool { return !pq.Contains(it) })
+		}
+		if err := verifyPriorityQueue(pq); err != nil {
+			t.Fatalf("Invariant violated after operation %d: %v", i, err)
+		}
+		if pq.Size() != len(live) {
+			t.Fatalf("Expected size %d but got %d", len(live), pq.Size())
+		}
+	}
+}
+
+func BenchmarkPriorityQueue(b *testing.B) {
+	const n = 1 << 12
+	values := rand.New(rand.NewSource(1)).Perm(n)
+	b.Run("Push", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pq := NewPriorityQueue(cmp.Compare[int])
+			for _, v := range values {
+				pq.Push(v)
+			}
+		}
+	})
+	b.Run("Heapify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewPriorityQueue(cmp.Compare[int]).Heapify(values)
+		}
+	})
+	b.Run("PushPop", func(b *testing.B) {
+		pq := NewPriorityQueue(cmp.Compare[int])
+		pq.Heapify(values)
+		for i := 0; i < b.N; i++ {
+			pq.Push(values[i%n])
+			pq.Pop()
+		}
+	})
+}
@@ -1,70 +1,64 @@
 package structs
 
-import "sync"
+import "iter"
 
-// Queue represents a thread-safe queue data structure.
-// It uses a mutex to ensure exclusive access to the underlying slice.
+// Queue represents a thread-safe queue data structure holding items of type T.
+// It is backed by a Deque, a ring buffer that grows and shrinks with the number of items, so
+// Enqueue and Dequeue take amortized O(1) time and the memory of the queue stays proportional to
+// its size, however many items pass through it.
 // The queue supports the operations Enqueue, Dequeue, Peek, IsEmpty, and Size.
 // Enqueue adds an item to the end of the queue.
 // Dequeue removes and returns the item from the front of the queue.
 // Peek returns the item from the front of the queue without removing it.
 // IsEmpty checks if the queue is empty and returns a boolean value.
 // Size returns the number of items in the queue.
-type Queue struct {
-	mu    sync.Mutex
-	items []interface{}
+type Queue[T any] struct {
+	items Deque[T]
 }
 
-// NewQueue creates a new instance of the Queue data structure with an empty list of items.
-func NewQueue() *Queue {
-	return &Queue{
-		items: make([]interface{}, 0),
-	}
+// NewQueue creates a new instance of the Queue data structure with no items of type T.
+func NewQueue[T any]() *Queue[T] {
+	return &Queue[T]{}
 }
 
-// Enqueue adds an item to the queue.
-// It acquires a lock on the queue, appends the item to the underlying slice,
-// and then releases the lock.
-func (q *Queue) Enqueue(item interface{}) {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	q.items = append(q.items, item)
+// Enqueue adds an item to the end of the queue.
+func (q *Queue[T]) Enqueue(item T) {
+	q.items.PushBack(item)
 }
 
 // Dequeue removes and returns the first item from the queue. If the queue is empty,
-// it returns nil. The method is thread-safe, and it uses a mutex to synchronize access
-// to the queue.
-func (q *Queue) Dequeue() interface{} {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	if len(q.items) == 0 {
-		return nil
-	}
-	item := q.items[0]
-	q.items = q.items[1:]
+// it returns the zero value of T. The method is thread-safe; the underlying Deque
+// synchronizes access to the items.
+func (q *Queue[T]) Dequeue() T {
+	item, _ := q.items.PopFront()
 	return item
 }
 
-// Peek returns the first element in the queue without removing it. If the queue is empty, it returns nil.
-func (q *Queue) Peek() interface{} {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	if len(q.items) == 0 {
-		return nil
-	}
-	return q.items[0]
+// Peek returns the first element in the queue without removing it. If the queue is empty,
+// it returns the zero value of T.
+func (q *Queue[T]) Peek() T {
+	item, _ := q.items.Front()
+	return item
 }
 
-// IsEmpty returns true if the queue is empty, false otherwise. It locks the queue for thread safety.
-func (q *Queue) IsEmpty() bool {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	return len(q.items) == 0
+// IsEmpty returns true if the queue is empty, false otherwise.
+func (q *Queue[T]) IsEmpty() bool {
+	return q.items.IsEmpty()
 }
 
 // Size returns the number of elements in the queue.
-func (q *Queue) Size() int {
-	q.mu.Lock()
-	defer q.mu.Unlock()
-	return len(q.items)
+func (q *Queue[T]) Size() int {
+	return q.items.Size()
+}
+
+// All returns an iterator over the items of the queue from the front to the back, which is the
+// order in which Dequeue would return them. The queue is locked while the loop runs; see the
+// package documentation.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return q.items.All()
+}
+
+// Backward returns an iterator over the items of the queue from the back to the front.
+func (q *Queue[T]) Backward() iter.Seq[T] {
+	return q.items.Backward()
 }
@@ -1,12 +1,13 @@
 package structs
 
 import (
+	"slices"
 	"testing"
 )
 
 func TestQueue(t *testing.T) {
 	t.Run("NewQueue", func(t *testing.T) {
-		q := NewQueue()
+		q := NewQueue[interface{}]()
 		if q == nil {
 			t.Errorf("NewQueue should never return nil")
 		}
@@ -16,7 +17,7 @@ func TestQueue(t *testing.T) {
 	})
 
 	t.Run("Enqueue", func(t *testing.T) {
-		q := NewQueue()
+		q := NewQueue[interface{}]()
 		q.Enqueue("test")
 		if q.IsEmpty() {
 			t.Errorf("Queue should not be empty after an Enqueue operation")
@@ -27,7 +28,7 @@ func TestQueue(t *testing.T) {
 	})
 
 	t.Run("Dequeue", func(t *testing.T) {
-		q := NewQueue()
+		q := NewQueue[interface{}]()
 		q.Enqueue("test")
 		item := q.Dequeue()
 		if item != "test" {
@@ -39,7 +40,7 @@ func TestQueue(t *testing.T) {
 	})
 
 	t.Run("Peek", func(t *testing.T) {
-		q := NewQueue()
+		q := NewQueue[interface{}]()
 		q.Enqueue("test")
 		item := q.Peek()
 		if item != "test" {
@@ -51,7 +52,7 @@ func TestQueue(t *testing.T) {
 	})
 
 	t.Run("IsEmpty", func(t *testing.T) {
-		q := NewQueue()
+		q := NewQueue[interface{}]()
 		if !q.IsEmpty() {
 			t.Errorf("Queue should be empty after initialization, got: %v", q.IsEmpty())
 		}
@@ -62,7 +63,7 @@ func TestQueue(t *testing.T) {
 	})
 
 	t.Run("Size", func(t *testing.T) {
-		q := NewQueue()
+		q := NewQueue[interface{}]()
 		if q.Size() != 0 {
 			t.Errorf("Size should be 0 after initialization, got: %d", q.Size())
 		}
@@ -71,4 +72,109 @@ func TestQueue(t *testing.T) {
 			t.Errorf("Size should be 1 after adding one item, got: %d", q.Size())
 		}
 	})
+
+	t.Run("Typed queue", func(t *testing.T) {
+		q := NewQueue[int]()
+		if item := q.Dequeue(); item != 0 {
+			t.Errorf("Dequeue on an empty Queue should return the zero value, got: %d", item)
+		}
+		q.Enqueue(1)
+		q.Enqueue(2)
+		if item := q.Dequeue(); item != 1 {
+			t.Errorf("Dequeue should return the first element Enqueued, got: %d", item)
+		}
+		if item := q.Peek(); item != 2 {
+			t.Errorf("Peek should return the remaining element, got: %d", item)
+		}
+	})
+}
+
+func TestQueueIterators(t *testing.T) {
+	q := NewQueue[string]()
+	for _, s := range []string{"a", "b", "c"} {
+		q.Enqueue(s)
+	}
+	q.Dequeue()
+	q.Enqueue("d")
+	if got := slices.Collect(q.All()); !slices.Equal(got, []string{"b", "c", "d"}) {
+		t.Errorf("All: expected [b c d] but got %v", got)
+	}
+	if got := slices.Collect(q.Backward()); !slices.Equal(got, []string{"d", "c", "b"}) {
+		t.Errorf("Backward: expected [d c b] but got %v", got)
+	}
+	for range q.All() {
+		break
+	}
+	if q.Size() != 3 {
+		t.Errorf("Expected the queue to be usable after an early break")
+	}
+}
+
+func TestQueueBoundedMemory(t *testing.T) {
+	q := NewQueue[[]byte]()
+	for i := 0; i < 100000; i++ {
+		q.Enqueue(make([]byte, 16))
+		if q.Size() > 100 {
+			q.Dequeue()
+		}
+	}
+	if got := len(q.items.ring.buf); got > 256 {
+		t.Errorf("Expected a queue of 100 items to use at most 256 slots but it uses %d", got)
+	}
+	for !q.IsEmpty() {
+		q.Dequeue()
+	}
+	if got := len(q.items.ring.buf); got != minDequeCapacity {
+		t.Errorf("Expected a drained queue to shrink to %d slots but it has %d", minDequeCapacity, got)
+	}
+}
+
+// sliceQueue is the slice-based queue that Queue used before it was backed by a Deque. It is kept
+// for BenchmarkQueueSteadyState to compare against.
+type sliceQueue[T any] struct {
+	items []T
+}
+
+func (q *sliceQueue[T]) enqueue(item T) {
+	q.items = append(q.items, item)
+}
+
+func (q *sliceQueue[T]) dequeue() T {
+	var zero T
+	item := q.items[0]
+	q.items[0] = zero
+	q.items = q.items[1:]
+	return item
+}
+
+// BenchmarkQueueSteadyState passes b.N items through a queue that holds 1000 items at any time.
+// The slots metric is the number of slots the queue has allocated at the end. Queue reuses its
+// slots and allocates nothing once it has grown, while the reslicing queue it replaced keeps
+// allocating new arrays as its items move through them, as the B/op column shows.
+func BenchmarkQueueSteadyState(b *testing.B) {
+	const window = 1000
+	b.Run("Queue", func(b *testing.B) {
+		b.ReportAllocs()
+		q := NewQueue[int]()
+		for i := 0; i < window; i++ {
+			q.Enqueue(i)
+		}
+		for i := 0; i < b.N; i++ {
+			q.Enqueue(i)
+			q.Dequeue()
+		}
+		b.ReportMetric(float64(len(q.items.ring.buf)), "slots")
+	})
+	b.Run("ResliceQueue", func(b *testing.B) {
+		b.ReportAllocs()
+		q := &sliceQueue[int]{}
+		for i := 0; i < window; i++ {
+			q.enqueue(i)
+		}
+		for i := 0; i < b.N; i++ {
+			q.enqueue(i)
+			q.dequeue()
+		}
+		b.ReportMetric(float64(cap(q.items)), "slots")
+	})
 }
@@ -1,6 +1,10 @@
 package structs
 
-import "sync"
+import (
+	"iter"
+	"math/bits"
+	"sync"
+)
 
 // RED is a constant of type bool representing the color red.
 // BLACK is a constant of type bool representing the color black.
@@ -11,15 +15,20 @@ const (
 
 // RBNode represents a node in a Red-Black Tree.
 // It contains the value, color, left child, right child, and parent of the node.
-type RBNode struct {
-	Value  interface{}
+// Each node also records the number of nodes in its subtree, which makes Select and Rank
+// run in O(log N).
+type RBNode[T any] struct {
+	Value  T
 	Color  bool
-	Left   *RBNode
-	Right  *RBNode
-	Parent *RBNode
+	Left   *RBNode[T]
+	Right  *RBNode[T]
+	Parent *RBNode[T]
+	size   int
 }
 
-// RedBlackTree represents a red-black tree data structure.
+// RedBlackTree represents a red-black tree data structure holding values of type T.
+// Besides insertion, deletion and search it supports ordered navigation with Min, Max,
+// Floor, Ceiling, Predecessor and Successor.
 //
 // Red-black trees are self-balancing binary search trees that provide
 // efficient insertion, deletion, and search operations. Each node of
@@ -28,21 +37,26 @@ type RBNode struct {
 // node.
 //
 // The RedBlackTree type consists of a mutex to handle concurrent access,
-// a root node representing the top of the tree, and a "less" function
-// used to compare the values of the nodes. The "less" function should
-// return true if the first value is less than the second value.
-type RedBlackTree struct {
-	mu   sync.Mutex
-	root *RBNode
-	less func(a, b interface{}) bool
+// a root node representing the top of the tree, and a "cmp" function
+// used to compare the values of the nodes. The "cmp" function should
+// return a negative number if the first value is less than the second value,
+// zero if they are equal, and a positive number otherwise.
+type RedBlackTree[T any] struct {
+	mu      sync.Mutex
+	root    *RBNode[T]
+	cmp     func(a, b T) int
+	size    int
+	augment func(node *RBNode[T])
+	discard func(node *RBNode[T])
 }
 
 // NewRedBlackTree creates a new Red-Black Tree with the specified comparison function.
-// The comparison function takes two interface{} values, a and b, and returns true if a is less than b.
+// The comparison function takes two values of type T, a and b, and returns a negative number if a is less than b,
+// zero if they are equal, and a positive number if a is greater than b, like cmp.Compare.
 // The Red-Black Tree is returned as a pointer to a RedBlackTree struct.
-func NewRedBlackTree(less func(a, b interface{}) bool) *RedBlackTree {
-	return &RedBlackTree{
-		less: less,
+func NewRedBlackTree[T any](cmp func(a, b T) int) *RedBlackTree[T] {
+	return &RedBlackTree[T]{
+		cmp: cmp,
 	}
 }
 
@@ -52,16 +66,18 @@ func NewRedBlackTree(less func(a, b interface{}) bool) *RedBlackTree {
 // delegates the insertion to the insertNode function to find the appropriate
 // position for the new node. After the insertion, the fixInsert function is
 // called to restore the red-black tree properties.
-func (rbt *RedBlackTree) Insert(value interface{}) {
+func (rbt *RedBlackTree[T]) Insert(value T) {
 	rbt.mu.Lock()
 	defer rbt.mu.Unlock()
-	newNode := &RBNode{Value: value, Color: RED}
+	newNode := &RBNode[T]{Value: value, Color: RED}
 	if rbt.root == nil {
 		rbt.root = newNode
 	} else {
 		rbt.insertNode(rbt.root, newNode)
 	}
+	rbt.updatePath(newNode)
 	rbt.fixInsert(newNode)
+	rbt.size++
 }
 
 // insertNode inserts a new node into the RedBlackTree.
@@ -76,8 +92,8 @@ func (rbt *RedBlackTree) Insert(value interface{}) {
 // - node: The new node to be inserted into the sub-tree.
 // Complexity: O(log n), where n is the number of nodes in the tree.
 // The method does not return anything.
-func (rbt *RedBlackTree) insertNode(root, node *RBNode) {
-	if rbt.less(node.Value, root.Value) {
+func (rbt *RedBlackTree[T]) insertNode(root, node *RBNode[T]) {
+	if rbt.cmp(node.Value, root.Value) < 0 {
 		if root.Left == nil {
 			root.Left = node
 			node.Parent = root
@@ -98,7 +114,7 @@ func (rbt *RedBlackTree) insertNode(root, node *RBNode) {
 // the properties of a Red-Black Tree are maintained. It starts from the newly
 // inserted node and traverses up the tree, performing rotations and color changes
 // as necessary. The fixInsert method is called internally by the Insert method.
-func (rbt *RedBlackTree) fixInsert(node *RBNode) {
+func (rbt *RedBlackTree[T]) fixInsert(node *RBNode[T]) {
 	for node != rbt.root && node.Parent.Color == RED {
 		if node.Parent == node.Parent.Parent.Left {
 			uncle := node.Parent.Parent.Right
@@ -140,7 +156,7 @@ func (rbt *RedBlackTree) fixInsert(node *RBNode) {
 // rotateLeft performs a left rotation on the specified node in a Red-Black Tree.
 // The right child of the node becomes its parent, and the left child of the right child becomes the right child of the original node.
 // The node's parent and the original right child's parent are adjusted accordingly.
-func (rbt *RedBlackTree) rotateLeft(node *RBNode) {
+func (rbt *RedBlackTree[T]) rotateLeft(node *RBNode[T]) {
 	right := node.Right
 	node.Right = right.Left
 	if right.Left != nil {
@@ -156,6 +172,8 @@ func (rbt *RedBlackTree) rotateLeft(node *RBNode) {
 	}
 	right.Left = node
 	node.Parent = right
+	rbt.update(node)
+	rbt.update(right)
 }
 
 // rotateRight performs a right rotation on the Red-Black Tree with the specified node as the pivot.
@@ -164,7 +182,7 @@ func (rbt *RedBlackTree) rotateLeft(node *RBNode) {
 // If the node has a parent, the new parent will replace the node as its parent's left or right child accordingly.
 // If the node is the root of the tree, the new parent becomes the root.
 // Any child or parent references affected during the rotation are adjusted accordingly.
-func (rbt *RedBlackTree) rotateRight(node *RBNode) {
+func (rbt *RedBlackTree[T]) rotateRight(node *RBNode[T]) {
 	left := node.Left
 	node.Left = left.Right
 	if left.Right != nil {
@@ -180,13 +198,15 @@ func (rbt *RedBlackTree) rotateRight(node *RBNode) {
 	}
 	left.Right = node
 	node.Parent = left
+	rbt.update(node)
+	rbt.update(left)
 }
 
 // Search searches for a value in the Red-Black Tree and returns true if the value is found, false otherwise. The search
 // operation is performed by calling the `searchNode` method recursively on the root node of the Red-Black Tree.
 // The method acquires a lock and releases it before returning.
 // The time complexity of the search operation is O(log N), where N is the number of nodes in the tree.
-func (rbt *RedBlackTree) Search(value interface{}) bool {
+func (rbt *RedBlackTree[T]) Search(value T) bool {
 	rbt.mu.Lock()
 	defer rbt.mu.Unlock()
 	return rbt.searchNode(rbt.root, value)
@@ -195,15 +215,17 @@ func (rbt *RedBlackTree) Search(value interface{}) bool {
 // searchNode searches for a node with the given value in the Red-Black Tree.
 // It starts the search from the provided node and recursively traverses the tree,
 // comparing the values and moving left or right based on the comparison.
+// Two values are equal when the cmp function returns zero for them.
 // Returns true if the node with the given value is found, false otherwise.
-func (rbt *RedBlackTree) searchNode(node *RBNode, value interface{}) bool {
+func (rbt *RedBlackTree[T]) searchNode(node *RBNode[T], value T) bool {
 	if node == nil {
 		return false
 	}
-	if node.Value == value {
+	c := rbt.cmp(value, node.Value)
+	if c == 0 {
 		return true
 	}
-	if rbt.less(value, node.Value) {
+	if c < 0 {
 		return rbt.searchNode(node.Left, value)
 	}
 	return rbt.searchNode(node.Right, value)
@@ -214,7 +236,7 @@ func (rbt *RedBlackTree) searchNode(node *RBNode, value interface{}) bool {
 // and finally the right subtree. The function f is called on each node's value during traversal.
 // The RedBlackTree's mutex is acquired before traversal and released after traversal is completed.
 // This method can be used to perform an in-order traversal and apply a function to each value in the tree.
-func (rbt *RedBlackTree) InOrder(f func(value interface{})) {
+func (rbt *RedBlackTree[T]) InOrder(f func(value T)) {
 	rbt.mu.Lock()
 	defer rbt.mu.Unlock()
 	rbt.inOrderTraverse(rbt.root, f)
@@ -222,10 +244,459 @@ func (rbt *RedBlackTree) InOrder(f func(value interface{})) {
 
 // inOrderTraverse performs an in-order traversal of the Red-Black Tree starting from the given node.
 // It applies the provided function to each value in the tree in ascending order.
-func (rbt *RedBlackTree) inOrderTraverse(node *RBNode, f func(value interface{})) {
+func (rbt *RedBlackTree[T]) inOrderTraverse(node *RBNode[T], f func(value T)) {
 	if node != nil {
 		rbt.inOrderTraverse(node.Left, f)
 		f(node.Value)
 		rbt.inOrderTraverse(node.Right, f)
 	}
 }
+
+// Delete removes one node whose value is equal to the given value according to the cmp
+// function and reports whether such a node was found. After unlinking the node, the
+// fixDelete function is called to restore the red-black tree properties.
+// The time complexity of the delete operation is O(log N).
+func (rbt *RedBlackTree[T]) Delete(value T) bool {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	node := rbt.findNode(value)
+	if node == nil {
+		return false
+	}
+	rbt.deleteNode(node)
+	rbt.size--
+	if rbt.discard != nil {
+		rbt.discard(node)
+	}
+	return true
+}
+
+// findNode returns a node whose value is equal to the given value according to the cmp
+// function, or nil if there is no such node.
+func (rbt *RedBlackTree[T]) findNode(value T) *RBNode[T] {
+	node := rbt.root
+	for node != nil {
+		c := rbt.cmp(value, node.Value)
+		if c == 0 {
+			return node
+		}
+		if c < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return nil
+}
+
+// deleteNode unlinks the node z from the tree. If z has two children, it is replaced by its
+// in-order successor y, which is unlinked from its own position first. If the node that
+// was physically removed from its position was black, one path of the tree has lost a black
+// node and fixDelete is called on the node x that took its place. Because leaves are
+// represented by nil, the parent of x is tracked separately. Every subtree that lost a node
+// lies on the path from the parent of x to the root, so that path is updated before fixing.
+func (rbt *RedBlackTree[T]) deleteNode(z *RBNode[T]) {
+	y := z
+	removedColor := y.Color
+	var x, xParent *RBNode[T]
+	if z.Left == nil {
+		x, xParent = z.Right, z.Parent
+		rbt.transplant(z, z.Right)
+	} else if z.Right == nil {
+		x, xParent = z.Left, z.Parent
+		rbt.transplant(z, z.Left)
+	} else {
+		y = minNode(z.Right)
+		removedColor = y.Color
+		x = y.Right
+		if y.Parent == z {
+			xParent = y
+		} else {
+			xParent = y.Parent
+			rbt.transplant(y, y.Right)
+			y.Right = z.Right
+			y.Right.Parent = y
+		}
+		rbt.transplant(z, y)
+		y.Left = z.Left
+		y.Left.Parent = y
+		y.Color = z.Color
+	}
+	rbt.updatePath(xParent)
+	if removedColor == BLACK {
+		rbt.fixDelete(x, xParent)
+	}
+}
+
+// update recomputes the subtree size of node from its children and, if the tree is augmented,
+// its aggregate. It must be called whenever the children of node change.
+func (rbt *RedBlackTree[T]) update(node *RBNode[T]) {
+	node.size = subtreeSize(node.Left) + subtreeSize(node.Right) + 1
+	if rbt.augment != nil {
+		rbt.augment(node)
+	}
+}
+
+// updatePath calls update on node and all of its ancestors.
+func (rbt *RedBlackTree[T]) updatePath(node *RBNode[T]) {
+	for ; node != nil; node = node.Parent {
+		rbt.update(node)
+	}
+}
+
+// subtreeSize returns the number of nodes in the subtree rooted at node.
+func subtreeSize[T any](node *RBNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// transplant replaces the subtree rooted at u with the subtree rooted at v.
+func (rbt *RedBlackTree[T]) transplant(u, v *RBNode[T]) {
+	if u.Parent == nil {
+		rbt.root = v
+	} else if u == u.Parent.Left {
+		u.Parent.Left = v
+	} else {
+		u.Parent.Right = v
+	}
+	if v != nil {
+		v.Parent = u.Parent
+	}
+}
+
+// fixDelete fixes the Red-Black Tree after a black node has been removed. The node x, which
+// may be nil, carries an extra black that is pushed up the tree by recoloring or resolved by
+// rotations around its parent, depending on the color of x's sibling and of the sibling's
+// children. The fixDelete method is called internally by the Delete method.
+func (rbt *RedBlackTree[T]) fixDelete(x, parent *RBNode[T]) {
+	for x != rbt.root && isBlack(x) {
+		if x == parent.Left {
+			sibling := parent.Right
+			if isRed(sibling) {
+				sibling.Color = BLACK
+				parent.Color = RED
+				rbt.rotateLeft(parent)
+				sibling = parent.Right
+			}
+			if isBlack(sibling.Left) && isBlack(sibling.Right) {
+				sibling.Color = RED
+				x, parent = parent, parent.Parent
+			} else {
+				if isBlack(sibling.Right) {
+					sibling.Left.Color = BLACK
+					sibling.Color = RED
+					rbt.rotateRight(sibling)
+					sibling = parent.Right
+				}
+				sibling.Color = parent.Color
+				parent.Color = BLACK
+				sibling.Right.Color = BLACK
+				rbt.rotateLeft(parent)
+				x, parent = rbt.root, nil
+			}
+		} else {
+			sibling := parent.Left
+			if isRed(sibling) {
+				sibling.Color = BLACK
+				parent.Color = RED
+				rbt.rotateRight(parent)
+				sibling = parent.Left
+			}
+			if isBlack(sibling.Left) && isBlack(sibling.Right) {
+				sibling.Color = RED
+				x, parent = parent, parent.Parent
+			} else {
+				if isBlack(sibling.Left) {
+					sibling.Right.Color = BLACK
+					sibling.Color = RED
+					rbt.rotateLeft(sibling)
+					sibling = parent.Left
+				}
+				sibling.Color = parent.Color
+				parent.Color = BLACK
+				sibling.Left.Color = BLACK
+				rbt.rotateRight(parent)
+				x, parent = rbt.root, nil
+			}
+		}
+	}
+	if x != nil {
+		x.Color = BLACK
+	}
+}
+
+// isRed reports whether node is red. Nil leaves are black.
+func isRed[T any](node *RBNode[T]) bool {
+	return node != nil && node.Color == RED
+}
+
+// isBlack reports whether node is black. Nil leaves are black.
+func isBlack[T any](node *RBNode[T]) bool {
+	return node == nil || node.Color == BLACK
+}
+
+// minNode returns the leftmost node of the subtree rooted at node.
+func minNode[T any](node *RBNode[T]) *RBNode[T] {
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node
+}
+
+// maxNode returns the rightmost node of the subtree rooted at node.
+func maxNode[T any](node *RBNode[T]) *RBNode[T] {
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
+
+// nextNode returns the in-order successor of node, or nil if node holds the largest value.
+func nextNode[T any](node *RBNode[T]) *RBNode[T] {
+	if node.Right != nil {
+		return minNode(node.Right)
+	}
+	for node.Parent != nil && node == node.Parent.Right {
+		node = node.Parent
+	}
+	return node.Parent
+}
+
+// prevNode returns the in-order predecessor of node, or nil if node holds the smallest value.
+func prevNode[T any](node *RBNode[T]) *RBNode[T] {
+	if node.Left != nil {
+		return maxNode(node.Left)
+	}
+	for node.Parent != nil && node == node.Parent.Left {
+		node = node.Parent
+	}
+	return node.Parent
+}
+
+// Len returns the number of values stored in the tree.
+func (rbt *RedBlackTree[T]) Len() int {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	return rbt.size
+}
+
+// Min returns the smallest value in the tree. The boolean result is false if the tree is empty.
+func (rbt *RedBlackTree[T]) Min() (T, bool) {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	if rbt.root == nil {
+		var zero T
+		return zero, false
+	}
+	return minNode(rbt.root).Value, true
+}
+
+// Max returns the largest value in the tree. The boolean result is false if the tree is empty.
+func (rbt *RedBlackTree[T]) Max() (T, bool) {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	if rbt.root == nil {
+		var zero T
+		return zero, false
+	}
+	return maxNode(rbt.root).Value, true
+}
+
+// Floor returns the largest value in the tree that is less than or equal to the given value.
+// The boolean result is false if there is no such value.
+func (rbt *RedBlackTree[T]) Floor(value T) (T, bool) {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	return nodeValue(rbt.floorNode(value, true))
+}
+
+// Ceiling returns the smallest value in the tree that is greater than or equal to the given
+// value. The boolean result is false if there is no such value.
+func (rbt *RedBlackTree[T]) Ceiling(value T) (T, bool) {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	return nodeValue(rbt.ceilingNode(value, true))
+}
+
+// Predecessor returns the largest value in the tree that is strictly less than the given value.
+// The given value does not need to be present in the tree. The boolean result is false if there
+// is no such value.
+func (rbt *RedBlackTree[T]) Predecessor(value T) (T, bool) {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	return nodeValue(rbt.floorNode(value, false))
+}
+
+// Successor returns the smallest value in the tree that is strictly greater than the given value.
+// The given value does not need to be present in the tree. The boolean result is false if there
+// is no such value.
+func (rbt *RedBlackTree[T]) Successor(value T) (T, bool) {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	return nodeValue(rbt.ceilingNode(value, false))
+}
+
+// floorNode returns the node with the largest value less than the given value, or less than
+// or equal to it if inclusive is set. It returns nil if there is no such node.
+func (rbt *RedBlackTree[T]) floorNode(value T, inclusive bool) *RBNode[T] {
+	var best *RBNode[T]
+	node := rbt.root
+	for node != nil {
+		c := rbt.cmp(node.Value, value)
+		if c < 0 || (inclusive && c == 0) {
+			best = node
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return best
+}
+
+// ceilingNode returns the node with the smallest value greater than the given value, or
+// greater than or equal to it if inclusive is set. It returns nil if there is no such node.
+func (rbt *RedBlackTree[T]) ceilingNode(value T, inclusive bool) *RBNode[T] {
+	var best *RBNode[T]
+	node := rbt.root
+	for node != nil {
+		c := rbt.cmp(node.Value, value)
+		if c > 0 || (inclusive && c == 0) {
+			best = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return best
+}
+
+// Select returns the k-th smallest value in the tree, counting from zero. The boolean result is
+// false if k is out of range. The search uses the subtree sizes stored in the nodes and takes
+// O(log N) time.
+func (rbt *RedBlackTree[T]) Select(k int) (T, bool) {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	return nodeValue(selectNode(rbt.root, k))
+}
+
+// Rank returns the number of values in the tree that are strictly less than the given value.
+// The value does not need to be present. The count is accumulated from the subtree sizes along
+// a single root-to-leaf path and takes O(log N) time.
+func (rbt *RedBlackTree[T]) Rank(value T) int {
+	rbt.mu.Lock()
+	defer rbt.mu.Unlock()
+	rank := 0
+	node := rbt.root
+	for node != nil {
+		if rbt.cmp(node.Value, value) < 0 {
+			rank += subtreeSize(node.Left) + 1
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return rank
+}
+
+// selectNode returns the node holding the k-th smallest value of the subtree rooted at node,
+// or nil if k is out of range.
+func selectNode[T any](node *RBNode[T], k int) *RBNode[T] {
+	if k < 0 || k >= subtreeSize(node) {
+		return nil
+	}
+	for node != nil {
+		left := subtreeSize(node.Left)
+		if k < left {
+			node = node.Left
+		} else if k == left {
+			return node
+		} else {
+			k -= left + 1
+			node = node.Right
+		}
+	}
+	return nil
+}
+
+// buildSorted replaces the contents of an empty, unaugmented tree with the given values, which
+// must be in ascending order, in O(N) time. The middle value becomes the root and the halves on
+// either side become its subtrees, recursively, so all nil children lie on the two deepest
+// levels; coloring the nodes on the deepest level red and all others black then gives every path
+// the same number of black nodes without two red nodes in a row.
+func (rbt *RedBlackTree[T]) buildSorted(values []T) {
+	redDepth := bits.Len(uint(len(values))) - 1
+	var build func(values []T, depth int, parent *RBNode[T]) *RBNode[T]
+	build = func(values []T, depth int, parent *RBNode[T]) *RBNode[T] {
+		if len(values) == 0 {
+			return nil
+		}
+		mid := len(values) / 2
+		node := &RBNode[T]{Value: values[mid], Color: depth == redDepth, Parent: parent, size: len(values)}
+		node.Left = build(values[:mid], depth+1, node)
+		node.Right = build(values[mid+1:], depth+1, node)
+		return node
+	}
+	rbt.root = build(values, 0, nil)
+	if rbt.root != nil {
+		rbt.root.Color = BLACK
+	}
+	rbt.size = len(values)
+}
+
+// nodeValue returns the value of node and true, or the zero value of T and false if node is nil.
+func nodeValue[T any](node *RBNode[T]) (T, bool) {
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.Value, true
+}
+
+// All returns an iterator over the values of the tree in ascending order. The tree is locked
+// while the loop runs; see the package documentation.
+func (rbt *RedBlackTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		rbt.mu.Lock()
+		defer rbt.mu.Unlock()
+		if rbt.root == nil {
+			return
+		}
+		for node := minNode(rbt.root); node != nil; node = nextNode(node) {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of the tree in descending order.
+func (rbt *RedBlackTree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		rbt.mu.Lock()
+		defer rbt.mu.Unlock()
+		if rbt.root == nil {
+			return
+		}
+		for node := maxNode(rbt.root); node != nil; node = prevNode(node) {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the values v with lo <= v < hi in ascending order. The first
+// value is located in O(log N) time and each further value is reached in amortized constant time.
+func (rbt *RedBlackTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		rbt.mu.Lock()
+		defer rbt.mu.Unlock()
+		for node := rbt.ceilingNode(lo, true); node != nil && rbt.cmp(node.Value, hi) < 0; node = nextNode(node) {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
@@ -1,14 +1,14 @@
 package structs
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
 	"sort"
 	"testing"
 )
 
-func lessInt(a, b interface{}) bool {
-	return a.(int) < b.(int)
-}
-
 func TestRedBlackTree(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -42,7 +42,7 @@ func TestRedBlackTree(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			rbt := NewRedBlackTree(lessInt)
+			rbt := NewRedBlackTree(cmp.Compare[int])
 			for _, val := range c.insertInt {
 				rbt.Insert(val)
 			}
@@ -53,8 +53,8 @@ func TestRedBlackTree(t *testing.T) {
 				}
 			}
 			resultInOrder := make([]int, 0)
-			rbt.InOrder(func(value interface{}) {
-				resultInOrder = append(resultInOrder, value.(int))
+			rbt.InOrder(func(value int) {
+				resultInOrder = append(resultInOrder, value)
 			})
 			if !sort.IntsAreSorted(resultInOrder) {
 				t.Errorf("Values are not sorted in InOrder traversal")
@@ -67,3 +67,267 @@ func TestRedBlackTree(t *testing.T) {
 		})
 	}
 }
+
+func TestRedBlackTreeCmpEquality(t *testing.T) {
+	type entry struct {
+		key   int
+		label string
+	}
+	rbt := NewRedBlackTree(func(a, b entry) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	rbt.Insert(entry{key: 1, label: "one"})
+	rbt.Insert(entry{key: 2, label: "two"})
+
+	// Equality is derived from cmp, so the label does not take part in the search.
+	if !rbt.Search(entry{key: 2}) {
+		t.Errorf("Expected entry with key 2 to be found")
+	}
+	if rbt.Search(entry{key: 3}) {
+		t.Errorf("Expected entry with key 3 not to be found")
+	}
+}
+
+// verifyRedBlackTree checks the invariants of a red-black tree: values are in search tree
+// order, parent links are consistent, the root is black, no red node has a red child, every
+// path from the root to a leaf has the same number of black nodes, and the size and the subtree
+// sizes are correct.
+func verifyRedBlackTree[T any](rbt *RedBlackTree[T]) error {
+	if isRed(rbt.root) {
+		return errors.New("root is red")
+	}
+	if rbt.root != nil && rbt.root.Parent != nil {
+		return errors.New("root has a parent")
+	}
+	count := 0
+	var walk func(node *RBNode[T]) (int, error)
+	walk = func(node *RBNode[T]) (int, error) {
+		if node == nil {
+			return 1, nil
+		}
+		count++
+		for _, child := range []*RBNode[T]{node.Left, node.Right} {
+			if child == nil {
+				continue
+			}
+			if child.Parent != node {
+				return 0, fmt.Errorf("broken parent link below %v", node.Value)
+			}
+			if isRed(node) && isRed(child) {
+				return 0, fmt.Errorf("red node %v has a red child", node.Value)
+			}
+		}
+		if node.size != subtreeSize(node.Left)+subtreeSize(node.Right)+1 {
+			return 0, fmt.Errorf("wrong subtree size %d at %v", node.size, node.Value)
+		}
+		if node.Left != nil && rbt.cmp(node.Left.Value, node.Value) > 0 {
+			return 0, fmt.Errorf("left child %v is greater than %v", node.Left.Value, node.Value)
+		}
+		if node.Right != nil && rbt.cmp(node.Right.Value, node.Value) < 0 {
+			return 0, fmt.Errorf("right child %v is less than %v", node.Right.Value, node.Value)
+		}
+		left, err := walk(node.Left)
+		if err != nil {
+			return 0, err
+		}
+		right, err := walk(node.Right)
+		if err != nil {
+			return 0, err
+		}
+		if left != right {
+			return 0, fmt.Errorf("black heights differ below %v: %d and %d", node.Value, left, right)
+		}
+		if isBlack(node) {
+			left++
+		}
+		return left, nil
+	}
+	if _, err := walk(rbt.root); err != nil {
+		return err
+	}
+	if count != rbt.size {
+		return fmt.Errorf("size is %d but tree holds %d nodes", rbt.size, count)
+	}
+	return nil
+}
+
+func TestRedBlackTreeDelete(t *testing.T) {
+	cases := []struct {
+		name      string
+		insertInt []int
+		deleteInt []int
+		deleted   []bool
+		inOrder   []int
+	}{
+		{
+			name:      "EmptyTree",
+			insertInt: []int{},
+			deleteInt: []int{1},
+			deleted:   []bool{false},
+			inOrder:   []int{},
+		},
+		{
+			name:      "Root",
+			insertInt: []int{10},
+			deleteInt: []int{10, 10},
+			deleted:   []bool{true, false},
+			inOrder:   []int{},
+		},
+		{
+			name:      "LeafInnerAndRoot",
+			insertInt: []int{50, 30, 70, 20, 40, 25, 60, 80},
+			deleteInt: []int{25, 30, 50, 100},
+			deleted:   []bool{true, true, true, false},
+			inOrder:   []int{20, 40, 60, 70, 80},
+		},
+		{
+			name:      "Duplicates",
+			insertInt: []int{5, 5, 5, 3},
+			deleteInt: []int{5},
+			deleted:   []bool{true},
+			inOrder:   []int{3, 5, 5},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rbt := NewRedBlackTree(cmp.Compare[int])
+			for _, val := range c.insertInt {
+				rbt.Insert(val)
+			}
+			for idx, val := range c.deleteInt {
+				if res := rbt.Delete(val); res != c.deleted[idx] {
+					t.Errorf("Expected delete result %v for %d, but got %v", c.deleted[idx], val, res)
+				}
+				if err := verifyRedBlackTree(rbt); err != nil {
+					t.Fatalf("Invariant violated after deleting %d: %v", val, err)
+				}
+			}
+			resultInOrder := make([]int, 0)
+			rbt.InOrder(func(value int) {
+				resultInOrder = append(resultInOrder, value)
+			})
+			if fmt.Sprint(resultInOrder) != fmt.Sprint(c.inOrder) {
+				t.Errorf("Expected InOrder traversal %v, but got %v", c.inOrder, resultInOrder)
+			}
+			if rbt.Len() != len(c.inOrder) {
+				t.Errorf("Expected length %d, but got %d", len(c.inOrder), rbt.Len())
+			}
+		})
+	}
+}
+
+func TestRedBlackTreeRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rbt := NewRedBlackTree(cmp.Compare[int])
+	counts := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		v := r.Intn(200)
+		if r.Intn(3) == 0 {
+			deleted := rbt.Delete(v)
+			if deleted != (counts[v] > 0) {
+				t.Fatalf("Delete(%d) = %v, but value count is %d", v, deleted, counts[v])
+			}
+			if deleted {
+				counts[v]--
+			}
+		} else {
+			rbt.Insert(v)
+			counts[v]++
+		}
+		if err := verifyRedBlackTree(rbt); err != nil {
+			t.Fatalf("Invariant violated after operation %d: %v", i, err)
+		}
+	}
+	for v := 0; v < 200; v++ {
+		if rbt.Search(v) != (counts[v] > 0) {
+			t.Errorf("Search(%d) disagrees with value count %d", v, counts[v])
+		}
+	}
+}
+
+func TestRedBlackTreeNavigation(t *testing.T) {
+	rbt := NewRedBlackTree(cmp.Compare[int])
+	if _, ok := rbt.Min(); ok {
+		t.Errorf("Expected Min of an empty tree to report false")
+	}
+	if _, ok := rbt.Max(); ok {
+		t.Errorf("Expected Max of an empty tree to report false")
+	}
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80} {
+		rbt.Insert(v)
+	}
+
+	if v, ok := rbt.Min(); !ok || v != 20 {
+		t.Errorf("Expected Min 20, but got %v (%v)", v, ok)
+	}
+	if v, ok := rbt.Max(); !ok || v != 80 {
+		t.Errorf("Expected Max 80, but got %v (%v)", v, ok)
+	}
+
+	cases := []struct {
+		name  string
+		query func(int) (int, bool)
+		input int
+		want  int
+		found bool
+	}{
+		{"FloorPresent", rbt.Floor, 40, 40, true},
+		{"FloorBetween", rbt.Floor, 45, 40, true},
+		{"FloorBelow", rbt.Floor, 10, 0, false},
+		{"CeilingPresent", rbt.Ceiling, 60, 60, true},
+		{"CeilingBetween", rbt.Ceiling, 45, 50, true},
+		{"CeilingAbove", rbt.Ceiling, 90, 0, false},
+		{"PredecessorPresent", rbt.Predecessor, 50, 40, true},
+		{"PredecessorBetween", rbt.Predecessor, 55, 50, true},
+		{"PredecessorOfMin", rbt.Predecessor, 20, 0, false},
+		{"SuccessorPresent", rbt.Successor, 50, 60, true},
+		{"SuccessorBetween", rbt.Successor, 65, 70, true},
+		{"SuccessorOfMax", rbt.Successor, 80, 0, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, found := c.query(c.input)
+			if got != c.want || found != c.found {
+				t.Errorf("Expected (%d, %v), but got (%d, %v)", c.want, c.found, got, found)
+			}
+		})
+	}
+}
+
+func TestRedBlackTreeSelectRank(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	rbt := NewRedBlackTree(cmp.Compare[int])
+	values := make([]int, 0)
+	for i := 0; i < 500; i++ {
+		v := r.Intn(1000)
+		rbt.Insert(v)
+		values = append(values, v)
+	}
+	for i := 0; i < 200; i++ {
+		idx := r.Intn(len(values))
+		rbt.Delete(values[idx])
+		values = append(values[:idx], values[idx+1:]...)
+	}
+	if err := verifyRedBlackTree(rbt); err != nil {
+		t.Fatalf("Invariant violated: %v", err)
+	}
+	sort.Ints(values)
+
+	for k, want := range values {
+		if got, ok := rbt.Select(k); !ok || got != want {
+			t.Fatalf("Select(%d) = (%d, %v), want (%d, true)", k, got, ok, want)
+		}
+	}
+	for _, k := range []int{-1, len(values)} {
+		if _, ok := rbt.Select(k); ok {
+			t.Errorf("Select(%d) should be out of range", k)
+		}
+	}
+	for v := -1; v <= 1001; v++ {
+		want := sort.SearchInts(values, v)
+		if got := rbt.Rank(v); got != want {
+			t.Fatalf("Rank(%d) = %d, want %d", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package structs
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// ring is a circular buffer, the storage shared by Deque and RingBuffer. The items are stored in
+// buf starting at index head and wrapping around at the end of buf. It is not safe for
+// concurrent use and does not grow by itself; its owners lock it and decide when to resize it.
+type ring[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+// slot returns the index in buf of the item at position i, counting from the front.
+func (r *ring[T]) slot(i int) int {
+	j := r.head + i
+	if j >= len(r.buf) {
+		j -= len(r.buf)
+	}
+	return j
+}
+
+// at returns the item at position i, which must be in [0, size).
+func (r *ring[T]) at(i int) T {
+	return r.buf[r.slot(i)]
+}
+
+// pushBack adds an item at the back. The buffer must not be full.
+func (r *ring[T]) pushBack(item T) {
+	r.buf[r.slot(r.size)] = item
+	r.size++
+}
+
+// pushFront adds an item at the front. The buffer must not be full.
+func (r *ring[T]) pushFront(item T) {
+	r.head--
+	if r.head < 0 {
+		r.head += len(r.buf)
+	}
+	r.buf[r.head] = item
+	r.size++
+}
+
+// popFront removes and returns the item at the front. The buffer must not be empty. The slot is
+// cleared, so that the buffer does not keep the item alive.
+func (r *ring[T]) popFront() T {
+	var zero T
+	item := r.buf[r.head]
+	r.buf[r.head] = zero
+	r.head = r.slot(1)
+	r.size--
+	return item
+}
+
+// popBack removes and returns the item at the back. The buffer must not be empty.
+func (r *ring[T]) popBack() T {
+	var zero T
+	j := r.slot(r.size - 1)
+	item := r.buf[j]
+	r.buf[j] = zero
+	r.size--
+	return item
+}
+
+// resize moves the items into a new buffer with the given capacity, which must hold them, so
+// that they start at index 0.
+func (r *ring[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	if r.head+r.size <= len(r.buf) {
+		copy(buf, r.buf[r.head:r.head+r.size])
+	} else {
+		n := copy(buf, r.buf[r.head:])
+		copy(buf[n:], r.buf[:r.size-n])
+	}
+	r.buf = buf
+	r.head = 0
+}
+
+// clear removes all items and clears their slots, keeping the capacity.
+func (r *ring[T]) clear() {
+	clear(r.buf)
+	r.head = 0
+	r.size = 0
+}
+
+// all returns an iterator over the items from the front to the back.
+func (r *ring[T]) all() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < r.size; i++ {
+			if !yield(r.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+// backward returns an iterator over the items from the back to the front.
+func (r *ring[T]) backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := r.size - 1; i >= 0; i-- {
+			if !yield(r.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+// OverflowPolicy decides what a full RingBuffer does with a new item.
+type OverflowPolicy int
+
+const (
+	// OverflowOverwrite makes room for the new item by discarding the oldest item.
+	OverflowOverwrite OverflowPolicy = iota
+	// OverflowReject keeps the buffer unchanged and discards the new item.
+	OverflowReject
+)
+
+// String returns the name of the policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowOverwrite:
+		return "overwrite"
+	case OverflowReject:
+		return "reject"
+	}
+	return "unknown"
+}
+
+// RingBuffer is a thread-safe first-in-first-out buffer with a fixed capacity, holding items of
+// type T. Its memory is allocated once, when it is created, and never grows, which makes it
+// suitable for keeping the latest items of a stream, such as recent log lines or samples, or for
+// bounding the backlog between a producer and a consumer.
+//
+// Push adds an item at the back and Pop removes the item at the front, both in O(1) time. What
+// Push does when the buffer is full depends on the OverflowPolicy the buffer was created with:
+// OverflowOverwrite discards the oldest item and OverflowReject discards the new one.
+type RingBuffer[T any] struct {
+	mu     sync.Mutex
+	ring   ring[T]
+	policy OverflowPolicy
+}
+
+// NewRingBuffer creates a new empty RingBuffer that holds up to capacity items and handles
+// overflow according to policy. It panics if capacity is less than 1.
+func NewRingBuffer[T any](capacity int, policy OverflowPolicy) *RingBuffer[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("structs: ring buffer capacity %d is less than 1", capacity))
+	}
+	return &RingBuffer[T]{ring: ring[T]{buf: make([]T, capacity)}, policy: policy}
+}
+
+// Push adds an item at the back of the buffer and reports whether it was stored. If the buffer
+// is full, the policy decides: OverflowOverwrite discards the item at the front and stores the new
+// item, and OverflowReject discards the new item and returns false.
+func (rb *RingBuffer[T]) Push(item T) bool {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if rb.ring.size == len(rb.ring.buf) {
+		if rb.policy == OverflowReject {
+			return false
+		}
+		rb.ring.popFront()
+	}
+	rb.ring.pushBack(item)
+	return true
+}
+
+// Pop removes and returns the item at the front of the buffer, the oldest one. The boolean
+// result is false if the buffer is empty.
+func (rb *RingBuffer[T]) Pop() (T, bool) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if rb.ring.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return rb.ring.popFront(), true
+}
+
+// Peek returns the item at the front of the buffer without removing it. The boolean result is
+// false if the buffer is empty.
+func (rb *RingBuffer[T]) Peek() (T, bool) {
+	return rb.At(0)
+}
+
+// At returns the item at position i, counting from zero at the front. The boolean result is
+// false if i is out of range.
+func (rb *RingBuffer[T]) At(i int) (T, bool) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	if i < 0 || i >= rb.ring.size {
+		var zero T
+		return zero, false
+	}
+	return rb.ring.at(i), true
+}
+
+// Size returns the number of items in the buffer.
+func (rb *RingBuffer[T]) Size() int {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	return rb.ring.size
+}
+
+// Capacity returns the maximum number of items the buffer holds.
+func (rb *RingBuffer[T]) Capacity() int {
+	return len(rb.ring.buf)
+}
+
+// IsEmpty returns true if the buffer holds no items, false otherwise.
+func (rb *RingBuffer[T]) IsEmpty() bool {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	return rb.ring.size == 0
+}
+
+// IsFull returns true if the buffer holds as many items as its capacity, false otherwise.
+func (rb *RingBuffer[T]) IsFull() bool {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	return rb.ring.size == len(rb.ring.buf)
+}
+
+// Clear removes all items from the buffer. The capacity stays the same.
+func (rb *RingBuffer[T]) Clear() {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	rb.ring.clear()
+}
+
+// All returns an iterator over the items of the buffer from the front to the back, oldest first.
+// The buffer is locked while the loop runs; see the package documentation.
+func (rb *RingBuffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		rb.mu.Lock()
+		defer rb.mu.Unlock()
+		rb.ring.all()(yield)
+	}
+}
+
+// Backward returns an iterator over the items of the buffer from the back to the front, newest
+// first.
+func (rb *RingBuffer[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		rb.mu.Lock()
+		defer rb.mu.Unlock()
+		rb.ring.backward()(yield)
+	}
+}
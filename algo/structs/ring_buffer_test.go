@@ -0,0 +1,109 @@
+package structs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingBuffer(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   OverflowPolicy
+		pushes   []int
+		pops     int
+		stored   []bool
+		expected []int
+	}{
+		{"Partial", OverflowOverwrite, []int{1, 2}, 0, []bool{true, true}, []int{1, 2}},
+		{"Overwrite", OverflowOverwrite, []int{1, 2, 3, 4, 5}, 0, []bool{true, true, true, true, true}, []int{3, 4, 5}},
+		{"Reject", OverflowReject, []int{1, 2, 3, 4, 5}, 0, []bool{true, true, true, false, false}, []int{1, 2, 3}},
+		{"PopThenWrap", OverflowReject, []int{1, 2, 3}, 2, []bool{true, true, true}, []int{3}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rb := NewRingBuffer[int](3, tc.policy)
+			for i, v := range tc.pushes {
+				if got := rb.Push(v); got != tc.stored[i] {
+					t.Errorf("Push(%d): expected %v but got %v", v, tc.stored[i], got)
+				}
+			}
+			for i := 0; i < tc.pops; i++ {
+				if v, ok := rb.Pop(); !ok || v != tc.pushes[i] {
+					t.Errorf("Pop: expected (%d, true) but got (%d, %v)", tc.pushes[i], v, ok)
+				}
+			}
+			if got := slices.Collect(rb.All()); !slices.Equal(got, tc.expected) {
+				t.Errorf("Expected %v but got %v", tc.expected, got)
+			}
+			reversed := slices.Clone(tc.expected)
+			slices.Reverse(reversed)
+			if got := slices.Collect(rb.Backward()); !slices.Equal(got, reversed) {
+				t.Errorf("Expected %v backwards but got %v", reversed, got)
+			}
+			for i, want := range tc.expected {
+				if got, ok := rb.At(i); !ok || got != want {
+					t.Errorf("At(%d): expected (%d, true) but got (%d, %v)", i, want, got, ok)
+				}
+			}
+			if rb.Size() != len(tc.expected) || rb.IsFull() != (len(tc.expected) == 3) {
+				t.Errorf("Expected size %d but got %d", len(tc.expected), rb.Size())
+			}
+		})
+	}
+}
+
+func TestRingBufferWrapAround(t *testing.T) {
+	rb := NewRingBuffer[int](4, OverflowReject)
+	next := 0
+	for round := 0; round < 10; round++ {
+		for rb.Push(next) {
+			next++
+		}
+		for i := 0; i < 3; i++ {
+			want := next - 4 + i
+			if v, ok := rb.Pop(); !ok || v != want {
+				t.Fatalf("Round %d: expected %d but got (%d, %v)", round, want, v, ok)
+			}
+		}
+	}
+	if v, ok := rb.Peek(); !ok || v != next-1 || rb.Size() != 1 {
+		t.Errorf("Expected the newest item %d to remain, got (%d, %v)", next-1, v, ok)
+	}
+	if rb.Capacity() != 4 || len(rb.ring.buf) != 4 {
+		t.Errorf("Expected the capacity to stay 4")
+	}
+}
+
+func TestRingBufferEmpty(t *testing.T) {
+	rb := NewRingBuffer[*int](2, OverflowOverwrite)
+	if _, ok := rb.Pop(); ok {
+		t.Errorf("Expected Pop of an empty buffer to report false")
+	}
+	if _, ok := rb.Peek(); ok {
+		t.Errorf("Expected Peek of an empty buffer to report false")
+	}
+	one, two, three := 1, 2, 3
+	rb.Push(&one)
+	rb.Push(&two)
+	rb.Push(&three)
+	rb.Pop()
+	for i, p := range rb.ring.buf {
+		if p == &one {
+			t.Errorf("Slot %d still holds the overwritten item", i)
+		}
+	}
+	rb.Clear()
+	if !rb.IsEmpty() || rb.Capacity() != 2 {
+		t.Errorf("Expected Clear to empty the buffer and keep its capacity")
+	}
+	if slices.ContainsFunc(rb.ring.buf, func(p *int) bool { return p != nil }) {
+		t.Errorf("Expected Clear to clear the slots")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for capacity 0")
+		}
+	}()
+	NewRingBuffer[int](0, OverflowReject)
+}
@@ -0,0 +1,203 @@
+package structs
+
+import (
+	"cmp"
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// testSet is what the tests below need from a set of ints. HashSet and TreeSet take and return
+// their own type in the set operations, so the tests are generic over the set type S.
+type testSet[S any] interface {
+	Add(value int) bool
+	Remove(value int) bool
+	Contains(value int) bool
+	IsEmpty() bool
+	Union(other S) S
+	Intersection(other S) S
+	Difference(other S) S
+	SymmetricDifference(other S) S
+	IsSubset(other S) bool
+	IsSuperset(other S) bool
+	Equal(other S) bool
+	All() iter.Seq[int]
+}
+
+// newIntTreeSet returns a new TreeSet of ints in ascending order holding the given values.
+func newIntTreeSet(values ...int) *TreeSet[int] {
+	return NewTreeSet(cmp.Compare[int], values...)
+}
+
+// setValues returns the values of a set in ascending order.
+func setValues[S testSet[S]](s S) []int {
+	values := slices.Collect(s.All())
+	slices.Sort(values)
+	return values
+}
+
+func TestSetAlgebra(t *testing.T) {
+	t.Run("HashSet", func(t *testing.T) { testSetAlgebra(t, NewHashSet[int], verifyHashSet) })
+	t.Run("TreeSet", func(t *testing.T) { testSetAlgebra(t, newIntTreeSet, verifyTreeSet) })
+}
+
+func testSetAlgebra[S testSet[S]](t *testing.T, newSet func(values ...int) S, verify func(S) error) {
+	tests := []struct {
+		name                            string
+		a, b                            []int
+		union, intersection, difference []int
+		symmetricDifference             []int
+	}{
+		{"Empty", nil, nil, nil, nil, nil, nil},
+		{"EmptyOther", []int{2, 1}, nil, []int{1, 2}, nil, []int{1, 2}, []int{1, 2}},
+		{"EmptySet", nil, []int{2, 1}, []int{1, 2}, nil, nil, []int{1, 2}},
+		{"Overlapping", []int{4, 3, 2, 1}, []int{5, 4, 3}, []int{1, 2, 3, 4, 5}, []int{3, 4}, []int{1, 2}, []int{1, 2, 5}},
+		{"Interleaved", []int{1, 3, 5}, []int{2, 3, 4}, []int{1, 2, 3, 4, 5}, []int{3}, []int{1, 5}, []int{1, 2, 4, 5}},
+		{"Disjoint", []int{1, 3}, []int{2, 4}, []int{1, 2, 3, 4}, nil, []int{1, 3}, []int{1, 2, 3, 4}},
+		{"Equal", []int{1, 2}, []int{2, 1}, []int{1, 2}, []int{1, 2}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newSet(tt.a...), newSet(tt.b...)
+			valuesA, valuesB := setValues(a), setValues(b)
+			results := []struct {
+				op   string
+				got  S
+				want []int
+			}{
+				{"Union", a.Union(b), tt.union},
+				{"Intersection", a.Intersection(b), tt.intersection},
+				{"Difference", a.Difference(b), tt.difference},
+				{"SymmetricDifference", a.SymmetricDifference(b), tt.symmetricDifference},
+			}
+			for _, r := range results {
+				if got := setValues(r.got); !slices.Equal(got, r.want) {
+					t.Errorf("%s = %v, want %v", r.op, got, r.want)
+				}
+				if err := verify(r.got); err != nil {
+					t.Errorf("%s: invariant violated: %v", r.op, err)
+				}
+			}
+			if !slices.Equal(setValues(a), valuesA) || !slices.Equal(setValues(b), valuesB) {
+				t.Errorf("the operands should not change")
+			}
+		})
+	}
+
+	t.Run("Self", func(t *testing.T) {
+		s := newSet(1, 2, 3)
+		if got := setValues(s.Union(s)); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("Union with itself = %v, want [1 2 3]", got)
+		}
+		if got := setValues(s.Intersection(s)); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("Intersection with itself = %v, want [1 2 3]", got)
+		}
+		if !s.Difference(s).IsEmpty() || !s.SymmetricDifference(s).IsEmpty() {
+			t.Errorf("Difference and SymmetricDifference with itself should be empty")
+		}
+	})
+}
+
+func TestSetSubset(t *testing.T) {
+	t.Run("HashSet", func(t *testing.T) { testSetSubset(t, NewHashSet[int], verifyHashSet) })
+	t.Run("TreeSet", func(t *testing.T) { testSetSubset(t, newIntTreeSet, verifyTreeSet) })
+}
+
+func testSetSubset[S testSet[S]](t *testing.T, newSet func(values ...int) S, verify func(S) error) {
+	tests := []struct {
+		name                      string
+		a, b                      []int
+		subset, superset, isEqual bool
+	}{
+		{"BothEmpty", nil, nil, true, true, true},
+		{"EmptySubset", nil, []int{1}, true, false, false},
+		{"ProperSubset", []int{1, 3}, []int{1, 2, 3}, true, false, false},
+		{"ProperSuperset", []int{1, 2, 3}, []int{2, 3}, false, true, false},
+		{"Equal", []int{1, 2, 3}, []int{3, 2, 1}, true, true, true},
+		{"SameSizeDifferent", []int{1, 2}, []int{1, 3}, false, false, false},
+		{"Disjoint", []int{1}, []int{2}, false, false, false},
+		{"LargerValue", []int{4}, []int{1, 2, 3}, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := newSet(tt.a...), newSet(tt.b...)
+			if got := a.IsSubset(b); got != tt.subset {
+				t.Errorf("IsSubset() = %v, want %v", got, tt.subset)
+			}
+			if got := a.IsSuperset(b); got != tt.superset {
+				t.Errorf("IsSuperset() = %v, want %v", got, tt.superset)
+			}
+			if got := a.Equal(b); got != tt.isEqual {
+				t.Errorf("Equal() = %v, want %v", got, tt.isEqual)
+			}
+			if err := verify(a); err != nil {
+				t.Errorf("Invariant violated: %v", err)
+			}
+		})
+	}
+}
+
+// TestSetRandomOperations adds and removes random values in two sets and two maps, checks every
+// result against the maps, and then checks the set operations on the two sets.
+func TestSetRandomOperations(t *testing.T) {
+	t.Run("HashSet", func(t *testing.T) { testSetRandomOperations(t, NewHashSet[int], verifyHashSet) })
+	t.Run("TreeSet", func(t *testing.T) { testSetRandomOperations(t, newIntTreeSet, verifyTreeSet) })
+}
+
+func testSetRandomOperations[S testSet[S]](t *testing.T, newSet func(values ...int) S, verify func(S) error) {
+	rng := rand.New(rand.NewSource(1))
+	a, b := newSet(), newSet()
+	modelA, modelB := map[int]bool{}, map[int]bool{}
+	for i := 0; i < 2000; i++ {
+		s, model := a, modelA
+		if rng.Intn(2) == 0 {
+			s, model = b, modelB
+		}
+		v := rng.Intn(100)
+		switch rng.Intn(4) {
+		case 0, 1:
+			if got := s.Add(v); got != !model[v] {
+				t.Fatalf("Add(%d) = %v, want %v", v, got, !model[v])
+			}
+			model[v] = true
+		case 2:
+			if got := s.Remove(v); got != model[v] {
+				t.Fatalf("Remove(%d) = %v, want %v", v, got, model[v])
+			}
+			delete(model, v)
+		default:
+			if got := s.Contains(v); got != model[v] {
+				t.Fatalf("Contains(%d) = %v, want %v", v, got, model[v])
+			}
+		}
+	}
+	for _, s := range []S{a, b} {
+		if err := verify(s); err != nil {
+			t.Fatalf("Invariant violated: %v", err)
+		}
+	}
+
+	check := func(op string, got S, in func(v int) bool) {
+		t.Helper()
+		var want []int
+		for v := 0; v < 100; v++ {
+			if in(v) {
+				want = append(want, v)
+			}
+		}
+		if values := setValues(got); !slices.Equal(values, want) {
+			t.Errorf("%s = %v, want %v", op, values, want)
+		}
+		if err := verify(got); err != nil {
+			t.Errorf("%s: invariant violated: %v", op, err)
+		}
+	}
+	check("Union", a.Union(b), func(v int) bool { return modelA[v] || modelB[v] })
+	check("Intersection", a.Intersection(b), func(v int) bool { return modelA[v] && modelB[v] })
+	check("Difference", a.Difference(b), func(v int) bool { return modelA[v] && !modelB[v] })
+	check("SymmetricDifference", a.SymmetricDifference(b), func(v int) bool { return modelA[v] != modelB[v] })
+	if !a.Intersection(b).IsSubset(a) || !a.Union(b).IsSuperset(b) {
+		t.Errorf("the intersection should be a subset and the union a superset of the operands")
+	}
+}
@@ -0,0 +1,237 @@
+package structs
+
+import (
+	"iter"
+	"math/bits"
+	"math/rand"
+	"sync"
+)
+
+// maxSkipListLevel is the maximum number of levels of a SkipList. With a promotion probability
+// of one half, 32 levels are enough for far more values than fit into memory.
+const maxSkipListLevel = 32
+
+// skipListNode is a node of a SkipList. next[i] is the following node on level i, and the
+// length of next is the number of levels the node takes part in.
+type skipListNode[T any] struct {
+	value T
+	next  []*skipListNode[T]
+}
+
+// SkipList represents a skip list holding values of type T.
+//
+// A skip list is a sorted linked list with additional express lanes: every node is on level 0,
+// and a node on level i is also on level i+1 with probability one half. A search starts on the
+// highest level and drops down a level whenever the next node would overshoot, so it skips over
+// large parts of the list and takes O(log N) expected time. Insertions and deletions only relink
+// the neighbours of a single node and need no rebalancing, which makes skip lists simple and a
+// popular alternative to balanced trees.
+//
+// The SkipList type consists of a mutex to handle concurrent access, a head node that is on every
+// level, the current number of levels, the number of values, and a "cmp" function used to compare
+// values. The skip list implements OrderedSet.
+type SkipList[T any] struct {
+	mu    sync.Mutex
+	head  *skipListNode[T]
+	level int
+	cmp   func(a, b T) int
+	size  int
+}
+
+var _ OrderedSet[int] = (*SkipList[int])(nil)
+
+// NewSkipList creates a new empty skip list with the specified comparison function.
+// The comparison function returns a negative number if a is less than b, zero if they are equal,
+// and a positive number if a is greater than b, like cmp.Compare.
+func NewSkipList[T any](cmp func(a, b T) int) *SkipList[T] {
+	return &SkipList[T]{
+		head:  &skipListNode[T]{next: make([]*skipListNode[T], maxSkipListLevel)},
+		level: 1,
+		cmp:   cmp,
+	}
+}
+
+// randomLevel returns the number of levels of a new node. Each random bit set in a row promotes
+// the node by one level, so a node has at least i+1 levels with probability 2^-i.
+func randomLevel() int {
+	return min(bits.TrailingZeros32(^rand.Uint32())+1, maxSkipListLevel)
+}
+
+// findPredecessors fills update with the last node on every level whose value is less than the
+// given value, or, if orEqual is set, less than or equal to it. It returns the node on level 0.
+func (s *SkipList[T]) findPredecessors(value T, orEqual bool, update []*skipListNode[T]) *skipListNode[T] {
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for next := node.next[i]; next != nil; next = node.next[i] {
+			c := s.cmp(next.value, value)
+			if c > 0 || c == 0 && !orEqual {
+				break
+			}
+			node = next
+		}
+		if update != nil {
+			update[i] = node
+		}
+	}
+	return node
+}
+
+// Insert adds a new node with the specified value to the skip list. The node is linked in after
+// all values equal to it, on a randomly chosen number of levels.
+func (s *SkipList[T]) Insert(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var update [maxSkipListLevel]*skipListNode[T]
+	s.findPredecessors(value, true, update[:])
+
+	level := randomLevel()
+	for ; s.level < level; s.level++ {
+		update[s.level] = s.head
+	}
+	newNode := &skipListNode[T]{value: value, next: make([]*skipListNode[T], level)}
+	for i := 0; i < level; i++ {
+		newNode.next[i] = update[i].next[i]
+		update[i].next[i] = newNode
+	}
+	s.size++
+}
+
+// Delete removes the first node whose value is equal to the given value and reports whether such
+// a node was found. Levels that become empty are dropped.
+func (s *SkipList[T]) Delete(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var update [maxSkipListLevel]*skipListNode[T]
+	node := s.findPredecessors(value, false, update[:]).next[0]
+	if node == nil || s.cmp(node.value, value) != 0 {
+		return false
+	}
+	for i := range node.next {
+		update[i].next[i] = node.next[i]
+	}
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size--
+	return true
+}
+
+// Search reports whether a value equal to the given value is present in the skip list.
+func (s *SkipList[T]) Search(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	node := s.findPredecessors(value, false, nil).next[0]
+	return node != nil && s.cmp(node.value, value) == 0
+}
+
+// Len returns the number of values stored in the skip list.
+func (s *SkipList[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// Min returns the smallest value in the skip list in O(1) time. The boolean result is false if
+// the skip list is empty.
+func (s *SkipList[T]) Min() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.valueOf(s.head.next[0])
+}
+
+// Max returns the largest value in the skip list. The boolean result is false if the skip list is
+// empty.
+func (s *SkipList[T]) Max() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for node.next[i] != nil {
+			node = node.next[i]
+		}
+	}
+	return s.valueOf(node)
+}
+
+// Floor returns the largest value in the skip list that is less than or equal to the given value.
+// The boolean result is false if there is no such value.
+func (s *SkipList[T]) Floor(value T) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.valueOf(s.findPredecessors(value, true, nil))
+}
+
+// Ceiling returns the smallest value in the skip list that is greater than or equal to the given
+// value. The boolean result is false if there is no such value.
+func (s *SkipList[T]) Ceiling(value T) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.valueOf(s.findPredecessors(value, false, nil).next[0])
+}
+
+// valueOf returns the value of node and true, or the zero value of T and false if node is nil or
+// the head node.
+func (s *SkipList[T]) valueOf(node *skipListNode[T]) (T, bool) {
+	if node == nil || node == s.head {
+		var zero T
+		return zero, false
+	}
+	return node.value, true
+}
+
+// InOrder traverses the skip list in ascending order and applies the provided function to each
+// value.
+func (s *SkipList[T]) InOrder(f func(value T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for node := s.head.next[0]; node != nil; node = node.next[0] {
+		f(node.value)
+	}
+}
+
+// All returns an iterator over the values of the skip list in ascending order. The skip list is
+// locked while the loop runs; see the package documentation.
+func (s *SkipList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for node := s.head.next[0]; node != nil; node = node.next[0] {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of the skip list in descending order. Since the
+// nodes only link forward, the loop first collects the nodes, which takes O(N) time and memory
+// before the first value is yielded.
+func (s *SkipList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		nodes := make([]*skipListNode[T], 0, s.size)
+		for node := s.head.next[0]; node != nil; node = node.next[0] {
+			nodes = append(nodes, node)
+		}
+		for i := len(nodes) - 1; i >= 0; i-- {
+			if !yield(nodes[i].value) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the values v with lo <= v < hi in ascending order. The first
+// value is located in O(log N) expected time, after which the loop walks the bottom level.
+func (s *SkipList[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for node := s.findPredecessors(lo, false, nil).next[0]; node != nil && s.cmp(node.value, hi) < 0; node = node.next[0] {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
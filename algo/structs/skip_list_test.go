@@ -0,0 +1,77 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// verifySkipList checks the invariants of a skip list: every level is sorted, every node on a
+// level is also on all levels below it, no level above the current level is used, and the size
+// is correct.
+func verifySkipList[T any](s *SkipList[T]) error {
+	for i := s.level; i < maxSkipListLevel; i++ {
+		if s.head.next[i] != nil {
+			return fmt.Errorf("level %d is above the current level %d but not empty", i, s.level)
+		}
+	}
+	below := map[*skipListNode[T]]bool{}
+	for i := 0; i < s.level; i++ {
+		on := map[*skipListNode[T]]bool{}
+		for node := s.head.next[i]; node != nil; node = node.next[i] {
+			if i > 0 && !below[node] {
+				return fmt.Errorf("node %v is on level %d but not on level %d", node.value, i, i-1)
+			}
+			if next := node.next[i]; next != nil && s.cmp(node.value, next.value) > 0 {
+				return fmt.Errorf("level %d is not sorted at %v", i, node.value)
+			}
+			on[node] = true
+		}
+		if i == 0 && len(on) != s.size {
+			return fmt.Errorf("skip list has %d nodes but size %d", len(on), s.size)
+		}
+		below = on
+	}
+	return nil
+}
+
+func TestSkipListInvariants(t *testing.T) {
+	s := NewSkipList(cmp.Compare[int])
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 5000; i++ {
+		s.Insert(rng.Intn(1000))
+	}
+	if err := verifySkipList(s); err != nil {
+		t.Fatalf("After inserts: %v", err)
+	}
+	if s.level < 5 {
+		t.Errorf("Expected about log2(5000) levels but got %d", s.level)
+	}
+
+	for i := 0; i < 1000; i++ {
+		for s.Delete(i) {
+		}
+	}
+	if err := verifySkipList(s); err != nil {
+		t.Fatalf("After deletes: %v", err)
+	}
+	if s.Len() != 0 || s.level != 1 {
+		t.Errorf("Expected an empty skip list with one level but got length %d and %d levels", s.Len(), s.level)
+	}
+}
+
+func TestRandomLevel(t *testing.T) {
+	counts := make([]int, maxSkipListLevel+1)
+	for i := 0; i < 100000; i++ {
+		level := randomLevel()
+		if level < 1 || level > maxSkipListLevel {
+			t.Fatalf("Level %d out of range", level)
+		}
+		counts[level]++
+	}
+	// About half of the nodes should have exactly one level and a quarter exactly two.
+	if counts[1] < 45000 || counts[1] > 55000 || counts[2] < 20000 || counts[2] > 30000 {
+		t.Errorf("Unexpected level distribution %v", counts[:6])
+	}
+}
@@ -0,0 +1,342 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+)
+
+// SplayNode represents a node in a splay tree.
+// It contains the value and the left and right children.
+type SplayNode[T any] struct {
+	Value T
+	Left  *SplayNode[T]
+	Right *SplayNode[T]
+}
+
+// SplayTree represents a self-adjusting binary search tree holding values of type T.
+//
+// A splay tree stores no balancing information. Instead, every access moves the accessed node to
+// the root with a sequence of rotations called a splay, which roughly halves the depth of every
+// node on the access path. A single operation may take O(N) time, but any sequence of M operations
+// takes O(M log N) time, and values that are accessed often stay near the root, so skewed
+// workloads run faster than on a balanced tree.
+//
+// Because lookups restructure the tree, Search, Floor and Ceiling take the same exclusive lock as
+// Insert and Delete. The SplayTree type consists of a mutex to handle concurrent access, a root
+// node, the number of values, and a "cmp" function used to compare values. The tree implements
+// OrderedSet.
+type SplayTree[T any] struct {
+	mu   sync.Mutex
+	root *SplayNode[T]
+	cmp  func(a, b T) int
+	size int
+}
+
+var _ OrderedSet[int] = (*SplayTree[int])(nil)
+
+// NewSplayTree creates a new empty splay tree with the specified comparison function.
+// The comparison function returns a negative number if a is less than b, zero if they are equal,
+// and a positive number if a is greater than b, like cmp.Compare.
+func NewSplayTree[T any](cmp func(a, b T) int) *SplayTree[T] {
+	return &SplayTree[T]{
+		cmp: cmp,
+	}
+}
+
+// splay performs a top-down splay of the given value on the subtree rooted at node and returns the
+// new root. If the subtree contains a value equal to the given value, a node holding it becomes
+// the root; otherwise the root is the last node visited, which holds either the largest value less
+// than the given value or the smallest value greater than it.
+//
+// The nodes left of the search path are collected in a left tree and the nodes right of it in a
+// right tree, which are attached below the final root at the end. Two steps in the same direction
+// are preceded by a rotation, which is what halves the depth of the path.
+func (t *SplayTree[T]) splay(node *SplayNode[T], value T) *SplayNode[T] {
+	if node == nil {
+		return nil
+	}
+	var header SplayNode[T]
+	left, right := &header, &header
+	for {
+		c := t.cmp(value, node.Value)
+		if c < 0 {
+			if node.Left == nil {
+				break
+			}
+			if t.cmp(value, node.Left.Value) < 0 {
+				child := node.Left
+				node.Left = child.Right
+				child.Right = node
+				node = child
+				if node.Left == nil {
+					break
+				}
+			}
+			right.Left = node
+			right = node
+			node = node.Left
+		} else if c > 0 {
+			if node.Right == nil {
+				break
+			}
+			if t.cmp(value, node.Right.Value) > 0 {
+				child := node.Right
+				node.Right = child.Left
+				child.Left = node
+				node = child
+				if node.Right == nil {
+					break
+				}
+			}
+			left.Right = node
+			left = node
+			node = node.Right
+		} else {
+			break
+		}
+	}
+	left.Right = node.Left
+	right.Left = node.Right
+	node.Left = header.Right
+	node.Right = header.Left
+	return node
+}
+
+// splayMax brings the largest value of the subtree rooted at node to the top and returns the new
+// root, which has no right child. It is the top-down splay of a value greater than every value in
+// the subtree, so it always descends to the right and never needs a right tree. Splaying on a
+// value from the subtree would not do, since equal values may follow the node it stops at.
+func splayMax[T any](node *SplayNode[T]) *SplayNode[T] {
+	var header SplayNode[T]
+	left := &header
+	for node.Right != nil {
+		child := node.Right
+		node.Right = child.Left
+		child.Left = node
+		node = child
+		if node.Right == nil {
+			break
+		}
+		left.Right = node
+		left = node
+		node = node.Right
+	}
+	left.Right = node.Left
+	node.Left = header.Right
+	return node
+}
+
+// Insert adds a new node with the specified value to the splay tree. The tree is splayed on the
+// value and split around the old root, and the new node becomes the root.
+func (t *SplayTree[T]) Insert(value T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	newNode := &SplayNode[T]{Value: value}
+	if t.root != nil {
+		root := t.splay(t.root, value)
+		if t.cmp(value, root.Value) < 0 {
+			newNode.Left = root.Left
+			newNode.Right = root
+			root.Left = nil
+		} else {
+			newNode.Right = root.Right
+			newNode.Left = root
+			root.Right = nil
+		}
+	}
+	t.root = newNode
+	t.size++
+}
+
+// Delete removes one node whose value is equal to the given value and reports whether such a node
+// was found. The tree is splayed on the value, and if the root then holds it, the root is replaced
+// by the join of its subtrees.
+func (t *SplayTree[T]) Delete(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.root == nil {
+		return false
+	}
+	t.root = t.splay(t.root, value)
+	if t.cmp(value, t.root.Value) != 0 {
+		return false
+	}
+	if t.root.Left == nil {
+		t.root = t.root.Right
+	} else {
+		left := splayMax(t.root.Left)
+		left.Right = t.root.Right
+		t.root = left
+	}
+	t.size--
+	return true
+}
+
+// Search reports whether a value equal to the given value is present in the splay tree. The tree
+// is splayed on the value, so repeated searches for the same value are fast.
+func (t *SplayTree[T]) Search(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = t.splay(t.root, value)
+	return t.root != nil && t.cmp(value, t.root.Value) == 0
+}
+
+// Len returns the number of values stored in the splay tree.
+func (t *SplayTree[T]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.size
+}
+
+// Min returns the smallest value in the splay tree. The boolean result is false if the tree is empty.
+func (t *SplayTree[T]) Min() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil && node.Left != nil {
+		node = node.Left
+	}
+	return splayValue(node)
+}
+
+// Max returns the largest value in the splay tree. The boolean result is false if the tree is empty.
+func (t *SplayTree[T]) Max() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil && node.Right != nil {
+		node = node.Right
+	}
+	return splayValue(node)
+}
+
+// Floor returns the largest value in the splay tree that is less than or equal to the given
+// value. The boolean result is false if there is no such value. The tree is splayed on the value,
+// after which the answer is either the root or the largest value in its left subtree.
+func (t *SplayTree[T]) Floor(value T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = t.splay(t.root, value)
+	node := t.root
+	if node != nil && t.cmp(node.Value, value) > 0 {
+		node = node.Left
+		for node != nil && node.Right != nil {
+			node = node.Right
+		}
+	}
+	return splayValue(node)
+}
+
+// Ceiling returns the smallest value in the splay tree that is greater than or equal to the given
+// value. The boolean result is false if there is no such value. The tree is splayed on the value,
+// after which the answer is either the root or the smallest value in its right subtree.
+func (t *SplayTree[T]) Ceiling(value T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = t.splay(t.root, value)
+	node := t.root
+	if node != nil && t.cmp(node.Value, value) < 0 {
+		node = node.Right
+		for node != nil && node.Left != nil {
+			node = node.Left
+		}
+	}
+	return splayValue(node)
+}
+
+// splayValue returns the value of node and true, or the zero value of T and false if node is nil.
+func splayValue[T any](node *SplayNode[T]) (T, bool) {
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.Value, true
+}
+
+// InOrder traverses the splay tree in ascending order and applies the provided function to each
+// value. The traversal uses an explicit stack, since a splay tree can be arbitrarily deep.
+func (t *SplayTree[T]) InOrder(f func(value T)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var stack []*SplayNode[T]
+	node := t.root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.Left
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		f(node.Value)
+		node = node.Right
+	}
+}
+
+// All returns an iterator over the values of the splay tree in ascending order. Iterating does
+// not splay, so the shape of the tree is left unchanged. The tree is locked while the loop runs;
+// see the package documentation.
+func (t *SplayTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.ascend(nil, nil, yield)
+	}
+}
+
+// Backward returns an iterator over the values of the splay tree in descending order. Like
+// InOrder, it uses an explicit stack, since a splay tree can be arbitrarily deep.
+func (t *SplayTree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		var stack []*SplayNode[T]
+		node := t.root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Right
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(node.Value) {
+				return
+			}
+			node = node.Left
+		}
+	}
+}
+
+// Range returns an iterator over the values v with lo <= v < hi in ascending order.
+func (t *SplayTree[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.ascend(&lo, &hi, yield)
+	}
+}
+
+// ascend yields the values that are not less than *lo and less than *hi in ascending order; a nil
+// bound is unbounded. It walks the tree with an explicit stack that only holds nodes not less than
+// the lower bound, so the subtrees below it are never visited.
+func (t *SplayTree[T]) ascend(lo, hi *T, yield func(T) bool) {
+	var stack []*SplayNode[T]
+	pushLeft := func(node *SplayNode[T]) {
+		for node != nil {
+			if lo != nil && t.cmp(node.Value, *lo) < 0 {
+				node = node.Right
+			} else {
+				stack = append(stack, node)
+				node = node.Left
+			}
+		}
+	}
+	pushLeft(t.root)
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if hi != nil && t.cmp(node.Value, *hi) >= 0 || !yield(node.Value) {
+			return
+		}
+		pushLeft(node.Right)
+	}
+}
@@ -0,0 +1,75 @@
+package structs
+
+import (
+	"cmp"
+	"testing"
+)
+
+func TestSplayTreeMovesAccessedValueToRoot(t *testing.T) {
+	tree := NewSplayTree(cmp.Compare[int])
+	for i := 0; i < 100; i++ {
+		tree.Insert(i)
+	}
+	for _, v := range []int{42, 0, 99, 42} {
+		if !tree.Search(v) {
+			t.Fatalf("Expected %d to be found", v)
+		}
+		if tree.root.Value != v {
+			t.Errorf("Expected %d at the root after searching it but got %d", v, tree.root.Value)
+		}
+	}
+
+	// An unsuccessful search leaves a neighbour of the value at the root.
+	tree.Delete(50)
+	tree.Search(50)
+	if v := tree.root.Value; v != 49 && v != 51 {
+		t.Errorf("Expected 49 or 51 at the root but got %d", v)
+	}
+}
+
+func TestSplayTreeDeepTree(t *testing.T) {
+	// Sorted insertions build a tree that is a single path, which the traversal and the
+	// operations must handle without deep recursion.
+	const n = 100000
+	tree := NewSplayTree(cmp.Compare[int])
+	for i := 0; i < n; i++ {
+		tree.Insert(i)
+	}
+	count := 0
+	tree.InOrder(func(value int) {
+		if value != count {
+			t.Fatalf("Expected %d but got %d", count, value)
+		}
+		count++
+	})
+	if count != n {
+		t.Errorf("Expected %d values but got %d", n, count)
+	}
+	if !tree.Search(0) || !tree.Delete(n/2) || tree.Search(n/2) {
+		t.Errorf("Unexpected result on a deep tree")
+	}
+}
+
+func TestSplayTreeDeleteDuplicates(t *testing.T) {
+	tree := NewSplayTree(cmp.Compare[int])
+	for _, v := range []int{5, 3, 5, 5, 7, 1, 5} {
+		tree.Insert(v)
+	}
+	for i := 4; i > 0; i-- {
+		if !tree.Delete(5) {
+			t.Fatalf("Expected a 5 to be deleted")
+		}
+		fives := 0
+		tree.InOrder(func(value int) {
+			if value == 5 {
+				fives++
+			}
+		})
+		if fives != i-1 || tree.Len() != 3+i-1 {
+			t.Fatalf("Expected %d fives and length %d but got %d and %d", i-1, 3+i-1, fives, tree.Len())
+		}
+	}
+	if tree.Delete(5) {
+		t.Errorf("Expected no 5 to be left")
+	}
+}
@@ -1,19 +1,22 @@
 package structs
 
-import "sync"
+import (
+	"iter"
+	"sync"
+)
 
 // Stack is a thread-safe data structure that implements the stack concept.
-// It allows adding, removing and retrieving items in a Last-In-First-Out (LIFO) manner.
-type Stack struct {
+// It allows adding, removing and retrieving items of type T in a Last-In-First-Out (LIFO) manner.
+type Stack[T any] struct {
 	mu    sync.Mutex
-	items []interface{}
+	items []T
 }
 
-// NewStack returns a new Stack instance.
-// The Stack is initialized with an empty slice of interface{}.
-func NewStack() *Stack {
-	return &Stack{
-		items: make([]interface{}, 0),
+// NewStack returns a new Stack instance for items of type T.
+// The Stack is initialized with an empty slice of T.
+func NewStack[T any]() *Stack[T] {
+	return &Stack[T]{
+		items: make([]T, 0),
 	}
 }
 
@@ -22,31 +25,36 @@ func NewStack() *Stack {
 //
 // It is safe to call this method concurrently from multiple goroutines,
 // as it uses a mutex to provide synchronization.
-func (s *Stack) Push(item interface{}) {
+func (s *Stack[T]) Push(item T) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.items = append(s.items, item)
 }
 
-// Pop removes and returns the top item from the stack. If the stack is empty, it returns nil.
-// It acquires a lock to ensure synchronization and releases it before returning the item.
-func (s *Stack) Pop() interface{} {
+// Pop removes and returns the top item from the stack. If the stack is empty, it returns
+// the zero value of T. It acquires a lock to ensure synchronization and releases it before
+// returning the item.
+func (s *Stack[T]) Pop() T {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	var zero T
 	if len(s.items) == 0 {
-		return nil
+		return zero
 	}
 	item := s.items[len(s.items)-1]
+	s.items[len(s.items)-1] = zero
 	s.items = s.items[:len(s.items)-1]
 	return item
 }
 
-// Peek returns the element at the top of the stack without removing it. If the stack is empty, it returns nil.
-func (s *Stack) Peek() interface{} {
+// Peek returns the element at the top of the stack without removing it. If the stack is empty,
+// it returns the zero value of T.
+func (s *Stack[T]) Peek() T {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if len(s.items) == 0 {
-		return nil
+		var zero T
+		return zero
 	}
 	return s.items[len(s.items)-1]
 }
@@ -55,7 +63,7 @@ func (s *Stack) Peek() interface{} {
 // This method acquires a lock on the stack, checks the length of the items slice,
 // and returns true if it is 0, indicating an empty stack.
 // It releases the lock before returning the result.
-func (s *Stack) IsEmpty() bool {
+func (s *Stack[T]) IsEmpty() bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	return len(s.items) == 0
@@ -63,8 +71,37 @@ func (s *Stack) IsEmpty() bool {
 
 // Size returns the number of items in the stack. It acquires a lock to ensure
 // thread safety and releases it before returning the result.
-func (s *Stack) Size() int {
+func (s *Stack[T]) Size() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	return len(s.items)
 }
+
+// All returns an iterator over the items of the stack from the top to the bottom, which is the
+// order in which Pop would return them. The stack is locked while the loop runs; see the package
+// documentation.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for i := len(s.items) - 1; i >= 0; i-- {
+			if !yield(s.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the items of the stack from the bottom to the top, which is
+// the order in which they were pushed.
+func (s *Stack[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, item := range s.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -1,19 +1,20 @@
 package structs
 
 import (
+	"slices"
 	"testing"
 )
 
 func TestStack(t *testing.T) {
 	t.Run("NewStack", func(t *testing.T) {
-		stack := NewStack()
+		stack := NewStack[interface{}]()
 		if stack.Size() != 0 {
 			t.Errorf("New stack should be empty")
 		}
 	})
 
 	t.Run("Push and Pop", func(t *testing.T) {
-		stack := NewStack()
+		stack := NewStack[interface{}]()
 		stack.Push(1)
 
 		if stack.Size() != 1 {
@@ -27,7 +28,7 @@ func TestStack(t *testing.T) {
 	})
 
 	t.Run("Pop empty", func(t *testing.T) {
-		stack := NewStack()
+		stack := NewStack[interface{}]()
 		item := stack.Pop()
 		if item != nil {
 			t.Errorf("Pop on empty stack should return nil")
@@ -35,7 +36,7 @@ func TestStack(t *testing.T) {
 	})
 
 	t.Run("Peek", func(t *testing.T) {
-		stack := NewStack()
+		stack := NewStack[interface{}]()
 		stack.Push(1)
 		item := stack.Peek()
 
@@ -45,7 +46,7 @@ func TestStack(t *testing.T) {
 	})
 
 	t.Run("Peek empty", func(t *testing.T) {
-		stack := NewStack()
+		stack := NewStack[interface{}]()
 		item := stack.Peek()
 		if item != nil {
 			t.Errorf("Peek on empty stack should return nil")
@@ -53,7 +54,7 @@ func TestStack(t *testing.T) {
 	})
 
 	t.Run("IsEmpty", func(t *testing.T) {
-		stack := NewStack()
+		stack := NewStack[interface{}]()
 		if !stack.IsEmpty() {
 			t.Errorf("New stack should be empty")
 		}
@@ -70,7 +71,7 @@ func TestStack(t *testing.T) {
 	})
 
 	t.Run("Size", func(t *testing.T) {
-		stack := NewStack()
+		stack := NewStack[interface{}]()
 		if stack.Size() != 0 {
 			t.Errorf("New stack should have size 0")
 		}
@@ -85,4 +86,41 @@ func TestStack(t *testing.T) {
 			t.Errorf("Pop should decrease size")
 		}
 	})
+
+	t.Run("Typed stack", func(t *testing.T) {
+		stack := NewStack[string]()
+		if item := stack.Pop(); item != "" {
+			t.Errorf("Pop on empty stack should return the zero value, got: %q", item)
+		}
+		stack.Push("a")
+		stack.Push("b")
+		if item := stack.Pop(); item != "b" {
+			t.Errorf("Pop should return the last item pushed, got: %q", item)
+		}
+		if item := stack.Peek(); item != "a" {
+			t.Errorf("Peek should return the remaining item, got: %q", item)
+		}
+	})
+}
+
+func TestStackIterators(t *testing.T) {
+	stack := NewStack[int]()
+	for i := 1; i <= 4; i++ {
+		stack.Push(i)
+	}
+	if got := slices.Collect(stack.All()); !slices.Equal(got, []int{4, 3, 2, 1}) {
+		t.Errorf("All: expected [4 3 2 1] but got %v", got)
+	}
+	if got := slices.Collect(stack.Backward()); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Backward: expected [1 2 3 4] but got %v", got)
+	}
+	for v := range stack.All() {
+		if v == 3 {
+			break
+		}
+	}
+	// Breaking out of the loop must release the lock.
+	if stack.Pop() != 4 {
+		t.Errorf("Expected the stack to be usable after an early break")
+	}
 }
@@ -0,0 +1,298 @@
+package structs
+
+import (
+	"iter"
+	"math/rand"
+	"sync"
+)
+
+// TreapNode represents a node in a treap.
+// It contains the value, a random priority, and the left and right children.
+type TreapNode[T any] struct {
+	Value    T
+	Left     *TreapNode[T]
+	Right    *TreapNode[T]
+	priority uint32
+}
+
+// Treap represents a randomized binary search tree holding values of type T.
+//
+// Every node of a treap is given a random priority, and the tree is kept in search tree order by
+// value and in heap order by priority, so that no child has a higher priority than its parent.
+// The shape of the tree is then the same as if the values had been inserted in random order,
+// which gives an expected height of O(log N) whatever the order of the insertions. Balancing is
+// done with the same rotations as in other search trees, but needs no per-node bookkeeping
+// besides the priority.
+//
+// The Treap type consists of a mutex to handle concurrent access, a root node, the number of
+// values, and a "cmp" function used to compare values. The treap implements OrderedSet.
+type Treap[T any] struct {
+	mu   sync.Mutex
+	root *TreapNode[T]
+	cmp  func(a, b T) int
+	size int
+}
+
+var _ OrderedSet[int] = (*Treap[int])(nil)
+
+// NewTreap creates a new empty treap with the specified comparison function.
+// The comparison function returns a negative number if a is less than b, zero if they are equal,
+// and a positive number if a is greater than b, like cmp.Compare.
+func NewTreap[T any](cmp func(a, b T) int) *Treap[T] {
+	return &Treap[T]{
+		cmp: cmp,
+	}
+}
+
+// Insert adds a new node with the specified value and a random priority to the treap. The node is
+// inserted as a leaf and rotated up until its parent has a higher priority.
+func (t *Treap[T]) Insert(value T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = t.insertNode(t.root, &TreapNode[T]{Value: value, priority: rand.Uint32()})
+	t.size++
+}
+
+// insertNode inserts newNode into the subtree rooted at node and returns the new root of the
+// subtree. Values equal to the value of a node are inserted into its right subtree.
+func (t *Treap[T]) insertNode(node, newNode *TreapNode[T]) *TreapNode[T] {
+	if node == nil {
+		return newNode
+	}
+	if t.cmp(newNode.Value, node.Value) < 0 {
+		node.Left = t.insertNode(node.Left, newNode)
+		if node.Left.priority > node.priority {
+			node = rotateRightTreap(node)
+		}
+	} else {
+		node.Right = t.insertNode(node.Right, newNode)
+		if node.Right.priority > node.priority {
+			node = rotateLeftTreap(node)
+		}
+	}
+	return node
+}
+
+// Delete removes one node whose value is equal to the given value and reports whether such a node
+// was found. The node is replaced by the merge of its two subtrees.
+func (t *Treap[T]) Delete(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	link := &t.root
+	for *link != nil {
+		node := *link
+		c := t.cmp(value, node.Value)
+		if c == 0 {
+			*link = mergeTreaps(node.Left, node.Right)
+			t.size--
+			return true
+		}
+		if c < 0 {
+			link = &node.Left
+		} else {
+			link = &node.Right
+		}
+	}
+	return false
+}
+
+// mergeTreaps joins two treaps, where every value in left is less than or equal to every value in
+// right, and returns the root of the result. The root with the higher priority stays on top.
+func mergeTreaps[T any](left, right *TreapNode[T]) *TreapNode[T] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.priority > right.priority {
+		left.Right = mergeTreaps(left.Right, right)
+		return left
+	}
+	right.Left = mergeTreaps(left, right.Left)
+	return right
+}
+
+// rotateLeftTreap rotates the subtree rooted at node to the left and returns its new root.
+func rotateLeftTreap[T any](node *TreapNode[T]) *TreapNode[T] {
+	right := node.Right
+	node.Right = right.Left
+	right.Left = node
+	return right
+}
+
+// rotateRightTreap rotates the subtree rooted at node to the right and returns its new root.
+func rotateRightTreap[T any](node *TreapNode[T]) *TreapNode[T] {
+	left := node.Left
+	node.Left = left.Right
+	left.Right = node
+	return left
+}
+
+// Search reports whether a value equal to the given value is present in the treap.
+func (t *Treap[T]) Search(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil {
+		c := t.cmp(value, node.Value)
+		if c == 0 {
+			return true
+		}
+		if c < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return false
+}
+
+// Len returns the number of values stored in the treap.
+func (t *Treap[T]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.size
+}
+
+// Min returns the smallest value in the treap. The boolean result is false if the treap is empty.
+func (t *Treap[T]) Min() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil && node.Left != nil {
+		node = node.Left
+	}
+	return treapValue(node)
+}
+
+// Max returns the largest value in the treap. The boolean result is false if the treap is empty.
+func (t *Treap[T]) Max() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := t.root
+	for node != nil && node.Right != nil {
+		node = node.Right
+	}
+	return treapValue(node)
+}
+
+// Floor returns the largest value in the treap that is less than or equal to the given value.
+// The boolean result is false if there is no such value.
+func (t *Treap[T]) Floor(value T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var best *TreapNode[T]
+	node := t.root
+	for node != nil {
+		if t.cmp(node.Value, value) <= 0 {
+			best = node
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return treapValue(best)
+}
+
+// Ceiling returns the smallest value in the treap that is greater than or equal to the given
+// value. The boolean result is false if there is no such value.
+func (t *Treap[T]) Ceiling(value T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var best *TreapNode[T]
+	node := t.root
+	for node != nil {
+		if t.cmp(node.Value, value) >= 0 {
+			best = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return treapValue(best)
+}
+
+// treapValue returns the value of node and true, or the zero value of T and false if node is nil.
+func treapValue[T any](node *TreapNode[T]) (T, bool) {
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.Value, true
+}
+
+// InOrder traverses the treap in ascending order and applies the provided function to each value.
+func (t *Treap[T]) InOrder(f func(value T)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inOrderTraverse(t.root, f)
+}
+
+// inOrderTraverse performs an in-order traversal of the subtree rooted at node.
+func (t *Treap[T]) inOrderTraverse(node *TreapNode[T], f func(value T)) {
+	if node != nil {
+		t.inOrderTraverse(node.Left, f)
+		f(node.Value)
+		t.inOrderTraverse(node.Right, f)
+	}
+}
+
+// All returns an iterator over the values of the treap in ascending order. The treap is locked
+// while the loop runs; see the package documentation.
+func (t *Treap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.ascend(t.root, nil, nil, yield)
+	}
+}
+
+// Backward returns an iterator over the values of the treap in descending order.
+func (t *Treap[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.descend(t.root, yield)
+	}
+}
+
+// Range returns an iterator over the values v with lo <= v < hi in ascending order. Subtrees that
+// lie entirely outside the range are skipped.
+func (t *Treap[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.ascend(t.root, &lo, &hi, yield)
+	}
+}
+
+// ascend yields the values of the subtree rooted at node that are not less than *lo and less than
+// *hi in ascending order; a nil bound is unbounded. It returns false once iteration must stop,
+// either because yield returned false or because a value reached the upper bound.
+func (t *Treap[T]) ascend(node *TreapNode[T], lo, hi *T, yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	if lo == nil || t.cmp(node.Value, *lo) >= 0 {
+		if !t.ascend(node.Left, lo, hi, yield) {
+			return false
+		}
+		if hi != nil && t.cmp(node.Value, *hi) >= 0 {
+			return false
+		}
+		if !yield(node.Value) {
+			return false
+		}
+	}
+	return t.ascend(node.Right, lo, hi, yield)
+}
+
+// descend yields the values of the subtree rooted at node in descending order and returns false
+// if yield did.
+func (t *Treap[T]) descend(node *TreapNode[T], yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	return t.descend(node.Right, yield) && yield(node.Value) && t.descend(node.Left, yield)
+}
@@ -0,0 +1,83 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// verifyTreap checks the invariants of a treap: values are in search tree order, no child has a
+// higher priority than its parent, and the size is correct.
+func verifyTreap[T any](t *Treap[T]) error {
+	count := 0
+	var walk func(node *TreapNode[T]) error
+	walk = func(node *TreapNode[T]) error {
+		if node == nil {
+			return nil
+		}
+		count++
+		if left := node.Left; left != nil {
+			if t.cmp(left.Value, node.Value) > 0 {
+				return fmt.Errorf("left child of %v is greater", node.Value)
+			}
+			if left.priority > node.priority {
+				return fmt.Errorf("left child of %v has a higher priority", node.Value)
+			}
+		}
+		if right := node.Right; right != nil {
+			if t.cmp(right.Value, node.Value) < 0 {
+				return fmt.Errorf("right child of %v is smaller", node.Value)
+			}
+			if right.priority > node.priority {
+				return fmt.Errorf("right child of %v has a higher priority", node.Value)
+			}
+		}
+		if err := walk(node.Left); err != nil {
+			return err
+		}
+		return walk(node.Right)
+	}
+	if err := walk(t.root); err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("treap has %d nodes but size %d", count, t.size)
+	}
+	return nil
+}
+
+// treapHeight returns the number of nodes on the longest path from node to a leaf.
+func treapHeight[T any](node *TreapNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return max(treapHeight(node.Left), treapHeight(node.Right)) + 1
+}
+
+func TestTreapInvariants(t *testing.T) {
+	treap := NewTreap(cmp.Compare[int])
+	for i := 0; i < 2000; i++ {
+		treap.Insert(i)
+	}
+	if err := verifyTreap(treap); err != nil {
+		t.Fatalf("After inserts: %v", err)
+	}
+	// Sorted insertions would degenerate an unbalanced tree into a list of height 2000; the
+	// random priorities keep the expected height around 3 log2 n.
+	if h := treapHeight(treap.root); h > 80 {
+		t.Errorf("Expected a height of O(log n) but got %d", h)
+	}
+
+	for _, v := range rand.New(rand.NewSource(3)).Perm(2000)[:1000] {
+		if !treap.Delete(v) {
+			t.Fatalf("Expected %d to be deleted", v)
+		}
+	}
+	if err := verifyTreap(treap); err != nil {
+		t.Fatalf("After deletes: %v", err)
+	}
+	if treap.Len() != 1000 {
+		t.Errorf("Expected length 1000 but got %d", treap.Len())
+	}
+}
@@ -0,0 +1,210 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+)
+
+// mapEntry is a key-value pair stored in a TreeMap.
+type mapEntry[K any, V any] struct {
+	key   K
+	value V
+}
+
+// TreeMap is a thread-safe sorted map from keys of type K to values of type V. It is built on
+// a RedBlackTree of key-value entries ordered by key, so lookups, insertions and deletions take
+// O(log N) time and keys can be visited in ascending or descending order or restricted to a range.
+//
+// The order of the keys is defined by a cmp function that returns a negative number if the first
+// key is less than the second, zero if they are equal, and a positive number otherwise, like
+// cmp.Compare. Two keys are the same key when cmp returns zero for them.
+type TreeMap[K any, V any] struct {
+	mu   sync.Mutex
+	tree *RedBlackTree[*mapEntry[K, V]]
+	cmp  func(a, b K) int
+}
+
+// NewTreeMap creates a new empty TreeMap whose keys are ordered by the given cmp function.
+func NewTreeMap[K any, V any](cmp func(a, b K) int) *TreeMap[K, V] {
+	return &TreeMap[K, V]{
+		tree: NewRedBlackTree(func(a, b *mapEntry[K, V]) int {
+			return cmp(a.key, b.key)
+		}),
+		cmp: cmp,
+	}
+}
+
+// Put associates the given value with the given key. If the key is already present, its value
+// is replaced; otherwise a new entry is inserted into the underlying tree.
+func (m *TreeMap[K, V]) Put(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if node := m.findNode(key); node != nil {
+		node.Value.value = value
+		return
+	}
+	m.tree.Insert(&mapEntry[K, V]{key: key, value: value})
+}
+
+// Get returns the value associated with the given key. The boolean result is false if the key
+// is not present, in which case the zero value of V is returned.
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if node := m.findNode(key); node != nil {
+		return node.Value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// ContainsKey reports whether the given key is present in the map.
+func (m *TreeMap[K, V]) ContainsKey(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.findNode(key) != nil
+}
+
+// Delete removes the entry with the given key and reports whether it was present.
+func (m *TreeMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tree.Delete(&mapEntry[K, V]{key: key})
+}
+
+// Len returns the number of entries in the map.
+func (m *TreeMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tree.Len()
+}
+
+// Ascend calls fn for every entry in ascending key order until fn returns false.
+// The map is locked for the duration of the call, so fn must not modify the map.
+func (m *TreeMap[K, V]) Ascend(fn func(key K, value V) bool) {
+	m.All()(fn)
+}
+
+// Descend calls fn for every entry in descending key order until fn returns false.
+// The map is locked for the duration of the call, so fn must not modify the map.
+func (m *TreeMap[K, V]) Descend(fn func(key K, value V) bool) {
+	m.Backward()(fn)
+}
+
+// All returns an iterator over the entries of the map in ascending key order. The map is locked
+// while the loop runs; see the package documentation.
+func (m *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.tree.root == nil {
+			return
+		}
+		for node := minNode(m.tree.root); node != nil; node = nextNode(node) {
+			if !yield(node.Value.key, node.Value.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the entries of the map in descending key order.
+func (m *TreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.tree.root == nil {
+			return
+		}
+		for node := maxNode(m.tree.root); node != nil; node = prevNode(node) {
+			if !yield(node.Value.key, node.Value.value) {
+				return
+			}
+		}
+	}
+}
+
+// AscendRange calls fn in ascending key order for every entry whose key lies in the half-open
+// range [lo, hi), until fn returns false. The map is locked for the duration of the call, so fn
+// must not modify the map.
+func (m *TreeMap[K, V]) AscendRange(lo, hi K, fn func(key K, value V) bool) {
+	m.Range(lo, hi)(fn)
+}
+
+// Range returns an iterator over the entries whose key lies in the half-open range [lo, hi), in
+// ascending key order. The first entry is located in O(log N) time and each further entry is
+// reached in amortized constant time.
+func (m *TreeMap[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		node := m.tree.ceilingNode(&mapEntry[K, V]{key: lo}, true)
+		for ; node != nil && m.cmp(node.Value.key, hi) < 0; node = nextNode(node) {
+			if !yield(node.Value.key, node.Value.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the map in ascending order.
+func (m *TreeMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the map in ascending key order.
+func (m *TreeMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Rank returns the number of keys in the map that are strictly less than the given key. The key
+// does not need to be present. If it is present, Rank returns its zero-based position in
+// ascending key order. It runs in O(log N) using the subtree sizes of the underlying tree.
+func (m *TreeMap[K, V]) Rank(key K) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tree.Rank(&mapEntry[K, V]{key: key})
+}
+
+// Select returns the entry with the k-th smallest key, counting from zero. The boolean result is
+// false if k is out of range. It runs in O(log N) using the subtree sizes of the underlying tree.
+func (m *TreeMap[K, V]) Select(k int) (K, V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.tree.Select(k); ok {
+		return entry.key, entry.value, true
+	}
+	var key K
+	var value V
+	return key, value, false
+}
+
+// findNode returns the tree node holding the entry with the given key, or nil if the key is not
+// present. It compares keys directly, so no probe entry has to be allocated.
+func (m *TreeMap[K, V]) findNode(key K) *RBNode[*mapEntry[K, V]] {
+	node := m.tree.root
+	for node != nil {
+		c := m.cmp(key, node.Value.key)
+		if c == 0 {
+			return node
+		}
+		if c < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package structs
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestTreeMap(t *testing.T) {
+	t.Run("PutGet", func(t *testing.T) {
+		m := NewTreeMap[string, int](strings.Compare)
+		m.Put("b", 2)
+		m.Put("a", 1)
+		m.Put("c", 3)
+		for key, want := range map[string]int{"a": 1, "b": 2, "c": 3} {
+			if got, ok := m.Get(key); !ok || got != want {
+				t.Errorf("Get(%q) = (%d, %v), want (%d, true)", key, got, ok, want)
+			}
+		}
+		if got, ok := m.Get("d"); ok || got != 0 {
+			t.Errorf("Get of a missing key = (%d, %v), want (0, false)", got, ok)
+		}
+		if m.Len() != 3 {
+			t.Errorf("Len() = %d, want 3", m.Len())
+		}
+	})
+
+	t.Run("PutReplaces", func(t *testing.T) {
+		m := NewTreeMap[string, int](strings.Compare)
+		m.Put("a", 1)
+		m.Put("a", 10)
+		if got, _ := m.Get("a"); got != 10 {
+			t.Errorf("Get after replacing = %d, want 10", got)
+		}
+		if m.Len() != 1 {
+			t.Errorf("Len() = %d, want 1", m.Len())
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		m := NewTreeMap[int, string](cmp.Compare[int])
+		m.Put(1, "one")
+		m.Put(2, "two")
+		if !m.Delete(1) {
+			t.Errorf("Delete of a present key should return true")
+		}
+		if m.Delete(1) {
+			t.Errorf("Delete of a missing key should return false")
+		}
+		if m.ContainsKey(1) || !m.ContainsKey(2) {
+			t.Errorf("ContainsKey does not reflect the deletion")
+		}
+	})
+}
+
+func TestTreeMapIteration(t *testing.T) {
+	m := NewTreeMap[int, string](cmp.Compare[int])
+	for _, k := range []int{50, 30, 70, 20, 40, 60, 80} {
+		m.Put(k, strings.Repeat("x", k/10))
+	}
+
+	collect := func(iterate func(fn func(int, string) bool), limit int) []int {
+		keys := make([]int, 0)
+		iterate(func(k int, v string) bool {
+			if len(v) != k/10 {
+				t.Errorf("Unexpected value %q for key %d", v, k)
+			}
+			keys = append(keys, k)
+			return len(keys) < limit
+		})
+		return keys
+	}
+
+	tests := []struct {
+		name    string
+		iterate func(fn func(int, string) bool)
+		limit   int
+		want    []int
+	}{
+		{"Ascend", m.Ascend, 100, []int{20, 30, 40, 50, 60, 70, 80}},
+		{"AscendStop", m.Ascend, 2, []int{20, 30}},
+		{"Descend", m.Descend, 100, []int{80, 70, 60, 50, 40, 30, 20}},
+		{"DescendStop", m.Descend, 3, []int{80, 70, 60}},
+		{"Range", func(fn func(int, string) bool) { m.Range(30, 60)(fn) }, 100, []int{30, 40, 50}},
+		{"RangeBetweenKeys", func(fn func(int, string) bool) { m.Range(35, 75)(fn) }, 100, []int{40, 50, 60, 70}},
+		{"RangeStop", func(fn func(int, string) bool) { m.Range(0, 100)(fn) }, 1, []int{20}},
+		{"RangeEmpty", func(fn func(int, string) bool) { m.Range(81, 90)(fn) }, 100, []int{}},
+		{"AscendRange", func(fn func(int, string) bool) { m.AscendRange(35, 75, fn) }, 100, []int{40, 50, 60, 70}},
+		{"AscendRangeStop", func(fn func(int, string) bool) { m.AscendRange(0, 100, fn) }, 1, []int{20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collect(tt.iterate, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got keys %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTreeMapRankSelect(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := NewTreeMap[int, int](cmp.Compare[int])
+	keys := make([]int, 0)
+	for len(keys) < 300 {
+		k := r.Intn(10000)
+		if !m.ContainsKey(k) {
+			keys = append(keys, k)
+		}
+		m.Put(k, -k)
+	}
+	sort.Ints(keys)
+
+	for i, k := range keys {
+		if got := m.Rank(k); got != i {
+			t.Fatalf("Rank(%d) = %d, want %d", k, got, i)
+		}
+		if got := m.Rank(k + 1); k+1 < 10000 && !m.ContainsKey(k+1) && got != i+1 {
+			t.Fatalf("Rank(%d) = %d, want %d", k+1, got, i+1)
+		}
+		key, value, ok := m.Select(i)
+		if !ok || key != k || value != -k {
+			t.Fatalf("Select(%d) = (%d, %d, %v), want (%d, %d, true)", i, key, value, ok, k, -k)
+		}
+	}
+	if _, _, ok := m.Select(len(keys)); ok {
+		t.Errorf("Select out of range should return false")
+	}
+	if _, _, ok := m.Select(-1); ok {
+		t.Errorf("Select of a negative index should return false")
+	}
+}
+
+func TestTreeMapIterators(t *testing.T) {
+	m := NewTreeMap[int, string](cmp.Compare[int])
+	for _, k := range []int{30, 10, 20} {
+		m.Put(k, strings.Repeat("x", k/10))
+	}
+	var keys []int
+	var values []string
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !slices.Equal(keys, []int{10, 20, 30}) || !slices.Equal(values, []string{"x", "xx", "xxx"}) {
+		t.Errorf("All: unexpected entries %v %v", keys, values)
+	}
+	if got := slices.Collect(m.Keys()); !slices.Equal(got, keys) {
+		t.Errorf("Keys: expected %v but got %v", keys, got)
+	}
+	if got := slices.Collect(m.Values()); !slices.Equal(got, values) {
+		t.Errorf("Values: expected %v but got %v", values, got)
+	}
+	var backward []int
+	for k := range m.Backward() {
+		backward = append(backward, k)
+	}
+	if !slices.Equal(backward, []int{30, 20, 10}) {
+		t.Errorf("Backward: expected [30 20 10] but got %v", backward)
+	}
+}
@@ -0,0 +1,335 @@
+package structs
+
+import (
+	"iter"
+	"sync"
+)
+
+// TreeSet is a thread-safe sorted set of values of type T. It is built on a RedBlackTree, so
+// adding, removing and looking up a value takes O(log N) time, and, unlike HashSet, the values
+// can be visited in ascending or descending order, restricted to a range, and navigated with
+// Floor, Ceiling, Predecessor, Successor, Rank and Select.
+//
+// The order of the values is defined by a cmp function like cmp.Compare, and two values are the
+// same value when cmp returns zero for them. Unlike the RedBlackTree it is built on, which keeps
+// equal values side by side, the set holds each value at most once.
+//
+// Union, Intersection, Difference and SymmetricDifference return a new set and leave both
+// operands unchanged. Both sets are sorted, so they are combined by walking them side by side
+// rather than by looking every value up, and the tree of the result is built bottom-up from the
+// sorted values instead of by inserting them one by one, so the operations take O(N + M) time
+// in total. The other set must be ordered by the same cmp function as the set. The two sets are
+// never locked together; see the package documentation.
+type TreeSet[T any] struct {
+	mu   sync.Mutex
+	tree *RedBlackTree[T]
+	cmp  func(a, b T) int
+}
+
+// NewTreeSet creates a new TreeSet whose values are ordered by the given cmp function and adds
+// the given values to it. Repeated values are added once.
+func NewTreeSet[T any](cmp func(a, b T) int, values ...T) *TreeSet[T] {
+	s := &TreeSet[T]{tree: NewRedBlackTree(cmp), cmp: cmp}
+	for _, v := range values {
+		s.add(v)
+	}
+	return s
+}
+
+// Add adds a value to the set and reports whether it was added, which is false if an equal value
+// was already present.
+func (s *TreeSet[T]) Add(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(value)
+}
+
+// Remove removes the value equal to the given value and reports whether it was present.
+func (s *TreeSet[T]) Remove(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Delete(value)
+}
+
+// Contains reports whether a value equal to the given value is present in the set.
+func (s *TreeSet[T]) Contains(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.findNode(value) != nil
+}
+
+// Len returns the number of values in the set.
+func (s *TreeSet[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Len()
+}
+
+// IsEmpty returns true if the set holds no values, false otherwise.
+func (s *TreeSet[T]) IsEmpty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.root == nil
+}
+
+// Clear removes all values from the set.
+func (s *TreeSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree = NewRedBlackTree(s.cmp)
+}
+
+// Clone returns a new TreeSet with the same values and order as the set. Changes to either set do
+// not affect the other.
+func (s *TreeSet[T]) Clone() *TreeSet[T] {
+	return s.merge(nil, true, false, false)
+}
+
+// Min returns the smallest value in the set. The boolean result is false if the set is empty.
+func (s *TreeSet[T]) Min() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Min()
+}
+
+// Max returns the largest value in the set. The boolean result is false if the set is empty.
+func (s *TreeSet[T]) Max() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Max()
+}
+
+// Floor returns the largest value in the set that is less than or equal to the given value. The
+// boolean result is false if there is no such value.
+func (s *TreeSet[T]) Floor(value T) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Floor(value)
+}
+
+// Ceiling returns the smallest value in the set that is greater than or equal to the given value.
+// The boolean result is false if there is no such value.
+func (s *TreeSet[T]) Ceiling(value T) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Ceiling(value)
+}
+
+// Predecessor returns the largest value in the set that is strictly less than the given value,
+// which does not need to be present. The boolean result is false if there is no such value.
+func (s *TreeSet[T]) Predecessor(value T) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Predecessor(value)
+}
+
+// Successor returns the smallest value in the set that is strictly greater than the given value,
+// which does not need to be present. The boolean result is false if there is no such value.
+func (s *TreeSet[T]) Successor(value T) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Successor(value)
+}
+
+// Rank returns the number of values in the set that are strictly less than the given value. The
+// value does not need to be present. If it is present, Rank returns its zero-based position in
+// ascending order. It runs in O(log N) using the subtree sizes of the underlying tree.
+func (s *TreeSet[T]) Rank(value T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Rank(value)
+}
+
+// Select returns the k-th smallest value in the set, counting from zero. The boolean result is
+// false if k is out of range. It runs in O(log N) using the subtree sizes of the underlying tree.
+func (s *TreeSet[T]) Select(k int) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Select(k)
+}
+
+// Union returns a new set holding the values that are in the set, in other, or in both.
+func (s *TreeSet[T]) Union(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other.values(), true, true, true)
+}
+
+// Intersection returns a new set holding the values that are in both the set and other.
+func (s *TreeSet[T]) Intersection(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other.values(), false, true, false)
+}
+
+// Difference returns a new set holding the values of the set that are not in other.
+func (s *TreeSet[T]) Difference(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other.values(), true, false, false)
+}
+
+// SymmetricDifference returns a new set holding the values that are in exactly one of the set
+// and other.
+func (s *TreeSet[T]) SymmetricDifference(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other.values(), true, false, true)
+}
+
+// IsSubset reports whether every value of the set is also in other. The empty set is a subset of
+// every set, and every set is a subset of itself.
+func (s *TreeSet[T]) IsSubset(other *TreeSet[T]) bool {
+	values := s.values()
+	other.mu.Lock()
+	defer other.mu.Unlock()
+	if len(values) > other.tree.size {
+		return false
+	}
+	node := other.tree.root
+	if node != nil {
+		node = minNode(node)
+	}
+	for _, v := range values {
+		for node != nil && other.cmp(node.Value, v) < 0 {
+			node = nextNode(node)
+		}
+		if node == nil || other.cmp(node.Value, v) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether every value of other is also in the set.
+func (s *TreeSet[T]) IsSuperset(other *TreeSet[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal reports whether the set and other hold the same values.
+func (s *TreeSet[T]) Equal(other *TreeSet[T]) bool {
+	values := s.values()
+	other.mu.Lock()
+	defer other.mu.Unlock()
+	if len(values) != other.tree.size {
+		return false
+	}
+	i := 0
+	for v := range other.tree.All() {
+		if other.cmp(v, values[i]) != 0 {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// All returns an iterator over the values of the set in ascending order. The set is locked while
+// the loop runs; see the package documentation.
+func (s *TreeSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.tree.root == nil {
+			return
+		}
+		for node := minNode(s.tree.root); node != nil; node = nextNode(node) {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of the set in descending order.
+func (s *TreeSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.tree.root == nil {
+			return
+		}
+		for node := maxNode(s.tree.root); node != nil; node = prevNode(node) {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the values v with lo <= v < hi in ascending order. The first
+// value is located in O(log N) time and each further value is reached in amortized constant time.
+func (s *TreeSet[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for node := s.tree.ceilingNode(lo, true); node != nil && s.cmp(node.Value, hi) < 0; node = nextNode(node) {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// add inserts a value unless an equal value is present and reports whether it did. The caller
+// must hold the lock of the set.
+func (s *TreeSet[T]) add(value T) bool {
+	if s.tree.findNode(value) != nil {
+		return false
+	}
+	s.tree.Insert(value)
+	return true
+}
+
+// values returns a copy of the values of the set in ascending order, taken under its lock.
+func (s *TreeSet[T]) values() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make([]T, 0, s.tree.size)
+	if s.tree.root == nil {
+		return values
+	}
+	for node := minNode(s.tree.root); node != nil; node = nextNode(node) {
+		values = append(values, node.Value)
+	}
+	return values
+}
+
+// merge walks the values of the set and the sorted values of another set side by side and returns
+// a new set holding the values found only in the set if onlyHere is set, those found in both if
+// both is set, and those found only in the other set if onlyThere is set. The walk yields the
+// result in ascending order, so its tree is built directly from it in linear time.
+func (s *TreeSet[T]) merge(values []T, onlyHere, both, onlyThere bool) *TreeSet[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var merged []T
+	node := s.tree.root
+	if node != nil {
+		node = minNode(node)
+	}
+	i := 0
+	for node != nil || i < len(values) {
+		var c int
+		switch {
+		case node == nil:
+			c = 1
+		case i == len(values):
+			c = -1
+		default:
+			c = s.cmp(node.Value, values[i])
+		}
+		switch {
+		case c < 0:
+			if onlyHere {
+				merged = append(merged, node.Value)
+			}
+			node = nextNode(node)
+		case c > 0:
+			if onlyThere {
+				merged = append(merged, values[i])
+			}
+			i++
+		default:
+			if both {
+				merged = append(merged, node.Value)
+			}
+			node = nextNode(node)
+			i++
+		}
+	}
+	result := NewTreeSet(s.cmp)
+	result.tree.buildSorted(merged)
+	return result
+}
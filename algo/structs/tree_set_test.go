@@ -0,0 +1,150 @@
+package structs
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// verifyTreeSet checks that the underlying tree of a TreeSet is a valid red-black tree holding
+// every value at most once and that the iterators agree with it.
+func verifyTreeSet[T any](s *TreeSet[T]) error {
+	if err := verifyRedBlackTree(s.tree); err != nil {
+		return err
+	}
+	values := slices.Collect(s.All())
+	for i := 1; i < len(values); i++ {
+		if s.cmp(values[i-1], values[i]) >= 0 {
+			return fmt.Errorf("values are not strictly ascending at index %d", i)
+		}
+	}
+	if len(values) != s.Len() {
+		return fmt.Errorf("All() yielded %d values, but Len() = %d", len(values), s.Len())
+	}
+	backward := slices.Collect(s.Backward())
+	slices.Reverse(backward)
+	if len(backward) != len(values) {
+		return fmt.Errorf("Backward() yielded %d values, want %d", len(backward), len(values))
+	}
+	for i := range values {
+		if s.cmp(values[i], backward[i]) != 0 {
+			return fmt.Errorf("Backward() does not reverse All() at index %d", i)
+		}
+	}
+	return nil
+}
+
+func TestTreeSet(t *testing.T) {
+	s := NewTreeSet(strings.Compare, "b", "a", "b")
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", s.Len())
+	}
+	if !s.Add("c") || s.Add("c") {
+		t.Errorf("Add should return true for a new value and false for a present one")
+	}
+	if !s.Contains("c") || s.Contains("d") {
+		t.Errorf("Contains does not reflect the added values")
+	}
+	if !s.Remove("a") || s.Remove("a") {
+		t.Errorf("Remove should return true for a present value and false for a missing one")
+	}
+	if got, want := slices.Collect(s.All()), []string{"b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if err := verifyTreeSet(s); err != nil {
+		t.Fatalf("Invariant violated: %v", err)
+	}
+
+	c := s.Clone()
+	c.Add("z")
+	if s.Contains("z") || !c.Contains("z") {
+		t.Errorf("Clone should be independent of the original set")
+	}
+	if err := verifyTreeSet(c); err != nil {
+		t.Fatalf("Invariant violated: %v", err)
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || s.Len() != 0 || s.Contains("b") {
+		t.Errorf("Clear should remove all values")
+	}
+	if _, ok := s.Min(); ok {
+		t.Errorf("Min of an empty set should return false")
+	}
+}
+
+func TestTreeSetNavigation(t *testing.T) {
+	s := NewTreeSet(cmp.Compare[int], 50, 10, 40, 20, 30)
+	tests := []struct {
+		name string
+		fn   func(int) (int, bool)
+		arg  int
+		want int
+		ok   bool
+	}{
+		{"FloorPresent", s.Floor, 30, 30, true},
+		{"FloorBetween", s.Floor, 35, 30, true},
+		{"FloorBelow", s.Floor, 5, 0, false},
+		{"CeilingPresent", s.Ceiling, 30, 30, true},
+		{"CeilingBetween", s.Ceiling, 35, 40, true},
+		{"CeilingAbove", s.Ceiling, 55, 0, false},
+		{"PredecessorPresent", s.Predecessor, 30, 20, true},
+		{"PredecessorFirst", s.Predecessor, 10, 0, false},
+		{"SuccessorPresent", s.Successor, 30, 40, true},
+		{"SuccessorLast", s.Successor, 50, 0, false},
+		{"Select", s.Select, 1, 20, true},
+		{"SelectOutOfRange", s.Select, 5, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := tt.fn(tt.arg); got != tt.want || ok != tt.ok {
+				t.Errorf("(%d) = (%d, %v), want (%d, %v)", tt.arg, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	if v, ok := s.Min(); v != 10 || !ok {
+		t.Errorf("Min() = (%d, %v), want (10, true)", v, ok)
+	}
+	if v, ok := s.Max(); v != 50 || !ok {
+		t.Errorf("Max() = (%d, %v), want (50, true)", v, ok)
+	}
+	if got := s.Rank(35); got != 3 {
+		t.Errorf("Rank(35) = %d, want 3", got)
+	}
+	if got, want := slices.Collect(s.Range(20, 50)), []int{20, 30, 40}; !slices.Equal(got, want) {
+		t.Errorf("Range(20, 50) = %v, want %v", got, want)
+	}
+	if got, want := slices.Collect(s.Backward()), []int{50, 40, 30, 20, 10}; !slices.Equal(got, want) {
+		t.Errorf("Backward() = %v, want %v", got, want)
+	}
+}
+
+func TestTreeSetBuildSorted(t *testing.T) {
+	// The result of a set operation is built from its sorted values; check every size up to a few
+	// complete levels, including the perfect trees, and that the built tree accepts updates.
+	for n := 0; n <= 70; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = 2 * i
+		}
+		s := NewTreeSet(cmp.Compare[int]).Union(NewTreeSet(cmp.Compare[int], values...))
+		if err := verifyTreeSet(s); err != nil {
+			t.Fatalf("Invariant violated: %v", err)
+		}
+		if got := slices.Collect(s.All()); !slices.Equal(got, values) {
+			t.Fatalf("n=%d: All() = %v, want %v", n, got, values)
+		}
+		for i := 0; i < n; i++ {
+			s.Add(2*i + 1)
+		}
+		for i := 0; i < n; i += 3 {
+			s.Remove(2 * i)
+		}
+		if err := verifyTreeSet(s); err != nil {
+			t.Fatalf("Invariant violated: %v", err)
+		}
+	}
+}
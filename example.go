@@ -156,6 +156,18 @@ func main() {
 	}
 	fmt.Printf("Fastest: %s\n", searchBenchmark.Fastest)
 
+	// Example usage of CompareSearchAlgorithmsWorkload
+	workloadBenchmark := algo.CompareSearchAlgorithmsWorkload(sortedList, algo.SearchWorkload{
+		Queries:      500,
+		HitRatio:     0.8,
+		Distribution: algo.SkewedQueries,
+	})
+	fmt.Println("\nSearch Workload Benchmark Results:")
+	for _, result := range workloadBenchmark.Results {
+		fmt.Printf("%s: Time: %v, Memory: %d bytes, Hits: %d/%d\n", result.Algorithm, result.Time, result.Memory, result.Hits, result.Queries)
+	}
+	fmt.Printf("Fastest: %s\n", workloadBenchmark.Fastest)
+
 	// Example usage of CompareSearchAlgorithmsGeneric
 	unsortedGenericList := algo.GenerateListGeneric(1_000_000, 1, 1_000_000_000)
 	sortedGenericList := sorting.QuickSortGeneric(unsortedGenericList, func(i, j int) bool {